@@ -0,0 +1,132 @@
+package nmeatest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- Public ------------------------------------------------------------------
+
+// Checksum returns the checksum of body, the characters of a sentence (or of a tag block) between
+// its start delimiter and its "*", as two upper case hexadecimal digits, e.g. "6F".
+func Checksum(body string) string {
+	return fmt.Sprintf("%02X", checksum(body))
+}
+
+// Sentence returns the parametric ("$") sentence made up of sentenceType (element [0], e.g.
+// "GPGLL") and fields, with its correct checksum; e.g. Sentence("GPGLL", "3723.2475", "N")
+// returns "$GPGLL,3723.2475,N*31". The fields are written verbatim.
+func Sentence(sentenceType string, fields ...string) string {
+	return build("", '$', append([]string{sentenceType}, fields...))
+}
+
+// Encapsulated is like Sentence, but returns an encapsulation ("!") sentence, e.g. "!AIVDM,...".
+func Encapsulated(sentenceType string, fields ...string) string {
+	return build("", '!', append([]string{sentenceType}, fields...))
+}
+
+// TagBlock returns the tag block made up of params (e.g. "s:r3669961" and "c:1503394200"), with
+// its correct checksum and enclosing backslashes, to be prepended to a sentence.
+func TagBlock(params ...string) string {
+	body := strings.Join(params, ",")
+
+	return `\` + body + "*" + Checksum(body) + `\`
+}
+
+// SetField returns s, a sentence (which may be preceded by a tag block), with element [i] set to
+// value and its checksum recalculated; elements up to [i] are added if s has fewer. It panics if
+// s is not a sentence.
+func SetField(s string, i int, value string) string {
+	p := split(s)
+	for len(p.elements) <= i {
+		p.elements = append(p.elements, "")
+	}
+
+	p.elements[i] = value
+
+	return build(p.tagBlock, p.delimiter, p.elements)
+}
+
+// Truncate returns s, a sentence (which may be preceded by a tag block), with only its first n
+// elements (including element [0]) and its checksum recalculated, as sent by a device that omits
+// a sentence's trailing fields. It panics if s is not a sentence.
+func Truncate(s string, n int) string {
+	p := split(s)
+
+	return build(p.tagBlock, p.delimiter, p.elements[:min(max(n, 1), len(p.elements))])
+}
+
+// CorruptChecksum returns s, a sentence (which may be preceded by a tag block), with a checksum
+// that does not match it (its correct checksum with every bit inverted). It panics if s is not a
+// sentence.
+func CorruptChecksum(s string) string {
+	p := split(s)
+	body := strings.Join(p.elements, ",")
+
+	return fmt.Sprintf("%s%c%s*%02X", p.tagBlock, p.delimiter, body, ^checksum(body))
+}
+
+// StripChecksum returns s, a sentence (which may be preceded by a tag block), without its "*hh"
+// checksum. It panics if s is not a sentence.
+func StripChecksum(s string) string {
+	p := split(s)
+
+	return fmt.Sprintf("%s%c%s", p.tagBlock, p.delimiter, strings.Join(p.elements, ","))
+}
+
+// CorruptByte returns s with the lowest bit of its byte at offset inverted (so that e.g. "A"
+// becomes "@"), as line noise might, without recalculating its checksum. It panics if offset is
+// out of range.
+func CorruptByte(s string, offset int) string {
+	b := []byte(s)
+	b[offset] ^= 1
+
+	return string(b)
+}
+
+// --- Private -----------------------------------------------------------------
+
+// parts are the parts of a sentence; see split.
+type parts struct {
+	tagBlock  string   // The raw tag block, including its backslashes, or "" if there is none
+	delimiter byte     // The start delimiter
+	elements  []string // The elements, without the checksum
+}
+
+// split splits s, a sentence that may be preceded by a tag block, into its parts. It panics if s
+// is not a sentence.
+func split(s string) parts {
+	var p parts
+	if strings.HasPrefix(s, `\`) {
+		if end := strings.Index(s[1:], `\`); end >= 0 {
+			p.tagBlock, s = s[:end+2], s[end+2:]
+		}
+	}
+
+	if s == "" || (s[0] != '$' && s[0] != '!') {
+		panic(fmt.Sprintf("nmeatest: %q is not a sentence", s))
+	}
+
+	body, _, _ := strings.Cut(s[1:], "*")
+	p.delimiter, p.elements = s[0], strings.Split(body, ",")
+
+	return p
+}
+
+// build returns the sentence made up of its tag block, start delimiter and elements, with its
+// correct checksum.
+func build(tagBlock string, delimiter byte, elements []string) string {
+	body := strings.Join(elements, ",")
+
+	return fmt.Sprintf("%s%c%s*%s", tagBlock, delimiter, body, Checksum(body))
+}
+
+// checksum returns the checksum of body; see Checksum.
+func checksum(body string) byte {
+	var sum byte
+	for i := 0; i < len(body); i++ {
+		sum ^= body[i]
+	}
+
+	return sum
+}
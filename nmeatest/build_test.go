@@ -0,0 +1,106 @@
+package nmeatest
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	for input, expected := range map[string]string{
+		"":                  "00",
+		"GPTST,183730":      "66",
+		"GPGLL,3723.2475,N": "31",
+	} {
+		if actual := Checksum(input); actual != expected {
+			t.Errorf("checksum of %q should have been %q but was %q", input, expected, actual)
+		}
+	}
+}
+
+func TestBuilders(t *testing.T) {
+	for title, vec := range map[string]struct {
+		actual, expected string
+	}{
+		"Sentence":             {actual: Sentence("GPTST", "183730"), expected: "$GPTST,183730*66"},
+		"Sentence (No Fields)": {actual: Sentence("GPTST"), expected: "$GPTST*" + Checksum("GPTST")},
+		"Encapsulated": {
+			actual:   Encapsulated("GPTST", "183730"),
+			expected: "!GPTST,183730*66",
+		},
+		"TagBlock": {actual: TagBlock("s:r3669961"), expected: `\s:r3669961*0F\`},
+		"TagBlock (Several)": {
+			actual:   TagBlock("s:r3669961", "c:1503394200"),
+			expected: `\s:r3669961,c:1503394200*71\`,
+		},
+	} {
+		t.Run(title, func(t *testing.T) {
+			if vec.actual != vec.expected {
+				t.Errorf("expected %q but was %q", vec.expected, vec.actual)
+			}
+		})
+	}
+}
+
+func TestMutators(t *testing.T) {
+	const s = "$GPTST,183730*66"
+	tb := TagBlock("s:r3669961")
+
+	for title, vec := range map[string]struct {
+		actual, expected string
+	}{
+		"SetField":              {actual: SetField(s, 1, "183731"), expected: Sentence("GPTST", "183731")},
+		"SetField (Type)":       {actual: SetField(s, 0, "GPXYZ"), expected: Sentence("GPXYZ", "183730")},
+		"SetField (Beyond End)": {actual: SetField(s, 3, "A"), expected: Sentence("GPTST", "183730", "", "A")},
+		"SetField (Tag Block)": {
+			actual:   SetField(tb+s, 1, "183731"),
+			expected: tb + Sentence("GPTST", "183731"),
+		},
+		"Truncate":              {actual: Truncate(s, 1), expected: Sentence("GPTST")},
+		"Truncate (Beyond End)": {actual: Truncate(s, 5), expected: s},
+		"Truncate (Zero)":       {actual: Truncate(s, 0), expected: Sentence("GPTST")},
+		"CorruptChecksum":       {actual: CorruptChecksum(s), expected: "$GPTST,183730*99"},
+		"CorruptChecksum (Tag Block)": {
+			actual:   CorruptChecksum(tb + "!GPTST,183730*00"),
+			expected: tb + "!GPTST,183730*99",
+		},
+		"StripChecksum":             {actual: StripChecksum(s), expected: "$GPTST,183730"},
+		"StripChecksum (Tag Block)": {actual: StripChecksum(tb + s), expected: tb + "$GPTST,183730"},
+		"CorruptByte":               {actual: CorruptByte(s, 7), expected: "$GPTST,083730*66"},
+	} {
+		t.Run(title, func(t *testing.T) {
+			if vec.actual != vec.expected {
+				t.Errorf("expected %q but was %q", vec.expected, vec.actual)
+			}
+		})
+	}
+}
+
+func TestMutators_notASentence(t *testing.T) {
+	for title, mutate := range map[string]func(string) string{
+		"SetField":        func(s string) string { return SetField(s, 1, "A") },
+		"Truncate":        func(s string) string { return Truncate(s, 1) },
+		"CorruptChecksum": CorruptChecksum,
+		"StripChecksum":   StripChecksum,
+	} {
+		t.Run(title, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("expected a panic but there was none")
+				}
+			}()
+
+			mutate("GPTST,183730*66")
+		})
+	}
+}
+
+func ExampleSentence() {
+	s := Sentence("GPGLL", "3723.2475", "N", "12158.3416", "W", "161229.487", "A", "A")
+	fmt.Println(s)
+	fmt.Println(SetField(s, 6, "V"))
+	fmt.Println(CorruptChecksum(s))
+	// Output:
+	// $GPGLL,3723.2475,N,12158.3416,W,161229.487,A,A*41
+	// $GPGLL,3723.2475,N,12158.3416,W,161229.487,V,A*56
+	// $GPGLL,3723.2475,N,12158.3416,W,161229.487,A,A*BE
+}
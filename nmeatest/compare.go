@@ -0,0 +1,180 @@
+package nmeatest
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// --- Public ------------------------------------------------------------------
+
+// Diff compares expected and actual, typically two decoded sentences, field by field, and returns
+// a description of each difference, e.g. `FixTime.Hour: expected 16 but was 17`, or nil if they
+// are equal. It descends into the exported fields of structs, through pointers and interfaces,
+// and into the elements of slices, arrays and maps. Values of a type that has an Equal method
+// (such as time.Time) are compared with it; other values are compared with ==. Unexported fields
+// are ignored.
+func Diff(expected, actual any) []string {
+	var diffs []string
+	diff(&diffs, "", reflect.ValueOf(expected), reflect.ValueOf(actual))
+
+	return diffs
+}
+
+// AssertEqual reports each difference between expected and actual (see Diff) as an error of t,
+// and returns true if there are none.
+func AssertEqual(t testing.TB, expected, actual any) bool {
+	t.Helper()
+
+	diffs := Diff(expected, actual)
+	for _, d := range diffs {
+		t.Error(d)
+	}
+
+	return len(diffs) == 0
+}
+
+// --- Private -----------------------------------------------------------------
+
+// diff appends the differences between e and a, the values at path, to diffs.
+func diff(diffs *[]string, path string, e, a reflect.Value) {
+	report := func(format string, args ...any) {
+		at := path
+		if at == "" {
+			at = "value"
+		}
+
+		*diffs = append(*diffs, at+": "+fmt.Sprintf(format, args...))
+	}
+
+	switch {
+	case !e.IsValid() || !a.IsValid():
+		if e.IsValid() != a.IsValid() {
+			report("expected %s but was %s", describe(e), describe(a))
+		}
+
+		return
+	case e.Type() != a.Type():
+		report("expected a %s but was a %s", e.Type(), a.Type())
+
+		return
+	}
+
+	if equal, ok := equalMethod(e, a); ok {
+		if !equal {
+			report("expected %s but was %s", describe(e), describe(a))
+		}
+
+		return
+	}
+
+	switch e.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if e.IsNil() || a.IsNil() {
+			if e.IsNil() != a.IsNil() {
+				report("expected %s but was %s", describe(e), describe(a))
+			}
+
+			return
+		}
+
+		diff(diffs, path, e.Elem(), a.Elem())
+	case reflect.Struct:
+		for i := 0; i < e.NumField(); i++ {
+			if f := e.Type().Field(i); f.IsExported() {
+				diff(diffs, join(path, f.Name), e.Field(i), a.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if e.Len() != a.Len() {
+			report("expected %d elements but was %d: expected %s but was %s", e.Len(), a.Len(),
+				describe(e), describe(a))
+
+			return
+		}
+
+		for i := 0; i < e.Len(); i++ {
+			diff(diffs, fmt.Sprintf("%s[%d]", path, i), e.Index(i), a.Index(i))
+		}
+	case reflect.Map:
+		keys := e.MapKeys()
+		for _, k := range a.MapKeys() {
+			if !e.MapIndex(k).IsValid() {
+				keys = append(keys, k)
+			}
+		}
+
+		slices.SortFunc(keys, func(x, y reflect.Value) int {
+			return strings.Compare(fmt.Sprint(x), fmt.Sprint(y))
+		})
+
+		for _, k := range keys {
+			diff(diffs, fmt.Sprintf("%s[%#v]", path, k), e.MapIndex(k), a.MapIndex(k))
+		}
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if e.Pointer() != a.Pointer() {
+			report("expected %s but was %s", describe(e), describe(a))
+		}
+	default:
+		if e.CanInterface() && a.CanInterface() && e.Interface() != a.Interface() {
+			report("expected %s but was %s", describe(e), describe(a))
+		}
+	}
+}
+
+// equalMethod compares e and a, which have the same type, with its "Equal(T) bool" method, and
+// reports whether it has one.
+func equalMethod(e, a reflect.Value) (equal, ok bool) {
+	if !e.CanInterface() || e.Kind() == reflect.Interface {
+		return false, false
+	}
+
+	m := e.MethodByName("Equal")
+	if !m.IsValid() || m.Type().NumIn() != 1 || m.Type().In(0) != e.Type() ||
+		m.Type().NumOut() != 1 || m.Type().Out(0).Kind() != reflect.Bool {
+		return false, false
+	}
+
+	if e.Kind() == reflect.Pointer && (e.IsNil() || a.IsNil()) {
+		return e.IsNil() == a.IsNil(), true
+	}
+
+	return m.Call([]reflect.Value{a})[0].Bool(), true
+}
+
+// describe returns a description of v for a difference, e.g. `16`, `"N"` or `nil`. It uses the
+// String method of a value that has one.
+func describe(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nothing"
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map, reflect.Func,
+		reflect.Chan:
+		if v.IsNil() {
+			return "nil"
+		}
+	}
+
+	if !v.CanInterface() {
+		return v.Type().String()
+	}
+
+	if v.Kind() == reflect.String {
+		return fmt.Sprintf("%q", v.Interface())
+	}
+
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// join returns the path of the field with the specified name of the struct at path.
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
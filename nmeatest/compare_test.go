@@ -0,0 +1,132 @@
+package nmeatest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fixTime struct {
+	Hour, Minute int
+}
+
+type fix struct {
+	TalkerID   string
+	FixTime    fixTime
+	Satellites []int
+	Position   *[2]float64
+	Extra      map[string]string
+	ReceivedAt time.Time
+	Sentence   any
+	note       string
+}
+
+func TestDiff(t *testing.T) {
+	at := time.Date(2026, 3, 1, 18, 37, 30, 0, time.UTC)
+	base := func() fix {
+		return fix{
+			TalkerID:   "GP",
+			FixTime:    fixTime{Hour: 16, Minute: 12},
+			Satellites: []int{2, 7},
+			Position:   &[2]float64{37.38, -121.97},
+			Extra:      map[string]string{"a": "1"},
+			ReceivedAt: at,
+			Sentence:   fixTime{Hour: 1},
+			note:       "base",
+		}
+	}
+
+	for title, vec := range map[string]struct {
+		modify   func(*fix)
+		expected []string
+	}{
+		"Equal":            {modify: func(*fix) {}},
+		"Unexported Field": {modify: func(f *fix) { f.note = "other" }},
+		"Equal Method": {
+			modify: func(f *fix) { f.ReceivedAt = at.In(time.FixedZone("X", 3600)) },
+		},
+		"String": {
+			modify:   func(f *fix) { f.TalkerID = "GN" },
+			expected: []string{`TalkerID: expected "GP" but was "GN"`},
+		},
+		"Nested": {
+			modify:   func(f *fix) { f.FixTime.Hour = 17 },
+			expected: []string{"FixTime.Hour: expected 16 but was 17"},
+		},
+		"Slice Element": {
+			modify:   func(f *fix) { f.Satellites[1] = 9 },
+			expected: []string{"Satellites[1]: expected 7 but was 9"},
+		},
+		"Slice Length": {
+			modify:   func(f *fix) { f.Satellites = f.Satellites[:1] },
+			expected: []string{"Satellites: expected 2 elements but was 1: expected [2 7] but was [2]"},
+		},
+		"Pointer": {
+			modify:   func(f *fix) { f.Position[0] = 37.39 },
+			expected: []string{"Position[0]: expected 37.38 but was 37.39"},
+		},
+		"Nil Pointer": {
+			modify:   func(f *fix) { f.Position = nil },
+			expected: []string{"Position: expected &[37.38 -121.97] but was nil"},
+		},
+		"Map": {
+			modify: func(f *fix) { f.Extra = map[string]string{"a": "2", "b": "3"} },
+			expected: []string{
+				`Extra["a"]: expected "1" but was "2"`,
+				`Extra["b"]: expected nothing but was "3"`,
+			},
+		},
+		"Interface Type": {
+			modify:   func(f *fix) { f.Sentence = "x" },
+			expected: []string{"Sentence: expected a nmeatest.fixTime but was a string"},
+		},
+		"Several": {
+			modify: func(f *fix) { f.TalkerID, f.FixTime.Minute = "GN", 13 },
+			expected: []string{
+				`TalkerID: expected "GP" but was "GN"`,
+				"FixTime.Minute: expected 12 but was 13",
+			},
+		},
+	} {
+		t.Run(title, func(t *testing.T) {
+			actual := base()
+			vec.modify(&actual)
+
+			if diffs := Diff(base(), actual); !reflect.DeepEqual(diffs, vec.expected) {
+				t.Errorf("differences should have been %q but were %q", vec.expected, diffs)
+			}
+		})
+	}
+
+	t.Run("Pointers to Structs", func(t *testing.T) {
+		e, a := base(), base()
+		if diffs := Diff(&e, &a); diffs != nil {
+			t.Errorf("expected no differences but there were %q", diffs)
+		}
+	})
+
+	t.Run("Top Level", func(t *testing.T) {
+		expected := []string{"value: expected 1 but was 2"}
+		if diffs := Diff(1, 2); !reflect.DeepEqual(diffs, expected) {
+			t.Errorf("differences should have been %q but were %q", expected, diffs)
+		}
+	})
+}
+
+func TestAssertEqual(t *testing.T) {
+	if !AssertEqual(t, fixTime{Hour: 16}, fixTime{Hour: 16}) {
+		t.Error("expected equal values to be reported as equal")
+	}
+}
+
+func ExampleDiff() {
+	expected := fix{TalkerID: "GP", FixTime: fixTime{Hour: 16, Minute: 12}}
+	actual := fix{TalkerID: "GP", FixTime: fixTime{Hour: 17, Minute: 12}}
+
+	for _, d := range Diff(expected, actual) {
+		fmt.Println(d)
+	}
+	// Output:
+	// FixTime.Hour: expected 16 but was 17
+}
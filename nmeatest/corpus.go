@@ -0,0 +1,102 @@
+// Package nmeatest helps to test programs that process NMEA 0183 sentences. It provides a corpus
+// of sample sentences from real receivers (see Good, InvalidChecksums and Malformed), functions
+// that build sentences with correct checksums from their fields (see Sentence) or corrupt them
+// (see CorruptChecksum), and a field-by-field comparison of decoded structs (see Diff).
+//
+// The corpus is the one against which this module is tested. nmeatest does not depend on the
+// sentence packages, so it can be used to test any NMEA decoder.
+package nmeatest // import "github.com/mab-go/nmea/nmeatest"
+
+import (
+	"embed"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// --- Public ------------------------------------------------------------------
+
+// Sample is a sentence of the corpus.
+type Sample struct {
+	// Title describes the sample and its origin, e.g. "GPRMC from Garmin G12 (v 4.57)". It is
+	// unique within its set.
+	Title string `yaml:"-"`
+
+	// Sentence is the sentence itself, e.g. "$GPRMC,183729,A,...,E*6F".
+	Sentence string `yaml:"Sentence"`
+
+	// ActualChecksum is the correct checksum of the sentence (e.g. "6F"), if it has one.
+	ActualChecksum string `yaml:"ActualChecksum"`
+
+	// AdvertisedChecksum is the checksum that an InvalidChecksums sample specifies, which is not
+	// ActualChecksum.
+	AdvertisedChecksum string `yaml:"AdvertisedChecksum"`
+
+	// ErrMsg is the error with which a Malformed sample is rejected by this module's
+	// sentence.VerifyChecksum.
+	ErrMsg string `yaml:"ErrMsg"`
+}
+
+// Good returns the samples of well-formed sentences with valid checksums, ordered by title. They
+// include sentence types that this module does not decode (e.g. RMC).
+func Good() []Sample {
+	return samples("good-data")
+}
+
+// InvalidChecksums returns the samples of well-formed sentences whose checksums are wrong,
+// ordered by title.
+func InvalidChecksums() []Sample {
+	return samples("bad-invalid-checksums")
+}
+
+// Malformed returns the samples of sentences that are malformed (e.g. that have no start
+// delimiter or a truncated checksum), ordered by title.
+func Malformed() []Sample {
+	return samples("bad-malformed")
+}
+
+// --- Private -----------------------------------------------------------------
+
+//go:embed testdata/*.yaml
+var testdata embed.FS
+
+// samples returns a copy (which the caller may modify) of the sample set read from the file
+// testdata/<name>.yaml.
+func samples(name string) []Sample {
+	return append([]Sample(nil), readCorpus()[name]...)
+}
+
+// readCorpus reads the sample sets from testdata, by name, the first time it is called.
+var readCorpus = sync.OnceValue(func() map[string][]Sample {
+	entries, err := testdata.ReadDir("testdata")
+	if err != nil {
+		panic(err)
+	}
+
+	sets := make(map[string][]Sample, len(entries))
+	for _, entry := range entries {
+		contents, err := testdata.ReadFile("testdata/" + entry.Name())
+		if err != nil {
+			panic(err)
+		}
+
+		// Each file maps the samples' titles to the samples
+		var byTitle map[string]Sample
+		if err := yaml.UnmarshalStrict(contents, &byTitle); err != nil {
+			panic("nmeatest: testdata/" + entry.Name() + ": " + err.Error())
+		}
+
+		set := make([]Sample, 0, len(byTitle))
+		for title, sample := range byTitle {
+			sample.Title = title
+			set = append(set, sample)
+		}
+
+		sort.Slice(set, func(i, j int) bool { return set[i].Title < set[j].Title })
+		sets[strings.TrimSuffix(entry.Name(), ".yaml")] = set
+	}
+
+	return sets
+})
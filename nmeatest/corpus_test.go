@@ -0,0 +1,68 @@
+package nmeatest
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestCorpus(t *testing.T) {
+	for title, vec := range map[string]struct {
+		samples []Sample
+		count   int
+	}{
+		"Good":             {samples: Good(), count: 22},
+		"InvalidChecksums": {samples: InvalidChecksums(), count: 10},
+		"Malformed":        {samples: Malformed(), count: 4},
+	} {
+		t.Run(title, func(t *testing.T) {
+			if len(vec.samples) != vec.count {
+				t.Errorf("expected %d samples but there were %d", vec.count, len(vec.samples))
+			}
+
+			if !sort.SliceIsSorted(vec.samples, func(i, j int) bool {
+				return vec.samples[i].Title < vec.samples[j].Title
+			}) {
+				t.Error("expected the samples to be ordered by title")
+			}
+
+			for _, s := range vec.samples {
+				if s.Title == "" || s.Sentence == "" {
+					t.Errorf("expected sample %+v to have a title and a sentence", s)
+				}
+			}
+		})
+	}
+}
+
+func TestGood_checksums(t *testing.T) {
+	for _, s := range Good() {
+		t.Run(s.Title, func(t *testing.T) {
+			i := strings.LastIndex(s.Sentence, "*")
+			if actual := Checksum(s.Sentence[1:i]); actual != s.ActualChecksum ||
+				s.Sentence[i+1:] != actual {
+				t.Errorf("expected checksum %q but was %q", s.ActualChecksum, actual)
+			}
+		})
+	}
+}
+
+func TestInvalidChecksums_checksums(t *testing.T) {
+	for _, s := range InvalidChecksums() {
+		t.Run(s.Title, func(t *testing.T) {
+			i := strings.LastIndex(s.Sentence, "*")
+			if actual := Checksum(s.Sentence[1:i]); actual != s.ActualChecksum ||
+				s.Sentence[i+1:] != s.AdvertisedChecksum {
+				t.Errorf("expected checksum %q (advertised %q) but was %q", s.ActualChecksum,
+					s.AdvertisedChecksum, actual)
+			}
+		})
+	}
+}
+
+func TestGood_copy(t *testing.T) {
+	Good()[0].Sentence = ""
+	if Good()[0].Sentence == "" {
+		t.Error("expected modifying the returned samples not to modify the corpus")
+	}
+}
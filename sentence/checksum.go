@@ -1,57 +1,77 @@
 package sentence
 
 import (
-	"errors"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
 // --- Public ------------------------------------------------------------------
 
-// VerifyChecksum verifies the checksum of the given NMEA sentence. It returns
-// an error if the sentence's checksum is invalid.
+// VerifyChecksum verifies the checksum of the given NMEA sentence, which may be either a parametric
+// ("$") or an encapsulation ("!") sentence. It returns an error if the sentence's checksum is
+// invalid: a *BadStartDelimiterError, *MissingChecksumError, *MalformedChecksumError or
+// *ChecksumMismatchError.
 func VerifyChecksum(sentence string) error {
-	calculated := 0
+	if sentence == "" {
+		return &MissingChecksumError{}
+	}
 
-	// Iterate over each character in the sentence
-	for i, c := range sentence {
-		ch := string(c)
+	// The first character MUST be a start delimiter ("$" or "!")
+	if !isStartDelimiter(sentence[0]) {
+		r, _ := utf8.DecodeRuneInString(sentence)
 
-		// If this is the first character (char [0])...
-		if i == 0 {
-			// The first character MUST be "$"
-			if ch != "$" {
-				return fmt.Errorf("character [0] must be \"$\" but was \"%v\"", ch)
-			}
+		return &BadStartDelimiterError{Actual: string(r)}
+	}
 
-			// '$' is not used as part of the checksum calculation
-			continue
-		}
+	// The start delimiter is not used as part of the checksum calculation
+	star := strings.IndexByte(sentence, '*')
+	if star < 0 {
+		return &MissingChecksumError{}
+	}
 
-		// If this is the beginning of the checksum value ("*")...
-		if ch == "*" {
-			// There MUST be exactly two characters remaining
-			if (i + 2) != (len(sentence) - 1) {
-				return fmt.Errorf(
-					"there must be exactly 2 characters remaining after \"*\" but there was/were %v",
-					len(sentence)-i-1,
-				)
-			}
-
-			expectedHex := strings.ToUpper(fmt.Sprintf("%c%c", sentence[i+1], sentence[i+2]))
-			calculatedHex := fmt.Sprintf("%02X", calculated)
-			if calculatedHex != expectedHex {
-				return fmt.Errorf(
-					"calculated checksum value \"%v\" does not match sentence-specified value of \"%v\"",
-					calculatedHex,
-					expectedHex)
-			}
-
-			return nil // No errors
+	// There MUST be exactly two characters remaining after the checksum delimiter ("*")
+	if remaining := len(sentence) - star - 1; remaining != 2 {
+		return &MalformedChecksumError{Remaining: remaining}
+	}
+
+	calculated := Checksum(sentence[1:star])
+	if !matchesHex(sentence[star+1], sentence[star+2], calculated) {
+		return &ChecksumMismatchError{
+			Expected:   strings.ToUpper(sentence[star+1:]),
+			Calculated: fmt.Sprintf("%02X", calculated),
 		}
+	}
+
+	return nil // No errors
+}
+
+// Checksum calculates the checksum of the given sentence body: the XOR of every character between
+// (but not including) the start delimiter ("$" or "!") and the checksum delimiter ("*"). For
+// example, the checksum of "GPGLL,3723.2475,N,12158.3416,W,161229.487,A,A" is 0x41.
+func Checksum(body string) byte {
+	var calculated byte
+	for i := 0; i < len(body); i++ {
+		calculated ^= body[i]
+	}
+
+	return calculated
+}
+
+// --- Private -----------------------------------------------------------------
+
+const hexDigits = "0123456789ABCDEF"
+
+// matchesHex reports whether the hex digits hi and lo (in either case) spell out the value v.
+// Unlike formatting v, it does not allocate.
+func matchesHex(hi, lo, v byte) bool {
+	return toUpperASCII(hi) == hexDigits[v>>4] && toUpperASCII(lo) == hexDigits[v&0x0F]
+}
 
-		calculated ^= int(ch[0])
+func toUpperASCII(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - ('a' - 'A')
 	}
 
-	return errors.New("sentence does not contain a checksum")
+	return c
 }
@@ -1,34 +1,16 @@
 package sentence
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
-	"github.com/mab-go/nmea/sentence/testhelp"
+	"github.com/mab-go/nmea/nmeatest"
 )
 
-type checksumTestData struct {
-	Title, Sentence, ActualChecksum, AdvertisedChecksum, ErrMsg string
-}
-
-func mapChecksumTestData(title string, input map[string]interface{}) interface{} {
-	return checksumTestData{
-		Title:              title,
-		Sentence:           testhelp.EnsureString(input["Sentence"]),
-		ActualChecksum:     testhelp.OptString(input["ActualChecksum"]),
-		AdvertisedChecksum: testhelp.OptString(input["AdvertisedChecksum"]),
-		ErrMsg:             testhelp.OptString(input["ErrMsg"]),
-	}
-}
-
-func sortChecksumTestData(result []interface{}, i, j int) bool {
-	return result[i].(checksumTestData).Title < result[j].(checksumTestData).Title
-}
-
 func TestVerifyChecksum_goodData(t *testing.T) {
-	for _, data := range testhelp.ReadTestData("good-data", mapChecksumTestData, sortChecksumTestData) {
-		d := data.(checksumTestData)
-
+	for _, d := range nmeatest.Good() {
 		t.Run(d.Title, func(t *testing.T) {
 			err := VerifyChecksum(d.Sentence)
 			if err != nil {
@@ -39,9 +21,7 @@ func TestVerifyChecksum_goodData(t *testing.T) {
 }
 
 func TestVerifyChecksum_invalidChecksums(t *testing.T) {
-	for _, data := range testhelp.ReadTestData("bad-invalid-checksums", mapChecksumTestData, sortChecksumTestData) {
-		d := data.(checksumTestData)
-
+	for _, d := range nmeatest.InvalidChecksums() {
 		t.Run(d.Title, func(t *testing.T) {
 			err := VerifyChecksum(d.Sentence)
 			if err == nil {
@@ -60,9 +40,7 @@ func TestVerifyChecksum_invalidChecksums(t *testing.T) {
 }
 
 func TestVerifyChecksum_malformedData(t *testing.T) {
-	for _, data := range testhelp.ReadTestData("bad-malformed", mapChecksumTestData, sortChecksumTestData) {
-		d := data.(checksumTestData)
-
+	for _, d := range nmeatest.Malformed() {
 		t.Run(d.Title, func(t *testing.T) {
 			err := VerifyChecksum(d.Sentence)
 			if err == nil {
@@ -76,6 +54,38 @@ func TestVerifyChecksum_malformedData(t *testing.T) {
 	}
 }
 
+func TestVerifyChecksum_errorTypes(t *testing.T) {
+	t.Run("Checksum Mismatch", func(t *testing.T) {
+		err := VerifyChecksum("$GPGGA,174800.864,4002.741,N,07618.550,W,1,12,1.0,0.0,M,0.0,M,,*24")
+
+		var mismatch *ChecksumMismatchError
+		if !errors.As(err, &mismatch) {
+			t.Fatalf("expected a *ChecksumMismatchError but was %v", err)
+		}
+
+		expected := ChecksumMismatchError{Expected: "24", Calculated: "70"}
+		if *mismatch != expected {
+			t.Errorf("error should have been %+v but was %+v", expected, *mismatch)
+		}
+	})
+
+	for title, vec := range map[string]struct {
+		input    string
+		sentinel error
+	}{
+		"Missing Checksum":    {input: "$GPGLL,3723.2475,N", sentinel: ErrMissingChecksum},
+		"Malformed Checksum":  {input: "$GPGLL,3723.2475,N*4", sentinel: ErrMalformedChecksum},
+		"Bad Start Delimiter": {input: "#GPGLL,3723.2475,N*41", sentinel: ErrBadStartDelimiter},
+		"Checksum Mismatch":   {input: "$GPGLL,3723.2475,N*00", sentinel: ErrChecksumMismatch},
+	} {
+		t.Run(title+" (Sentinel)", func(t *testing.T) {
+			if err := VerifyChecksum(vec.input); !errors.Is(err, vec.sentinel) {
+				t.Errorf("expected an error matching %q but was %v", vec.sentinel, err)
+			}
+		})
+	}
+}
+
 func ExampleVerifyChecksum_validSentence() {
 	err := VerifyChecksum("$GPGGA,174800.864,4002.741,N,07618.550,W,1,12,1.0,0.0,M,0.0,M,,*70")
 	fmt.Printf("err == %v", err)
@@ -89,3 +99,30 @@ func ExampleVerifyChecksum_invalidSentence() {
 	// Output:
 	// err == calculated checksum value "70" does not match sentence-specified value of "24"
 }
+
+func TestChecksum(t *testing.T) {
+	for _, d := range nmeatest.Good() {
+		t.Run(d.Title, func(t *testing.T) {
+			body := d.Sentence[1:strings.LastIndex(d.Sentence, "*")]
+			actual := fmt.Sprintf("%02X", Checksum(body))
+			if actual != d.ActualChecksum {
+				t.Errorf("checksum should have been %q but was %q", d.ActualChecksum, actual)
+			}
+		})
+	}
+}
+
+func BenchmarkVerifyChecksum(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := VerifyChecksum("$GPGLL,3723.2475,N,12158.3416,W,161229.487,A,A*41"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func ExampleChecksum() {
+	fmt.Printf("%02X", Checksum("GPGLL,3723.2475,N,12158.3416,W,161229.487,A,A"))
+	// Output:
+	// 41
+}
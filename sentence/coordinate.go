@@ -0,0 +1,479 @@
+package sentence
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// --- Public ------------------------------------------------------------------
+
+// NorthSouth indicates the hemisphere in which a latitude value resides. It can be either
+// "N" or "S".
+type NorthSouth int
+
+const (
+	// North represents the northern hemisphere.
+	North NorthSouth = iota + 1 // N
+
+	// South represents the southern hemisphere.
+	South // S
+)
+
+// EastWest indicates the hemisphere in which a longitude value resides. It can be either
+// "E" or "W".
+type EastWest int
+
+const (
+	// East represents the eastern hemisphere.
+	East EastWest = iota + 1 // E
+
+	// West represents the western hemisphere.
+	West // W
+)
+
+//go:generate go run github.com/dmarkham/enumer@v1.6.3 -type=NorthSouth,EastWest -json -text -values -linecomment -transform=first-upper -output=enum_gen.go
+
+// Coordinate is the unsigned value of a latitude or longitude exactly as it is written in a
+// sentence, in the (d)ddmm.mmmm format: whole degrees, and minutes as a fixed-point number with
+// the number of decimal places that the sender used. For example, "5106.7198674" is
+// Coordinate{Degrees: 51, Minutes: 67198674, Decimals: 7, IntegerDigits: 4}. Unlike a float64, it
+// keeps the sender's precision, its trailing zeros and its leading zeros (or their absence), so
+// that a decoded coordinate is encoded again exactly as it was received.
+type Coordinate struct {
+	// Degrees is the whole degrees.
+	Degrees int
+
+	// Minutes is the minutes multiplied by 10 to the power of Decimals, e.g. 67198674 for
+	// 6.7198674' with 7 decimal places.
+	Minutes int64
+
+	// Decimals is the number of decimal places of the minutes, from 0 to MaxCoordinateDecimals.
+	Decimals int
+
+	// IntegerDigits is the number of digits before the decimal point (those of the degrees,
+	// including any leading zeros, and the two of the whole minutes), from 2 to 5, e.g. 5 for
+	// "00833.9159" or 3 for "833.9159". If it is 0, the degrees are zero-padded to the usual
+	// width: two digits for a latitude and three for a longitude.
+	IntegerDigits int
+}
+
+// MaxCoordinateDecimals is the largest number of decimal places of the minutes of a Coordinate.
+const MaxCoordinateDecimals = 15
+
+// ParseCoordinate parses s, an unsigned latitude or longitude value in the (d)ddmm.mmmm format
+// (e.g. "3723.2475"), as a Coordinate. It checks only the format of s, not the range of its
+// degrees and minutes (see SegmentParser.AsLatitude and SegmentParser.AsLongitude).
+func ParseCoordinate(s string) (Coordinate, error) {
+	intPart, fracPart, hasFrac := strings.Cut(s, ".")
+	if len(intPart) < 2 || len(intPart) > 5 || !isDigits(intPart) || !isDigits(fracPart) ||
+		(hasFrac && fracPart == "") || len(fracPart) > MaxCoordinateDecimals {
+		return Coordinate{}, fmt.Errorf("\"%s\" is not a (d)ddmm.mmmm value", s)
+	}
+
+	minutes := digitsValue(intPart[len(intPart)-2:])*pow10[len(fracPart)] + digitsValue(fracPart)
+
+	return Coordinate{
+		Degrees:       int(digitsValue(intPart[:len(intPart)-2])),
+		Minutes:       minutes,
+		Decimals:      len(fracPart),
+		IntegerDigits: len(intPart),
+	}, nil
+}
+
+// CoordinateFromDegrees returns the Coordinate of d unsigned decimal degrees, with the minutes
+// rounded to the specified number of decimal places (from 0 to MaxCoordinateDecimals).
+func CoordinateFromDegrees(d float64, decimals int) Coordinate {
+	decimals = min(max(decimals, 0), MaxCoordinateDecimals)
+	degrees := math.Floor(d)
+	minutes := int64(math.Round((d - degrees) * 60 * float64(pow10[decimals])))
+	if minutes >= 60*pow10[decimals] { // Rounded up to a whole degree
+		degrees++
+		minutes -= 60 * pow10[decimals]
+	}
+
+	return Coordinate{Degrees: int(degrees), Minutes: minutes, Decimals: decimals}
+}
+
+// DecimalDegrees returns the coordinate in unsigned decimal degrees, e.g. 37.387458 for
+// "3723.2475".
+func (c Coordinate) DecimalDegrees() float64 {
+	return float64(c.Degrees) + float64(c.Minutes)/float64(pow10[c.Decimals])/60
+}
+
+// DMS returns the whole degrees, the whole minutes and the seconds of the coordinate, e.g. 37, 23
+// and 14.85 for "3723.2475".
+func (c Coordinate) DMS() (degrees, minutes int, seconds float64) {
+	scale := pow10[c.Decimals]
+
+	return c.Degrees, int(c.Minutes / scale), float64(c.Minutes%scale) / float64(scale) * 60
+}
+
+// Latitude is the latitude of a position as it appears in a sentence: a pair of segments holding
+// an unsigned value in the ddmm.mmmm format (e.g. "3723.2475" for 37° 23.2475') and its
+// hemisphere (e.g. "N"). Valid is false if both segments were empty, as they are in a sentence
+// from a receiver that has no fix.
+type Latitude struct {
+	// Value is the latitude as it was written, without its sign; the hemisphere gives its sign.
+	Value Coordinate
+
+	// Hemisphere is the hemisphere in which the latitude resides.
+	Hemisphere NorthSouth
+
+	// Valid is false if the latitude was empty.
+	Valid bool
+}
+
+// NewLatitude returns a valid Latitude of c in hemisphere h.
+func NewLatitude(c Coordinate, h NorthSouth) Latitude {
+	return Latitude{Value: c, Hemisphere: h, Valid: true}
+}
+
+// ParseLatitude parses s, a latitude in the form of its two segments (e.g. "3723.2475,N"), as a
+// Latitude. As for SegmentParser.AsLatitude, "," is an invalid Latitude, and the latitude can be
+// at most 90 degrees.
+func ParseLatitude(s string) (Latitude, error) {
+	p := &SegmentParser{segments: []string{"", "", ""}}
+	p.segments[1], p.segments[2], _ = strings.Cut(s, ",")
+	l := p.AsLatitude(1)
+
+	return l, coordinateError(s, "latitude", p.Err())
+}
+
+// MustParseLatitude is like ParseLatitude but panics if s is not a valid latitude. It simplifies
+// the initialization of variables (e.g. in tests) that hold latitudes.
+func MustParseLatitude(s string) Latitude {
+	l, err := ParseLatitude(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return l
+}
+
+// LatitudeFromDegrees returns a valid Latitude of d signed decimal degrees (negative in the
+// southern hemisphere), with the minutes rounded to the specified number of decimal places. For
+// example, LatitudeFromDegrees(37.387458, 4) is "3723.2475,N".
+func LatitudeFromDegrees(d float64, decimals int) Latitude {
+	h := North
+	if d < 0 {
+		h = South
+	}
+
+	return NewLatitude(CoordinateFromDegrees(math.Abs(d), decimals), h)
+}
+
+// Degrees returns the latitude in signed decimal degrees: negative in the southern hemisphere.
+// It returns 0 if l is not valid.
+func (l Latitude) Degrees() float64 {
+	if !l.Valid {
+		return 0
+	}
+
+	if l.Hemisphere == South {
+		return -l.Value.DecimalDegrees()
+	}
+
+	return l.Value.DecimalDegrees()
+}
+
+// DMS returns the unsigned degrees, minutes and seconds of the latitude (see Hemisphere for its
+// sign), e.g. 37, 23 and 14.85 for "3723.2475,N".
+func (l Latitude) DMS() (degrees, minutes int, seconds float64) {
+	return l.Value.DMS()
+}
+
+// String returns the latitude as its two segments are written in a sentence, e.g.
+// "3723.2475,N", or "," if l is not valid.
+func (l Latitude) String() string {
+	if !l.Valid {
+		return ","
+	}
+
+	return formatCoordinate(l.Value, 2) + "," + l.Hemisphere.String()
+}
+
+// Longitude is the longitude of a position as it appears in a sentence: a pair of segments
+// holding an unsigned value in the dddmm.mmmm format (e.g. "12158.3416" for 121° 58.3416') and
+// its hemisphere (e.g. "W"). Valid is false if both segments were empty, as they are in a
+// sentence from a receiver that has no fix.
+type Longitude struct {
+	// Value is the longitude as it was written, without its sign; the hemisphere gives its sign.
+	Value Coordinate
+
+	// Hemisphere is the hemisphere in which the longitude resides.
+	Hemisphere EastWest
+
+	// Valid is false if the longitude was empty.
+	Valid bool
+}
+
+// NewLongitude returns a valid Longitude of c in hemisphere h.
+func NewLongitude(c Coordinate, h EastWest) Longitude {
+	return Longitude{Value: c, Hemisphere: h, Valid: true}
+}
+
+// ParseLongitude parses s, a longitude in the form of its two segments (e.g. "12158.3416,W"), as
+// a Longitude. As for SegmentParser.AsLongitude, "," is an invalid Longitude, and the longitude
+// can be at most 180 degrees.
+func ParseLongitude(s string) (Longitude, error) {
+	p := &SegmentParser{segments: []string{"", "", ""}}
+	p.segments[1], p.segments[2], _ = strings.Cut(s, ",")
+	l := p.AsLongitude(1)
+
+	return l, coordinateError(s, "longitude", p.Err())
+}
+
+// MustParseLongitude is like ParseLongitude but panics if s is not a valid longitude. It
+// simplifies the initialization of variables (e.g. in tests) that hold longitudes.
+func MustParseLongitude(s string) Longitude {
+	l, err := ParseLongitude(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return l
+}
+
+// LongitudeFromDegrees returns a valid Longitude of d signed decimal degrees (negative in the
+// western hemisphere), with the minutes rounded to the specified number of decimal places. For
+// example, LongitudeFromDegrees(-121.972360, 4) is "12158.3416,W".
+func LongitudeFromDegrees(d float64, decimals int) Longitude {
+	h := East
+	if d < 0 {
+		h = West
+	}
+
+	return NewLongitude(CoordinateFromDegrees(math.Abs(d), decimals), h)
+}
+
+// Degrees returns the longitude in signed decimal degrees: negative in the western hemisphere.
+// It returns 0 if l is not valid.
+func (l Longitude) Degrees() float64 {
+	if !l.Valid {
+		return 0
+	}
+
+	if l.Hemisphere == West {
+		return -l.Value.DecimalDegrees()
+	}
+
+	return l.Value.DecimalDegrees()
+}
+
+// DMS returns the unsigned degrees, minutes and seconds of the longitude (see Hemisphere for its
+// sign), e.g. 121, 58 and 20.496 for "12158.3416,W".
+func (l Longitude) DMS() (degrees, minutes int, seconds float64) {
+	return l.Value.DMS()
+}
+
+// String returns the longitude as its two segments are written in a sentence, e.g.
+// "12158.3416,W", or "," if l is not valid.
+func (l Longitude) String() string {
+	if !l.Valid {
+		return ","
+	}
+
+	return formatCoordinate(l.Value, 3) + "," + l.Hemisphere.String()
+}
+
+// AsLatitude parses the input segments at the specified index and the next one as a Latitude: a
+// value in the ddmm.mmmm format of at most 90 degrees and its hemisphere. If both segments are
+// empty, it returns an invalid Latitude. If p.Err() is not nil, this function returns a zero
+// Latitude and leaves the error unchanged.
+func (p *SegmentParser) AsLatitude(i int8) Latitude {
+	c, s, ok := p.asCoordinate(i, "Latitude", 90)
+	if !ok {
+		return Latitude{}
+	}
+
+	h, err := NorthSouthString(s)
+	if err != nil || !p.AcceptsCase(s, h.String()) {
+		p.Fail(i+1, "NorthSouth", fmt.Sprintf("must be parsable as a NorthSouth but was \"%s\"", s))
+
+		return Latitude{}
+	}
+
+	return NewLatitude(c, h)
+}
+
+// AsLongitude parses the input segments at the specified index and the next one as a Longitude:
+// a value in the dddmm.mmmm format of at most 180 degrees and its hemisphere. If both segments
+// are empty, it returns an invalid Longitude. If p.Err() is not nil, this function returns a
+// zero Longitude and leaves the error unchanged.
+func (p *SegmentParser) AsLongitude(i int8) Longitude {
+	c, s, ok := p.asCoordinate(i, "Longitude", 180)
+	if !ok {
+		return Longitude{}
+	}
+
+	h, err := EastWestString(s)
+	if err != nil || !p.AcceptsCase(s, h.String()) {
+		p.Fail(i+1, "EastWest", fmt.Sprintf("must be parsable as an EastWest but was \"%s\"", s))
+
+		return Longitude{}
+	}
+
+	return NewLongitude(c, h)
+}
+
+// WriteLatitude appends l as the next two segments: its value, with the degrees zero-padded to
+// their recorded number of digits or, if there is none, two digits (e.g. "0510.5") and the
+// minutes written with their recorded number of decimal places, and its hemisphere. Both segments
+// are empty if l is not valid. An invalid value or hemisphere is reported as an *EncodingError by
+// Sentence.
+func (w *SegmentWriter) WriteLatitude(l Latitude) {
+	w.writeCoordinate(l.Value, 2, 90, l.Valid)
+	if l.Valid {
+		w.WriteEnum(l.Hemisphere, l.Hemisphere.IsANorthSouth())
+	} else {
+		w.WriteString("")
+	}
+}
+
+// WriteLongitude appends l as the next two segments: its value, with the degrees zero-padded to
+// their recorded number of digits or, if there is none, three digits (e.g. "07618.55") and the
+// minutes written with their recorded number of decimal places, and its hemisphere. Both segments
+// are empty if l is not valid. An invalid value or hemisphere is reported as an *EncodingError by
+// Sentence.
+func (w *SegmentWriter) WriteLongitude(l Longitude) {
+	w.writeCoordinate(l.Value, 3, 180, l.Valid)
+	if l.Valid {
+		w.WriteEnum(l.Hemisphere, l.Hemisphere.IsAEastWest())
+	} else {
+		w.WriteString("")
+	}
+}
+
+// --- Private -----------------------------------------------------------------
+
+// pow10 holds the powers of 10 by which the minutes of a Coordinate can be scaled.
+var pow10 = [MaxCoordinateDecimals + 1]int64{
+	1, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10, 1e11, 1e12, 1e13, 1e14, 1e15,
+}
+
+// asCoordinate parses the value segment of a latitude or longitude (named name) at index i,
+// checking that it has at most limit degrees and fewer than 60 minutes, and returns it along with
+// the (unparsed) hemisphere segment that follows it. It returns false if p.Err() is not nil, if
+// the value is invalid, or if both segments are empty.
+func (p *SegmentParser) asCoordinate(i int8, name string, limit int) (Coordinate, string, bool) {
+	s, ok := p.Segment(i)
+	if !ok {
+		return Coordinate{}, "", false
+	}
+
+	h, ok := p.Segment(i + 1)
+	if !ok || (s == "" && h == "") {
+		return Coordinate{}, "", false // There's already an error, or the coordinate is empty.
+	}
+
+	c, err := ParseCoordinate(s)
+	if err != nil {
+		p.Fail(i, name, fmt.Sprintf("must be parsable as %s %s but was \"%s\"",
+			indefiniteArticle(name), name, s))
+
+		return Coordinate{}, "", false
+	}
+
+	if !c.inRange(limit) {
+		p.Fail(i, name, fmt.Sprintf("must be %s %s of at most %d degrees with fewer than 60 "+
+			"minutes but was \"%s\"", indefiniteArticle(name), name, limit, s))
+
+		return Coordinate{}, "", false
+	}
+
+	return c, h, true
+}
+
+// inRange reports whether c is a valid coordinate of at most maxDegrees degrees.
+func (c Coordinate) inRange(maxDegrees int) bool {
+	if c.Decimals < 0 || c.Decimals > MaxCoordinateDecimals || c.Degrees < 0 || c.Minutes < 0 {
+		return false
+	}
+
+	if c.IntegerDigits != 0 && (c.IntegerDigits < 2 || c.IntegerDigits > 5) {
+		return false
+	}
+
+	if c.Minutes >= 60*pow10[c.Decimals] {
+		return false
+	}
+
+	return c.Degrees < maxDegrees || (c.Degrees == maxDegrees && c.Minutes == 0)
+}
+
+// coordinateError turns err, the error of parsing s as a latitude or longitude (named name), into
+// an error that does not refer to sentence segments.
+func coordinateError(s, name string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("\"%s\" is not a valid %s (ddmm.mmmm,h)", s, name)
+}
+
+// writeCoordinate appends c, a latitude or longitude value, as the next segment (or an empty
+// segment if valid is false). The degrees are left-padded with zeros to degreeDigits digits, and
+// must be at most maxDegrees.
+func (w *SegmentWriter) writeCoordinate(c Coordinate, degreeDigits, maxDegrees int, valid bool) {
+	if w.err != nil {
+		return
+	}
+
+	if !valid {
+		w.append("")
+
+		return
+	}
+
+	if !c.inRange(maxDegrees) {
+		w.setErr(fmt.Sprintf("must be a valid (d)ddmm.mmmm value but was %+v", c))
+
+		return
+	}
+
+	w.append(formatCoordinate(c, degreeDigits))
+}
+
+// formatCoordinate formats c, a latitude or longitude value, with its degrees left-padded with
+// zeros to their recorded number of digits (or, if there is none, to degreeDigits digits) and its
+// minutes written with their recorded number of decimal places.
+func formatCoordinate(c Coordinate, degreeDigits int) string {
+	if c.IntegerDigits != 0 {
+		degreeDigits = c.IntegerDigits - 2
+	}
+
+	var s string
+	if c.Degrees != 0 || degreeDigits > 0 {
+		s = fmt.Sprintf("%0*d", degreeDigits, c.Degrees)
+	}
+
+	scale := pow10[min(max(c.Decimals, 0), MaxCoordinateDecimals)]
+	s += fmt.Sprintf("%02d", c.Minutes/scale)
+	if c.Decimals > 0 {
+		s += fmt.Sprintf(".%0*d", c.Decimals, c.Minutes%scale)
+	}
+
+	return s
+}
+
+// digitsValue returns the value of s, a string of at most 18 ASCII digits (0 for an empty s).
+func digitsValue(s string) int64 {
+	var v int64
+	for i := 0; i < len(s); i++ {
+		v = v*10 + int64(s[i]-'0')
+	}
+
+	return v
+}
+
+// isDigits reports whether s consists only of ASCII digits (it is true for an empty s).
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,285 @@
+package sentence
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestSegmentParser_AsLatitude(t *testing.T) {
+	p := mustParse(t)
+
+	actual := p.AsLatitude(2)
+	if err := p.Err(); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+
+	expected := NewLatitude(Coordinate{Degrees: 39, Minutes: 7356, Decimals: 3, IntegerDigits: 4}, North)
+	if actual != expected {
+		t.Errorf("expected %+v but was %+v", expected, actual)
+	}
+
+	if lon := p.AsLongitude(4); lon != MustParseLongitude("12102.482,W") {
+		t.Errorf("expected longitude 12102.482 W but was %+v", lon)
+	}
+}
+
+func TestSegmentParser_AsLatitude_empty(t *testing.T) {
+	p := &SegmentParser{}
+	if err := p.ParseWithOptions("$GPGLL,,,,,161229.487,V,N", WithChecksumOptional()); err != nil {
+		t.Fatalf("failed to parse sentence: %v", err)
+	}
+
+	if lat, lon := p.AsLatitude(1), p.AsLongitude(3); lat.Valid || lon.Valid {
+		t.Errorf("expected an invalid latitude and longitude but were %+v and %+v", lat, lon)
+	}
+
+	if err := p.Err(); err != nil {
+		t.Errorf("expected no error but got %v", err)
+	}
+}
+
+func TestSegmentParser_AsLatitude_errors(t *testing.T) {
+	for title, vec := range map[string]struct {
+		segments string
+		errMsg   string
+	}{
+		"Bad Value": {
+			segments: "X,N",
+			errMsg:   "sentence segment [1] must be parsable as a Latitude but was \"X\"",
+		},
+		"Negative Value": {
+			segments: "-3723.2475,N",
+			errMsg:   "sentence segment [1] must be parsable as a Latitude but was \"-3723.2475\"",
+		},
+		"Too Many Degrees": {
+			segments: "9000.5,N",
+			errMsg: "sentence segment [1] must be a Latitude of at most 90 degrees with fewer " +
+				"than 60 minutes but was \"9000.5\"",
+		},
+		"Too Many Minutes": {
+			segments: "3760.0,N",
+			errMsg: "sentence segment [1] must be a Latitude of at most 90 degrees with fewer " +
+				"than 60 minutes but was \"3760.0\"",
+		},
+		"Missing Value": {
+			segments: ",N",
+			errMsg:   "sentence segment [1] must be parsable as a Latitude but was \"\"",
+		},
+		"Missing Hemisphere": {
+			segments: "3723.2475,",
+			errMsg:   "sentence segment [2] must be parsable as a NorthSouth but was \"\"",
+		},
+		"Bad Hemisphere": {
+			segments: "3723.2475,E",
+			errMsg:   "sentence segment [2] must be parsable as a NorthSouth but was \"E\"",
+		},
+	} {
+		t.Run(title, func(t *testing.T) {
+			p := &SegmentParser{}
+			if err := p.ParseWithOptions("$GPGLL,"+vec.segments, WithChecksumOptional()); err != nil {
+				t.Fatalf("failed to parse sentence: %v", err)
+			}
+
+			if lat := p.AsLatitude(1); lat != (Latitude{}) {
+				t.Errorf("expected a zero Latitude but was %+v", lat)
+			}
+
+			if err := p.Err(); err == nil || err.Error() != vec.errMsg {
+				t.Errorf("expected error %q but was %v", vec.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestSegmentParser_AsLongitude_errors(t *testing.T) {
+	p := &SegmentParser{}
+	if err := p.ParseWithOptions("$GPGLL,18000.01,W", WithChecksumOptional()); err != nil {
+		t.Fatalf("failed to parse sentence: %v", err)
+	}
+
+	p.AsLongitude(1)
+	expected := "sentence segment [1] must be a Longitude of at most 180 degrees with fewer than " +
+		"60 minutes but was \"18000.01\""
+	if err := p.Err(); err == nil || err.Error() != expected {
+		t.Errorf("expected error %q but was %v", expected, err)
+	}
+
+	p = &SegmentParser{}
+	if err := p.ParseWithOptions("$GPGLL,12158.3416,N", WithChecksumOptional()); err != nil {
+		t.Fatalf("failed to parse sentence: %v", err)
+	}
+
+	p.AsLongitude(1)
+	expected = "sentence segment [2] must be parsable as an EastWest but was \"N\""
+	if err := p.Err(); err == nil || err.Error() != expected {
+		t.Errorf("expected error %q but was %v", expected, err)
+	}
+}
+
+func TestParseCoordinate(t *testing.T) {
+	for input, expected := range map[string]Coordinate{
+		"5106.7198674": {Degrees: 51, Minutes: 67198674, Decimals: 7, IntegerDigits: 4},
+		"00833.91560":  {Degrees: 8, Minutes: 3391560, Decimals: 5, IntegerDigits: 5},
+		"833.9159":     {Degrees: 8, Minutes: 339159, Decimals: 4, IntegerDigits: 3},
+		"3723":         {Degrees: 37, Minutes: 23, IntegerDigits: 4},
+		"05":           {Minutes: 5, IntegerDigits: 2},
+	} {
+		if actual, err := ParseCoordinate(input); err != nil || actual != expected {
+			t.Errorf("expected %q to be %+v but was %+v (%v)", input, expected, actual, err)
+		}
+	}
+
+	for _, input := range []string{"", "5", "37a3.5", "3723.", "3723.5.5", "-3723.5", "123456.7",
+		"3723.1234567890123456"} {
+		if _, err := ParseCoordinate(input); err == nil {
+			t.Errorf("expected %q not to be parsable", input)
+		}
+	}
+}
+
+func TestLatitude_roundTrip(t *testing.T) {
+	for _, input := range []string{"5106.7198674,N", "4717.11360,S", "0510.5,N", "510.5,N", "00510.5,N",
+		"05,N", "9000,S", ","} {
+		lat, err := ParseLatitude(input)
+		if err != nil {
+			t.Fatalf("ParseLatitude(%q) failed: %v", input, err)
+		}
+
+		if actual := lat.String(); actual != input {
+			t.Errorf("expected %q but was %q", input, actual)
+		}
+	}
+
+	for _, input := range []string{"9000.1,N", "3760,N", "3723.2475,E", "3723.2475"} {
+		if _, err := ParseLatitude(input); err == nil {
+			t.Errorf("expected %q not to be a valid latitude", input)
+		}
+	}
+}
+
+func TestLongitude_roundTrip(t *testing.T) {
+	for _, input := range []string{"12158.3416,W", "00833.9159,E", "833.9159,E", "0833.9159,E", "33.9159,E",
+		","} {
+		lon, err := ParseLongitude(input)
+		if err != nil {
+			t.Fatalf("ParseLongitude(%q) failed: %v", input, err)
+		}
+
+		if actual := lon.String(); actual != input {
+			t.Errorf("expected %q but was %q", input, actual)
+		}
+	}
+}
+
+func TestLatitude_conversions(t *testing.T) {
+	lat := MustParseLatitude("3723.2475,S")
+	if d := lat.Degrees(); math.Abs(d-(-37.387458)) > 1e-6 {
+		t.Errorf("expected -37.387458 degrees but was %v", d)
+	}
+
+	if deg, min, sec := lat.DMS(); deg != 37 || min != 23 || math.Abs(sec-14.85) > 1e-9 {
+		t.Errorf("expected 37° 23' 14.85\" but was %d° %d' %v\"", deg, min, sec)
+	}
+
+	expected := lat
+	expected.Value.IntegerDigits = 0 // LatitudeFromDegrees records no number of integer digits
+	if back := LatitudeFromDegrees(lat.Degrees(), 4); back != expected {
+		t.Errorf("expected %+v but was %+v", expected, back)
+	}
+
+	if (Latitude{}).Degrees() != 0 || (Latitude{}).String() != "," {
+		t.Errorf("expected an invalid Latitude to be 0 degrees and \",\"")
+	}
+}
+
+func TestLongitude_conversions(t *testing.T) {
+	lon := MustParseLongitude("00833.91565,W")
+	if d := lon.Degrees(); math.Abs(d-(-8.565261)) > 1e-6 {
+		t.Errorf("expected -8.565261 degrees but was %v", d)
+	}
+
+	if deg, min, sec := lon.DMS(); deg != 8 || min != 33 || math.Abs(sec-54.939) > 1e-9 {
+		t.Errorf("expected 8° 33' 54.939\" but was %d° %d' %v\"", deg, min, sec)
+	}
+
+	expected := lon
+	expected.Value.IntegerDigits = 0 // LongitudeFromDegrees records no number of integer digits
+	if back := LongitudeFromDegrees(lon.Degrees(), 5); back != expected {
+		t.Errorf("expected %+v but was %+v", expected, back)
+	}
+
+	if back := LongitudeFromDegrees(7.99999999, 2); back.String() != "00800.00,E" {
+		t.Errorf("expected minutes that round up to 60 to carry but was %q", back)
+	}
+}
+
+func TestSegmentWriter_WriteLatitude(t *testing.T) {
+	for _, vec := range []struct {
+		title    string
+		write    func(w *SegmentWriter)
+		expected []string
+	}{
+		{
+			title:    "Latitude",
+			write:    func(w *SegmentWriter) { w.WriteLatitude(MustParseLatitude("4002.7410,N")) },
+			expected: []string{"4002.7410", "N"},
+		},
+		{
+			title: "Latitude (Padded)",
+			write: func(w *SegmentWriter) {
+				w.WriteLatitude(NewLatitude(Coordinate{Degrees: 5, Minutes: 105, Decimals: 1}, South))
+			},
+			expected: []string{"0510.5", "S"},
+		},
+		{
+			title:    "Latitude (Unpadded)",
+			write:    func(w *SegmentWriter) { w.WriteLatitude(MustParseLatitude("510.5,S")) },
+			expected: []string{"510.5", "S"},
+		},
+		{
+			title:    "Latitude (Empty)",
+			write:    func(w *SegmentWriter) { w.WriteLatitude(Latitude{}) },
+			expected: []string{"", ""},
+		},
+		{
+			title: "Longitude (Padded)",
+			write: func(w *SegmentWriter) {
+				w.WriteLongitude(NewLongitude(Coordinate{Degrees: 76, Minutes: 1855, Decimals: 2}, West))
+			},
+			expected: []string{"07618.55", "W"},
+		},
+		{
+			title:    "Longitude (Over-Padded)",
+			write:    func(w *SegmentWriter) { w.WriteLongitude(MustParseLongitude("00833.9159,E")) },
+			expected: []string{"00833.9159", "E"},
+		},
+	} {
+		t.Run(vec.title, func(t *testing.T) {
+			w := &SegmentWriter{}
+			vec.write(w)
+			if len(w.segments) != 2 || w.segments[0] != vec.expected[0] ||
+				w.segments[1] != vec.expected[1] {
+				t.Errorf("expected segments %q but were %q", vec.expected, w.segments)
+			}
+		})
+	}
+}
+
+func TestSegmentWriter_WriteLatitude_errors(t *testing.T) {
+	w := &SegmentWriter{}
+	w.WriteString("GPGLL")
+	w.WriteLatitude(NewLatitude(Coordinate{Degrees: 37, Minutes: 6000}, North))
+
+	var encErr *EncodingError
+	if !errors.As(w.Err(), &encErr) || encErr.Segment != 1 {
+		t.Errorf("expected an *EncodingError for segment [1] but was %v", w.Err())
+	}
+
+	w = &SegmentWriter{}
+	w.WriteString("GPGLL")
+	w.WriteLongitude(Longitude{Value: Coordinate{Degrees: 121}, Valid: true})
+	if !errors.As(w.Err(), &encErr) || encErr.Segment != 2 {
+		t.Errorf("expected an *EncodingError for segment [2] but was %v", w.Err())
+	}
+}
@@ -0,0 +1,56 @@
+package sentence
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- Public ------------------------------------------------------------------
+
+// StartDelimiter is the first character of an NMEA sentence. It distinguishes parametric sentences,
+// whose fields are individually delimited values (e.g. "$GPGGA"), from encapsulation sentences,
+// which carry an armoured payload (e.g. "!AIVDM").
+type StartDelimiter byte
+
+const (
+	// ParametricDelimiter ("$") begins a parametric sentence such as GGA, GLL or GSA.
+	ParametricDelimiter StartDelimiter = '$'
+
+	// EncapsulationDelimiter ("!") begins an encapsulation sentence such as AIS VDM or VDO.
+	EncapsulationDelimiter StartDelimiter = '!'
+)
+
+// String returns the start delimiter as a one-character string ("$" or "!").
+func (d StartDelimiter) String() string {
+	return string(rune(d))
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for StartDelimiter.
+func (d StartDelimiter) MarshalText() ([]byte, error) {
+	if !isStartDelimiter(byte(d)) {
+		return nil, fmt.Errorf("%q is not a valid StartDelimiter", d.String())
+	}
+
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for StartDelimiter. It accepts
+// "$" and "!".
+func (d *StartDelimiter) UnmarshalText(text []byte) error {
+	if len(text) != 1 || !isStartDelimiter(text[0]) {
+		return fmt.Errorf("start delimiter must be \"$\" or \"!\" but was \"%s\"", text)
+	}
+
+	*d = StartDelimiter(text[0])
+
+	return nil
+}
+
+// --- Private -----------------------------------------------------------------
+
+// startDelimiters are the characters that begin an NMEA sentence.
+const startDelimiters = "$!"
+
+func isStartDelimiter(c byte) bool {
+	return strings.IndexByte(startDelimiters, c) >= 0
+}
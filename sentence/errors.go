@@ -1,17 +1,208 @@
 package sentence
 
 import (
+	"errors"
 	"fmt"
 )
 
-// ParsingError represents an error that occurs when attempting to parse an NMEA
+// Sentinel errors that classify the errors returned by this package (and by the sentence
+// packages), for use with errors.Is. Each typed error below matches exactly one of them; for
+// example, errors.Is(err, ErrChecksumMismatch) reports whether err is (or wraps) a
+// ChecksumMismatchError.
+var (
+	ErrChecksumMismatch    = errors.New("checksum mismatch")
+	ErrMissingChecksum     = errors.New("missing checksum")
+	ErrMalformedChecksum   = errors.New("malformed checksum")
+	ErrBadStartDelimiter   = errors.New("bad start delimiter")
+	ErrUnknownSentenceType = errors.New("unknown sentence type")
+	ErrInvalidField        = errors.New("invalid field")
+	ErrIncompleteGroup     = errors.New("incomplete sentence group")
+)
+
+// ChecksumMismatchError represents an error that occurs when the checksum calculated from a
+// sentence (or from a tag block) does not match the checksum that it specifies. It matches
+// ErrChecksumMismatch.
+type ChecksumMismatchError struct {
+	Expected   string // The sentence-specified checksum, in upper case (e.g. "4F")
+	Calculated string // The calculated checksum, as two upper-case hex digits
+	TagBlock   bool   // Whether the checksum is that of a tag block (see TagBlock)
+}
+
+// Error returns the ChecksumMismatchError's message.
+func (e ChecksumMismatchError) Error() string {
+	if e.TagBlock {
+		return fmt.Sprintf(
+			"calculated tag block checksum value \"%v\" does not match tag block-specified value of \"%v\"",
+			e.Calculated, e.Expected)
+	}
+
+	return fmt.Sprintf(
+		"calculated checksum value \"%v\" does not match sentence-specified value of \"%v\"",
+		e.Calculated, e.Expected)
+}
+
+// Is reports whether target is ErrChecksumMismatch.
+func (e ChecksumMismatchError) Is(target error) bool {
+	return target == ErrChecksumMismatch
+}
+
+// MissingChecksumError represents an error that occurs when a sentence (or a tag block) does not
+// contain a checksum. It matches ErrMissingChecksum.
+type MissingChecksumError struct {
+	TagBlock bool // Whether the checksum is that of a tag block (see TagBlock)
+}
+
+// Error returns the MissingChecksumError's message.
+func (e MissingChecksumError) Error() string {
+	if e.TagBlock {
+		return "tag block does not contain a checksum"
+	}
+
+	return "sentence does not contain a checksum"
+}
+
+// Is reports whether target is ErrMissingChecksum.
+func (e MissingChecksumError) Is(target error) bool {
+	return target == ErrMissingChecksum
+}
+
+// MalformedChecksumError represents an error that occurs when the checksum delimiter ("*") of a
+// sentence (or of a tag block) is not followed by exactly two characters. It matches
+// ErrMalformedChecksum.
+type MalformedChecksumError struct {
+	Remaining int  // The number of characters that follow the checksum delimiter
+	TagBlock  bool // Whether the checksum is that of a tag block (see TagBlock)
+}
+
+// Error returns the MalformedChecksumError's message.
+func (e MalformedChecksumError) Error() string {
+	if e.TagBlock {
+		return fmt.Sprintf(
+			"there must be exactly 2 characters remaining after \"*\" in a tag block but there was/were %v",
+			e.Remaining)
+	}
+
+	return fmt.Sprintf(
+		"there must be exactly 2 characters remaining after \"*\" but there was/were %v", e.Remaining)
+}
+
+// Is reports whether target is ErrMalformedChecksum.
+func (e MalformedChecksumError) Is(target error) bool {
+	return target == ErrMalformedChecksum
+}
+
+// BadStartDelimiterError represents an error that occurs when a sentence does not begin with a
+// start delimiter (see StartDelimiter), or does not begin with the one that its sentence type
+// requires. It matches ErrBadStartDelimiter.
+type BadStartDelimiterError struct {
+	Expected string // The required start delimiter, or "" if either "$" or "!" would do
+	Actual   string // The sentence's first character
+}
+
+// Error returns the BadStartDelimiterError's message.
+func (e BadStartDelimiterError) Error() string {
+	if e.Expected != "" {
+		return fmt.Sprintf(
+			"must be preceded by start delimiter \"%s\" but was preceded by \"%s\"", e.Expected, e.Actual)
+	}
+
+	return fmt.Sprintf("character [0] must be \"$\" or \"!\" but was \"%v\"", e.Actual)
+}
+
+// Is reports whether target is ErrBadStartDelimiter.
+func (e BadStartDelimiterError) Is(target error) bool {
+	return target == ErrBadStartDelimiter
+}
+
+// FieldError represents an error that occurs when attempting to parse a segment (field) of an NMEA
+// sentence. It matches ErrInvalidField, as well as any error that it wraps (see Err).
+type FieldError struct {
+	SentenceType string // Sentence segment [0] (e.g. "GPGGA"), if the sentence has one
+	Field        string // The name of the field, if known (see SegmentParser.NameFields)
+	Segment      int8   // The index of the sentence segment
+	Value        string // The raw value of the sentence segment, if it is in range
+	Expected     string // The kind of value expected (e.g. "float32" or "NorthSouth"), if known
+	Message      string // A description of the error, which follows the segment index in Error
+	Err          error  // The underlying error, if any
+}
+
+// Error returns the FieldError's message.
+func (e FieldError) Error() string {
+	return fmt.Sprintf("sentence segment [%d] %s", e.Segment, e.Message)
+}
+
+// Is reports whether target is ErrInvalidField.
+func (e FieldError) Is(target error) bool {
+	return target == ErrInvalidField
+}
+
+// Unwrap returns the FieldError's underlying error, if any.
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ParsingError represents an error that occurs when attempting to parse an NMEA sentence.
+//
+// Deprecated: ParsingError is an alias for FieldError, which carries more detail.
+type ParsingError = FieldError
+
+// UnknownSentenceTypeError represents an error that occurs when a sentence's type has no
+// registered Decoder. It matches ErrUnknownSentenceType.
+//
+// Deprecated: Parse no longer returns it; it returns such a sentence as a *RawSentence.
+type UnknownSentenceTypeError struct {
+	SentenceType string
+}
+
+// Error returns the UnknownSentenceTypeError's message.
+func (e UnknownSentenceTypeError) Error() string {
+	return fmt.Sprintf("no decoder is registered for sentence type \"%s\"", e.SentenceType)
+}
+
+// Is reports whether target is ErrUnknownSentenceType.
+func (e UnknownSentenceTypeError) Is(target error) bool {
+	return target == ErrUnknownSentenceType
+}
+
+// IncompleteGroupError represents a group of sentences (see GroupAssembler) that was abandoned
+// before all of its parts were received. It matches ErrIncompleteGroup.
+type IncompleteGroupError struct {
+	Key    string         // The group's key
+	Parts  []NMEASentence // The group's parts, in order; a missing part is nil
+	Reason string         // Why the group was abandoned, e.g. "timed out"
+}
+
+// Error returns the IncompleteGroupError's message.
+func (e IncompleteGroupError) Error() string {
+	return fmt.Sprintf("sentence group \"%s\" is incomplete (%s): missing parts %v of %d", e.Key,
+		e.Reason, e.Missing(), len(e.Parts))
+}
+
+// Is reports whether target is ErrIncompleteGroup.
+func (e IncompleteGroupError) Is(target error) bool {
+	return target == ErrIncompleteGroup
+}
+
+// Missing returns the (1-based) numbers of the parts of the group that were not received.
+func (e IncompleteGroupError) Missing() []int {
+	var missing []int
+	for i, part := range e.Parts {
+		if part == nil {
+			missing = append(missing, i+1)
+		}
+	}
+
+	return missing
+}
+
+// EncodingError represents an error that occurs when attempting to encode a value as an NMEA
 // sentence.
-type ParsingError struct {
+type EncodingError struct {
 	Segment int8
 	Message string
 }
 
-// Error returns the ParsingError's message.
-func (e ParsingError) Error() string {
+// Error returns the EncodingError's message.
+func (e EncodingError) Error() string {
 	return fmt.Sprintf("sentence segment [%d] %s", e.Segment, e.Message)
 }
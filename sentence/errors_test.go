@@ -1,6 +1,9 @@
 package sentence
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestParsingError_Error(t *testing.T) {
 	err := ParsingError{Segment: 3, Message: "is out of range"}
@@ -9,3 +12,120 @@ func TestParsingError_Error(t *testing.T) {
 		t.Errorf("expected %q but was %q", expected, err.Error())
 	}
 }
+
+func TestUnknownSentenceTypeError_Error(t *testing.T) {
+	err := UnknownSentenceTypeError{SentenceType: "GPXYZ"}
+	expected := "no decoder is registered for sentence type \"GPXYZ\""
+	if err.Error() != expected {
+		t.Errorf("expected %q but was %q", expected, err.Error())
+	}
+}
+
+func TestEncodingError_Error(t *testing.T) {
+	err := EncodingError{Segment: 5, Message: "must be a valid gga.EastWest but was \"EastWest(0)\""}
+	expected := "sentence segment [5] must be a valid gga.EastWest but was \"EastWest(0)\""
+	if err.Error() != expected {
+		t.Errorf("expected %q but was %q", expected, err.Error())
+	}
+}
+
+func TestErrors_Error(t *testing.T) {
+	for title, vec := range map[string]struct {
+		err      error
+		expected string
+	}{
+		"ChecksumMismatchError": {
+			err:      ChecksumMismatchError{Expected: "4F", Calculated: "41"},
+			expected: "calculated checksum value \"41\" does not match sentence-specified value of \"4F\"",
+		},
+		"ChecksumMismatchError (Tag Block)": {
+			err:      ChecksumMismatchError{Expected: "4F", Calculated: "41", TagBlock: true},
+			expected: "calculated tag block checksum value \"41\" does not match tag block-specified value of \"4F\"",
+		},
+		"MissingChecksumError": {
+			err:      MissingChecksumError{},
+			expected: "sentence does not contain a checksum",
+		},
+		"MissingChecksumError (Tag Block)": {
+			err:      MissingChecksumError{TagBlock: true},
+			expected: "tag block does not contain a checksum",
+		},
+		"MalformedChecksumError": {
+			err:      MalformedChecksumError{Remaining: 3},
+			expected: "there must be exactly 2 characters remaining after \"*\" but there was/were 3",
+		},
+		"MalformedChecksumError (Tag Block)": {
+			err:      MalformedChecksumError{Remaining: 1, TagBlock: true},
+			expected: "there must be exactly 2 characters remaining after \"*\" in a tag block but there was/were 1",
+		},
+		"BadStartDelimiterError": {
+			err:      BadStartDelimiterError{Actual: "#"},
+			expected: "character [0] must be \"$\" or \"!\" but was \"#\"",
+		},
+		"BadStartDelimiterError (Expected)": {
+			err:      BadStartDelimiterError{Expected: "!", Actual: "$"},
+			expected: "must be preceded by start delimiter \"!\" but was preceded by \"$\"",
+		},
+		"FieldError": {
+			err:      FieldError{SentenceType: "GPGGA", Field: "FixTime", Segment: 1, Message: "is bad"},
+			expected: "sentence segment [1] is bad",
+		},
+		"IncompleteGroupError": {
+			err:      IncompleteGroupError{Key: "AIVDM,3,B", Parts: make([]NMEASentence, 2), Reason: "flushed"},
+			expected: "sentence group \"AIVDM,3,B\" is incomplete (flushed): missing parts [1 2] of 2",
+		},
+	} {
+		t.Run(title, func(t *testing.T) {
+			if vec.err.Error() != vec.expected {
+				t.Errorf("expected %q but was %q", vec.expected, vec.err.Error())
+			}
+		})
+	}
+}
+
+func TestErrors_Is(t *testing.T) {
+	sentinels := []error{
+		ErrChecksumMismatch,
+		ErrMissingChecksum,
+		ErrMalformedChecksum,
+		ErrBadStartDelimiter,
+		ErrUnknownSentenceType,
+		ErrInvalidField,
+		ErrIncompleteGroup,
+	}
+
+	for title, vec := range map[string]struct {
+		err      error
+		sentinel error
+	}{
+		"ChecksumMismatchError":    {err: &ChecksumMismatchError{}, sentinel: ErrChecksumMismatch},
+		"MissingChecksumError":     {err: &MissingChecksumError{}, sentinel: ErrMissingChecksum},
+		"MalformedChecksumError":   {err: &MalformedChecksumError{}, sentinel: ErrMalformedChecksum},
+		"BadStartDelimiterError":   {err: &BadStartDelimiterError{}, sentinel: ErrBadStartDelimiter},
+		"UnknownSentenceTypeError": {err: &UnknownSentenceTypeError{}, sentinel: ErrUnknownSentenceType},
+		"FieldError":               {err: &FieldError{}, sentinel: ErrInvalidField},
+		"IncompleteGroupError":     {err: &IncompleteGroupError{}, sentinel: ErrIncompleteGroup},
+	} {
+		t.Run(title, func(t *testing.T) {
+			for _, sentinel := range sentinels {
+				if errors.Is(vec.err, sentinel) != (sentinel == vec.sentinel) {
+					t.Errorf("errors.Is(%T, %q) should have been %v", vec.err, sentinel, sentinel == vec.sentinel)
+				}
+			}
+		})
+	}
+}
+
+func TestFieldError_Unwrap(t *testing.T) {
+	cause := &BadStartDelimiterError{Expected: "!", Actual: "$"}
+	err := error(&FieldError{Segment: 0, Message: cause.Error(), Err: cause})
+
+	if !errors.Is(err, ErrInvalidField) || !errors.Is(err, ErrBadStartDelimiter) {
+		t.Errorf("expected %v to match both ErrInvalidField and ErrBadStartDelimiter", err)
+	}
+
+	var bad *BadStartDelimiterError
+	if !errors.As(err, &bad) || bad != cause {
+		t.Errorf("expected errors.As to find %v but found %v", cause, bad)
+	}
+}
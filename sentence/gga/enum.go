@@ -0,0 +1,75 @@
+package gga
+
+import (
+	"github.com/mab-go/nmea/sentence"
+)
+
+// NorthSouth is an alias for [sentence.NorthSouth].
+type NorthSouth = sentence.NorthSouth
+
+// NorthSouth values.
+const (
+	North = sentence.North
+	South = sentence.South
+)
+
+// NorthSouthString is an alias for [sentence.NorthSouthString].
+func NorthSouthString(s string) (NorthSouth, error) { return sentence.NorthSouthString(s) }
+
+// NorthSouthValues is an alias for [sentence.NorthSouthValues].
+func NorthSouthValues() []NorthSouth { return sentence.NorthSouthValues() }
+
+// NorthSouthStrings is an alias for [sentence.NorthSouthStrings].
+func NorthSouthStrings() []string { return sentence.NorthSouthStrings() }
+
+// EastWest is an alias for [sentence.EastWest].
+type EastWest = sentence.EastWest
+
+// EastWest values.
+const (
+	East = sentence.East
+	West = sentence.West
+)
+
+// EastWestString is an alias for [sentence.EastWestString].
+func EastWestString(s string) (EastWest, error) { return sentence.EastWestString(s) }
+
+// EastWestValues is an alias for [sentence.EastWestValues].
+func EastWestValues() []EastWest { return sentence.EastWestValues() }
+
+// EastWestStrings is an alias for [sentence.EastWestStrings].
+func EastWestStrings() []string { return sentence.EastWestStrings() }
+
+// FixQuality indicates the type/quality of a GPS fix.
+type FixQuality int
+
+const (
+	// InvalidFixQuality represents an invalid GPS fix quality. Its value is 0.
+	InvalidFixQuality FixQuality = iota + 1 // 0
+
+	// GPSFixQuality represents a standard GPS fix quality. Its value is 1.
+	GPSFixQuality // 1
+
+	// DGPSFixQuality represents a differential GPS (DGPS) fix quality. Its value is 2.
+	DGPSFixQuality // 2
+
+	// PPSFixQuality represents a precise positioning system (PPS) fix quality. Its value is 3.
+	PPSFixQuality // 3
+
+	// RTKFixQuality represents a Real Time Kinematic fix quality. Its value is 4.
+	RTKFixQuality // 4
+
+	// FloatRTKFixQuality represents a Float Real Time Kinematic fix quality. Its value is 5.
+	FloatRTKFixQuality // 5
+
+	// EstimatedFixQuality represents an estimated (dead reckoning) fix quality. Its value is 6.
+	EstimatedFixQuality // 6
+
+	// ManualInputFixQuality represents a "manual input mode" fix quality. Its value is 7.
+	ManualInputFixQuality // 7
+
+	// SimulationFixQuality represents a "simulation mode" fix quality. Its value is 8.
+	SimulationFixQuality // 8
+)
+
+//go:generate go run github.com/dmarkham/enumer@v1.6.3 -type=FixQuality -json -text -values -linecomment -transform=first-upper -output=enum_gen.go
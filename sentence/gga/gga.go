@@ -0,0 +1,245 @@
+// Package gga contains data structures and functions related to NMEA sentences of type "GGA"
+// (global positioning system fix data), as sent by any talker (e.g. "$GPGGA", "$GNGGA" or
+// "$GLGGA").
+package gga // import "github.com/mab-go/nmea/sentence/gga"
+
+import (
+	"encoding/json"
+
+	"github.com/mab-go/nmea/sentence"
+)
+
+//go:generate go run github.com/mab-go/nmea/tools/nmeaschema gga
+
+// GGA represents an NMEA sentence of type "GGA".
+type GGA struct {
+	// TalkerID identifies the talker that sent the sentence (e.g. "GP" or "GN"). It is the first
+	// two characters of element [0] of a GGA sentence.
+	TalkerID string `json:"talkerId"`
+
+	// FixTime is the time at which the GPS fix was acquired (typically UTC). It is element [1] of
+	// a GGA sentence. It is not valid if the field is empty, which distinguishes a missing time
+	// from midnight. Wire format and validation: see [sentence.NMEATime].
+	FixTime sentence.Time `json:"fixTime"`
+
+	// Latitude is the "latitude" component of a GPS fix, e.g. 48° 7.038' N for "4807.038,N". It
+	// is elements [2] (the ddmm.mmmm value) and [3] (the hemisphere) of a GGA sentence. It is not
+	// valid if both are empty, as they are when the receiver has no fix.
+	Latitude sentence.Latitude `json:"latitude"`
+
+	// Longitude is the "longitude" component of a GPS fix, e.g. 11° 31.215' E for "01131.215,E".
+	// It is elements [4] (the dddmm.mmmm value) and [5] (the hemisphere) of a GGA sentence. It is
+	// not valid if both are empty.
+	Longitude sentence.Longitude `json:"longitude"`
+
+	// FixQuality indicates the type/quality of the GPS fix. It is element [6] of a GGA sentence.
+	FixQuality FixQuality `json:"fixQuality,omitzero"`
+
+	// SatCount is the number of satellites used to obtain the GPS fix. It is element [7] of a GGA
+	// sentence. It is not valid if the field is empty, as opposed to 0 (no satellites in use).
+	SatCount sentence.Int `json:"satCount"`
+
+	// HDOP is the horizontal dilution of precision (HDOP) of the GPS fix. It indicates a relative
+	// "confidence" level in the precision reported. Generally an HDOP of 1.0 is the best possible
+	// value. It is element [8] of a GGA sentence.
+	//
+	// Refer to https://en.wikipedia.org/wiki/Dilution_of_precision_(navigation)#Meaning_of_DOP_Values
+	// for a better understanding of the meaning of HDOP values. It is not valid if the field is
+	// empty.
+	HDOP sentence.Float32 `json:"hdop"`
+
+	// Altitude is the above or below mean sea level for the GPS fix. Its unit of measure is
+	// specified by the AltitudeUOM field. It is element [9] of a GGA sentence. It is not valid if
+	// the altitude is unknown (the field is empty), as opposed to 0 (sea level).
+	Altitude sentence.Float32 `json:"altitude"`
+
+	// AltitudeUOM is the unit of measure in which Altitude is expressed. It should always be "M"
+	// (meters), but may be empty if Altitude is not valid. It is element [10] of a GGA sentence.
+	AltitudeUOM string `json:"altitudeUom"`
+
+	// GeoidHeight is the height of the geoid above or below the WGS84 ellipsoid. Its unit of
+	// measure is specified by the GeoidHeightUOM field. It is element [11] of a GGA sentence. It
+	// is not valid if the field is empty.
+	GeoidHeight sentence.Float32 `json:"geoidHeight"`
+
+	// GeoidHeightUOM is the unit of measure in which GeoidHeight is expressed. It should always be
+	// "M" (meters), but may be empty if GeoidHeight is not valid. It is element [12] of a GGA
+	// sentence.
+	GeoidHeightUOM string `json:"geoidHeightUom"`
+
+	// DGPSUpdateAge is the age (in seconds) since the last update from a differential GPS reference
+	// station. It is element [13] of a GGA sentence. If differential GPS was not used to obtain
+	// the fix, (i.e., if FixQuality is not 2), then the field is empty and DGPSUpdateAge is not
+	// valid; this distinguishes "no DGPS" from a DGPS update age of 0.
+	DGPSUpdateAge sentence.Float32 `json:"dgpsUpdateAge"`
+
+	// DGPSStationID is the unique identifier for the differential GPS reference station that was
+	// used to obtain the GPS fix (if DGPS was used). It is element [14] of a GGA sentence. If
+	// differential GPS was not used to obtain the fix, (i.e., if FixQuality is not 2), then the
+	// field is empty and DGPSStationID is not valid.
+	DGPSStationID sentence.Int `json:"dgpsStationId"`
+
+	// Version is the NMEA 0183 version of the GGA sentence, as far as its fields tell. No version
+	// since 2.0 has added a field to GGA, so it is always [sentence.Version20] for a decoded
+	// sentence, and it does not affect MarshalNMEA. It is not part of the GGA sentence itself.
+	Version sentence.Version `json:"version,omitzero"`
+
+	// TagBlock is the tag block (see [sentence.TagBlock]) that preceded the GGA sentence, or nil if
+	// there was none. It is not part of the GGA sentence itself.
+	TagBlock *sentence.TagBlock `json:"tagBlock,omitzero"`
+}
+
+// GetSentenceType returns the type of NMEA sentence represented by the struct GGA: its talker
+// identifier followed by "GGA" (e.g. "GNGGA"). It represents element [0] of a GGA sentence.
+func (g GGA) GetSentenceType() string {
+	return g.TalkerID + "GGA"
+}
+
+// Talker returns the talker identifier of the GGA sentence (e.g. "GP" or "GN").
+func (g GGA) Talker() string {
+	return g.TalkerID
+}
+
+// MarshalNMEA encodes g as a GGA sentence (including its checksum), e.g.
+// "$GNGGA,092725.00,4717.11399,N,00833.91590,E,1,08,1.01,499.6,M,48.0,M,,*45". Fields that are
+// not valid (e.g. DGPSUpdateAge and DGPSStationID when differential GPS was not used) are written
+// as empty segments. It returns an error if g.TalkerID is not a valid talker identifier or if an
+// enum field does not hold one of its defined values.
+func (g GGA) MarshalNMEA() (string, error) {
+	w := &sentence.SegmentWriter{}
+	w.WriteTagBlock(g.TagBlock)
+	w.WriteSentenceType(g.TalkerID, "GGA")
+	w.WriteOptionalNMEATime(g.FixTime)
+	w.WriteLatitude(g.Latitude)
+	w.WriteLongitude(g.Longitude)
+	w.WriteEnum(g.FixQuality, g.FixQuality.IsAFixQuality())
+	w.WriteOptionalZeroPaddedInt(g.SatCount, 2)
+	w.WriteOptionalFloat32(g.HDOP)
+	w.WriteOptionalFloat32(g.Altitude)
+	w.WriteString(g.AltitudeUOM)
+	w.WriteOptionalFloat32(g.GeoidHeight)
+	w.WriteString(g.GeoidHeightUOM)
+	w.WriteOptionalFloat32(g.DGPSUpdateAge)
+	w.WriteOptionalZeroPaddedInt(g.DGPSStationID, 4)
+
+	return w.Sentence()
+}
+
+// MarshalJSON encodes g as a JSON object whose members are "sentenceType" (e.g. "GNGGA") and the
+// fields of g, named by their json struct tags. See [sentence.MarshalSentenceJSON] for the encoding
+// of each type of field, and gga.schema.json for its JSON Schema.
+func (g GGA) MarshalJSON() ([]byte, error) {
+	type fields GGA // Has the fields of GGA, but not its MarshalJSON method
+
+	return sentence.MarshalSentenceJSON(g.GetSentenceType(), fields(g))
+}
+
+// UnmarshalJSON decodes a JSON object encoded by MarshalJSON into g, which is only modified if
+// data is valid. Its "sentenceType" member may be omitted.
+func (g *GGA) UnmarshalJSON(data []byte) error {
+	type fields GGA
+
+	var decoded GGA
+	if err := sentence.UnmarshalSentenceJSON(data, (*fields)(&decoded), &decoded); err != nil {
+		return err
+	}
+
+	*g = decoded
+
+	return nil
+}
+
+// Ensure that GGA properly implements the NMEASentence, Marshaler and JSON interfaces
+var (
+	_ sentence.NMEASentence = GGA{}
+	_ sentence.Marshaler    = GGA{}
+	_ json.Marshaler        = GGA{}
+	_ json.Unmarshaler      = (*GGA)(nil)
+)
+
+func init() {
+	sentence.Register("GGA", func(p *sentence.SegmentParser) (sentence.NMEASentence, error) {
+		gga, err := Decode(p)
+		if err != nil {
+			return nil, err
+		}
+
+		return gga, nil
+	})
+}
+
+// Parse parses a GGA sentence string from any talker and returns a pointer to a GGA struct (or an
+// error if the sentence is invalid).
+func Parse(s string) (*GGA, error) {
+	return ParseWithOptions(s)
+}
+
+// ParseWithOptions is like Parse, but parses the sentence according to opts (see
+// [sentence.ParseOptions]), e.g. to accept a sentence that has no checksum. With no options, it
+// is identical to Parse.
+func ParseWithOptions(s string, opts ...sentence.ParseOption) (*GGA, error) {
+	p := &sentence.SegmentParser{}
+	if err := p.ParseWithOptions(s, opts...); err != nil {
+		return nil, err
+	}
+
+	return Decode(p)
+}
+
+// Decode decodes a GGA sentence from p, which must already have parsed the sentence (see
+// [sentence.SegmentParser.Parse]). It returns a pointer to a GGA struct (or an error if the
+// sentence is invalid).
+func Decode(p *sentence.SegmentParser) (*GGA, error) {
+	gga := &GGA{}
+	if err := DecodeInto(p, gga); err != nil {
+		return nil, err
+	}
+
+	return gga, nil
+}
+
+// DecodeInto is like Decode, but decodes the sentence into dst, which is only modified if the
+// sentence is valid. Used with a reused [sentence.SegmentParser] and its ParseBytes method, it
+// decodes a sentence without a tag block without allocating.
+func DecodeInto(p *sentence.SegmentParser, dst *GGA) error {
+	segments := &SegmentParser{SegmentParser: *p}
+	segments.NameFields(fieldNames...)
+	gga := GGA{
+		TalkerID:      segments.RequireFormatter("GGA"), // Verify sentence type
+		FixTime:       segments.AsOptionalNMEATime(1),
+		Latitude:      segments.AsLatitude(2),
+		Longitude:     segments.AsLongitude(4),
+		FixQuality:    segments.AsFixQuality(6),
+		SatCount:      segments.AsOptionalInt(7, 8),
+		HDOP:          segments.AsOptionalFloat32(8),
+		Altitude:      segments.AsOptionalFloat32(9),
+		GeoidHeight:   segments.AsOptionalFloat32(11),
+		DGPSUpdateAge: segments.AsOptionalFloat32(13),
+		DGPSStationID: segments.AsOptionalInt(14, 16),
+		Version:       versions.Detect(segments.Len()),
+		TagBlock:      p.TagBlock(),
+	}
+	gga.AltitudeUOM = segments.AsMeters(10, gga.Altitude.Valid)
+	gga.GeoidHeightUOM = segments.AsMeters(12, gga.GeoidHeight.Valid)
+
+	if err := segments.Err(); err != nil {
+		return err
+	}
+
+	*dst = gga
+
+	return nil
+}
+
+// versions declares the NMEA 0183 versions of the GGA fields: every field dates from version 2.0.
+var versions = sentence.FieldVersions{
+	{Version: sentence.Version20, Len: 15},
+}
+
+// fieldNames names the GGA field held by each sentence segment, for use in errors (see
+// [sentence.FieldError]).
+var fieldNames = []string{
+	"TalkerID", "FixTime", "Latitude", "Latitude", "Longitude", "Longitude", "FixQuality",
+	"SatCount", "HDOP", "Altitude", "AltitudeUOM", "GeoidHeight", "GeoidHeightUOM",
+	"DGPSUpdateAge", "DGPSStationID",
+}
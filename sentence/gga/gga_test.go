@@ -0,0 +1,483 @@
+package gga
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mab-go/nmea/sentence"
+)
+
+type testVec struct {
+	input    string
+	expected GGA
+	json     string
+	errMsg   string
+}
+
+var goodTestData = map[string]testVec{
+	"GPS (GP)": {
+		input: "$GPGGA,174800.864,4002.741,N,07618.550,W,1,12,1.0,0.0,M,0.0,M,,*70",
+		json:  `{"sentenceType":"GPGGA","talkerId":"GP","fixTime":"174800.864","latitude":"4002.741,N","longitude":"07618.550,W","fixQuality":"1","satCount":12,"hdop":1,"altitude":0,"altitudeUom":"M","geoidHeight":0,"geoidHeightUom":"M","dgpsUpdateAge":null,"dgpsStationId":null,"version":"2.0"}`,
+		expected: GGA{
+			TalkerID:       "GP",
+			FixTime:        sentence.NewTime(sentence.NMEATime{Hour: 17, Minute: 48, Second: 0, Millisecond: 864}),
+			Latitude:       sentence.MustParseLatitude("4002.741,N"),
+			Longitude:      sentence.MustParseLongitude("07618.550,W"),
+			FixQuality:     GPSFixQuality,
+			SatCount:       sentence.NewInt(12),
+			HDOP:           sentence.NewFloat32(1.0),
+			Altitude:       sentence.NewFloat32(0.0),
+			AltitudeUOM:    "M",
+			GeoidHeight:    sentence.NewFloat32(0.0),
+			GeoidHeightUOM: "M",
+			Version:        sentence.Version20,
+		},
+	},
+	"Multi-Constellation GNSS (GN)": {
+		input: "$GNGGA,092725.00,4717.11399,N,00833.91590,E,1,08,1.01,499.6,M,48.0,M,,*45",
+		json:  `{"sentenceType":"GNGGA","talkerId":"GN","fixTime":"092725.00","latitude":"4717.11399,N","longitude":"00833.91590,E","fixQuality":"1","satCount":8,"hdop":1.01,"altitude":499.6,"altitudeUom":"M","geoidHeight":48,"geoidHeightUom":"M","dgpsUpdateAge":null,"dgpsStationId":null,"version":"2.0"}`,
+		expected: GGA{
+			TalkerID:       "GN",
+			FixTime:        sentence.NewTime(sentence.NMEATime{Hour: 9, Minute: 27, Second: 25, Millisecond: 0, FractionDigits: 2}),
+			Latitude:       sentence.MustParseLatitude("4717.11399,N"),
+			Longitude:      sentence.MustParseLongitude("00833.91590,E"),
+			FixQuality:     GPSFixQuality,
+			SatCount:       sentence.NewInt(8),
+			HDOP:           sentence.NewFloat32(1.01),
+			Altitude:       sentence.NewFloat32(499.6),
+			AltitudeUOM:    "M",
+			GeoidHeight:    sentence.NewFloat32(48.0),
+			GeoidHeightUOM: "M",
+			Version:        sentence.Version20,
+		},
+	},
+	"GLONASS (GL)": {
+		input: "$GLGGA,002454,3553.5295,N,13938.6570,E,1,05,2.2,18.3,M,39.0,M,,*63",
+		json:  `{"sentenceType":"GLGGA","talkerId":"GL","fixTime":"002454","latitude":"3553.5295,N","longitude":"13938.6570,E","fixQuality":"1","satCount":5,"hdop":2.2,"altitude":18.3,"altitudeUom":"M","geoidHeight":39,"geoidHeightUom":"M","dgpsUpdateAge":null,"dgpsStationId":null,"version":"2.0"}`,
+		expected: GGA{
+			TalkerID:       "GL",
+			FixTime:        sentence.NewTime(sentence.NMEATime{Hour: 0, Minute: 24, Second: 54, Millisecond: 0, FractionDigits: sentence.NoFractionDigits}),
+			Latitude:       sentence.MustParseLatitude("3553.5295,N"),
+			Longitude:      sentence.MustParseLongitude("13938.6570,E"),
+			FixQuality:     GPSFixQuality,
+			SatCount:       sentence.NewInt(5),
+			HDOP:           sentence.NewFloat32(2.2),
+			Altitude:       sentence.NewFloat32(18.3),
+			AltitudeUOM:    "M",
+			GeoidHeight:    sentence.NewFloat32(39.0),
+			GeoidHeightUOM: "M",
+			Version:        sentence.Version20,
+		},
+	},
+}
+
+var badTestData = map[string]testVec{
+	"Bad Formatter": {
+		input:  "$GPFOO,174800.864,4002.741,N,07618.550,W,1,12,1.0,0.0,M,0.0,M,,*77",
+		errMsg: "sentence segment [0] must be \"--GGA\" (case insensitive) but was \"GPFOO\"",
+	},
+	"Bad SentenceType Length": {
+		input:  "$GNGGAA,092725.00,4717.11399,N,00833.91590,E,1,08,1.01,499.6,M,48.0,M,,*04",
+		errMsg: "sentence segment [0] must be \"--GGA\" (case insensitive) but was \"GNGGAA\"",
+	},
+	"Bad FixTime": {
+		input:  "$GPGGA,bad_FixTime,4002.741,N,07618.550,W,1,12,1.0,0.0,M,0.0,M,,*34",
+		errMsg: "sentence segment [1] must be parsable as an NMEATime but was \"bad_FixTime\"",
+	},
+}
+
+func TestParse_goodData(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			actual, err := Parse(vec.input)
+			if err != nil {
+				t.Fatalf("error creating GGA from NMEA input \"%v\": %v", title, err)
+			}
+
+			if *actual != vec.expected {
+				t.Errorf("result should have been %+v but was %+v for NMEA input \"%v\"", vec.expected, *actual, title)
+			}
+		})
+	}
+}
+
+func TestParse_badSegments(t *testing.T) {
+	for title, vec := range badTestData {
+		t.Run(title, func(t *testing.T) {
+			gga, err := Parse(vec.input)
+			if err == nil {
+				t.Fatalf("parsing succeeded (but should not have) for test sentence %q", title)
+			}
+
+			if gga != nil {
+				t.Fatalf("result should have been <nil> but was %v for test sentence %q", gga, title)
+			}
+
+			if err.Error() != vec.errMsg {
+				t.Fatalf("error message should have been '%v' but was '%v' for test sentence %q", vec.errMsg, err.Error(), title)
+			}
+		})
+	}
+}
+
+func TestParse_registered(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			s, err := sentence.Parse(vec.input)
+			if err != nil {
+				t.Fatalf("sentence.Parse failed: %v", err)
+			}
+
+			actual, ok := s.(*GGA)
+			if !ok {
+				t.Fatalf("sentence.Parse should have returned a *GGA but returned %T", s)
+			}
+
+			if *actual != vec.expected {
+				t.Errorf("sentence.Parse result should have been %+v but was %+v", vec.expected, *actual)
+			}
+		})
+	}
+}
+
+func TestParse_tagBlock(t *testing.T) {
+	vec := goodTestData["GPS (GP)"]
+	input := "\\s:r3669961,c:1503394200*71\\" + vec.input
+
+	actual, err := Parse(input)
+	if err != nil {
+		t.Fatalf("error creating GGA from NMEA input %q: %v", input, err)
+	}
+
+	expectedTagBlock := sentence.TagBlock{Source: "r3669961", UnixTime: 1503394200}
+	if actual.TagBlock == nil || *actual.TagBlock != expectedTagBlock {
+		t.Errorf("tag block should have been %+v but was %+v", expectedTagBlock, actual.TagBlock)
+	}
+
+	encoded, err := actual.MarshalNMEA()
+	if err != nil {
+		t.Fatalf("MarshalNMEA failed: %v", err)
+	}
+
+	reparsed, err := Parse(encoded)
+	if err != nil {
+		t.Fatalf("error re-parsing %q: %v", encoded, err)
+	}
+
+	if reparsed.TagBlock == nil || *reparsed.TagBlock != expectedTagBlock {
+		t.Errorf("MarshalNMEA should have re-emitted tag block %+v but produced %q", expectedTagBlock, encoded)
+	}
+}
+
+func TestGGA_MarshalJSON(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			encoded, err := json.Marshal(vec.expected)
+			if err != nil {
+				t.Fatalf("json.Marshal failed: %v", err)
+			}
+
+			if string(encoded) != vec.json {
+				t.Errorf("expected %s but was %s", vec.json, encoded)
+			}
+
+			var actual GGA
+			if err := json.Unmarshal([]byte(vec.json), &actual); err != nil {
+				t.Fatalf("error decoding %s: %v", vec.json, err)
+			}
+
+			if actual != vec.expected {
+				t.Errorf("decoding %s should have produced %+v but produced %+v", vec.json, vec.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGGA_MarshalNMEA_version(t *testing.T) {
+	vec := goodTestData["GPS (GP)"]
+	g := vec.expected
+	for title, version := range map[string]sentence.Version{
+		"Version 2.0":   sentence.Version20,
+		"Later Version": sentence.Version411,
+		"No Version":    0,
+	} {
+		t.Run(title, func(t *testing.T) {
+			g.Version = version
+			if actual, err := g.MarshalNMEA(); err != nil || actual != vec.input {
+				t.Errorf("expected %q but was %q (error %v)", vec.input, actual, err)
+			}
+		})
+	}
+}
+
+func TestParse_emptyFields(t *testing.T) {
+	// Unknown altitude and geoid height (with empty units of measure) and a DGPS update age of 0
+	input := "$GPGGA,104715.20,5100.2111,N,00500.0006,E,2,04,2.0,,,,,0.0,0001*76"
+
+	actual, err := Parse(input)
+	if err != nil {
+		t.Fatalf("error creating GGA from NMEA input %q: %v", input, err)
+	}
+
+	if actual.Altitude.Valid || actual.GeoidHeight.Valid {
+		t.Errorf("expected empty altitude and geoid height to be invalid but were %+v and %+v",
+			actual.Altitude, actual.GeoidHeight)
+	}
+
+	if expected := sentence.NewFloat32(0); actual.DGPSUpdateAge != expected {
+		t.Errorf("DGPSUpdateAge should have been %+v but was %+v", expected, actual.DGPSUpdateAge)
+	}
+
+	if expected := sentence.NewInt(1); actual.DGPSStationID != expected {
+		t.Errorf("DGPSStationID should have been %+v but was %+v", expected, actual.DGPSStationID)
+	}
+
+	encoded, err := actual.MarshalNMEA()
+	if err != nil {
+		t.Fatalf("MarshalNMEA failed: %v", err)
+	}
+
+	if encoded != input {
+		t.Errorf("MarshalNMEA should have produced %q but produced %q", input, encoded)
+	}
+}
+
+func TestParse_emptyTimeAndSatCount(t *testing.T) {
+	// A receiver without a fix may leave the time and the satellite count empty
+	input := "$GPGGA,,,,,,0,,,,,,,,*66"
+
+	actual, err := Parse(input)
+	if err != nil {
+		t.Fatalf("error creating GGA from NMEA input %q: %v", input, err)
+	}
+
+	if actual.FixTime.Valid || actual.SatCount.Valid {
+		t.Errorf("expected empty fix time and satellite count to be invalid but were %+v and %+v",
+			actual.FixTime, actual.SatCount)
+	}
+
+	encoded, err := actual.MarshalNMEA()
+	if err != nil {
+		t.Fatalf("MarshalNMEA failed: %v", err)
+	}
+
+	if encoded != input {
+		t.Errorf("MarshalNMEA should have produced %q but produced %q", input, encoded)
+	}
+}
+
+func TestParseWithOptions_collectErrors(t *testing.T) {
+	input := "$GPGGA,bad_FixTime,4002.741,X,07618.550,W,Q,12,1.0,0.0,M,0.0,M,,*42"
+
+	gga, err := ParseWithOptions(input, sentence.WithCollectErrors())
+	if gga != nil || err == nil {
+		t.Fatalf("expected a <nil> result and an error but were %v and %v", gga, err)
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("expected a joined error but was %T: %v", err, err)
+	}
+
+	expected := []sentence.FieldError{
+		{Field: "FixTime", Segment: 1, Value: "bad_FixTime", Expected: "NMEATime"},
+		{Field: "Latitude", Segment: 3, Value: "X", Expected: "NorthSouth"},
+		{Field: "FixQuality", Segment: 6, Value: "Q", Expected: "FixQuality"},
+	}
+	if len(joined.Unwrap()) != len(expected) {
+		t.Fatalf("expected %d errors but was %d: %v", len(expected), len(joined.Unwrap()), err)
+	}
+
+	for i, e := range joined.Unwrap() {
+		fieldErr, ok := e.(*sentence.FieldError)
+		if !ok {
+			t.Fatalf("error [%d] should have been a *sentence.FieldError but was %T", i, e)
+		}
+
+		actual := *fieldErr
+		actual.Message = ""
+		expected[i].SentenceType = "GPGGA"
+		if actual != expected[i] {
+			t.Errorf("error [%d] should have been %+v but was %+v", i, expected[i], actual)
+		}
+	}
+}
+
+func TestDecodeInto_parseBytes(t *testing.T) {
+	p := &sentence.SegmentParser{}
+	var actual GGA
+
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			if err := p.ParseBytes([]byte(vec.input)); err != nil {
+				t.Fatalf("segment parsing failed: %v", err)
+			}
+
+			if err := DecodeInto(p, &actual); err != nil {
+				t.Fatalf("error creating GGA from NMEA input \"%v\": %v", title, err)
+			}
+
+			if actual != vec.expected {
+				t.Errorf("result should have been %+v but was %+v for NMEA input \"%v\"", vec.expected, actual, title)
+			}
+		})
+	}
+}
+
+func TestDecodeInto_allocations(t *testing.T) {
+	line := []byte(goodTestData["Multi-Constellation GNSS (GN)"].input)
+	p := &sentence.SegmentParser{}
+	var gga GGA
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := p.ParseBytes(line); err != nil {
+			t.Fatalf("segment parsing failed: %v", err)
+		}
+
+		if err := DecodeInto(p, &gga); err != nil {
+			t.Fatalf("DecodeInto failed: %v", err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected ParseBytes and DecodeInto not to allocate but there were %v allocations", allocs)
+	}
+}
+
+func TestDecodeInto_badSegments(t *testing.T) {
+	expected := GGA{TalkerID: "unchanged"}
+	actual := expected
+
+	p := &sentence.SegmentParser{}
+	if err := p.ParseBytes([]byte(badTestData["Bad FixTime"].input)); err != nil {
+		t.Fatalf("segment parsing failed: %v", err)
+	}
+
+	if err := DecodeInto(p, &actual); err == nil || err.Error() != badTestData["Bad FixTime"].errMsg {
+		t.Errorf("expected error %q but was %v", badTestData["Bad FixTime"].errMsg, err)
+	}
+
+	if actual != expected {
+		t.Errorf("DecodeInto should not have modified its destination but it was %+v", actual)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	s := goodTestData["Multi-Constellation GNSS (GN)"].input
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := Parse(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeInto(b *testing.B) {
+	line := []byte(goodTestData["Multi-Constellation GNSS (GN)"].input)
+	p := &sentence.SegmentParser{}
+	var gga GGA
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := p.ParseBytes(line); err != nil {
+			b.Fatal(err)
+		}
+
+		if err := DecodeInto(p, &gga); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestGGA_MarshalNMEA_roundTrips(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			encoded, err := vec.expected.MarshalNMEA()
+			if err != nil {
+				t.Fatalf("MarshalNMEA failed: %v", err)
+			}
+
+			if err := sentence.VerifyChecksum(encoded); err != nil {
+				t.Fatalf("MarshalNMEA produced an invalid checksum: %v", err)
+			}
+
+			actual, err := Parse(encoded)
+			if err != nil {
+				t.Fatalf("error re-parsing %q: %v", encoded, err)
+			}
+
+			if *actual != vec.expected {
+				t.Errorf("round trip through %q should have produced %+v but produced %+v", encoded, vec.expected, *actual)
+			}
+		})
+	}
+}
+
+func TestGGA_MarshalNMEA_errors(t *testing.T) {
+	valid := goodTestData["GPS (GP)"].expected
+
+	for title, vec := range map[string]struct {
+		modify func(g *GGA)
+		errMsg string
+	}{
+		"Missing TalkerID": {
+			modify: func(g *GGA) { g.TalkerID = "" },
+			errMsg: "sentence segment [0] must be a valid sentence type but was \"GGA\"",
+		},
+		"Invalid NorthSouth": {
+			modify: func(g *GGA) { g.Latitude.Hemisphere = 0 },
+			errMsg: "sentence segment [3] must be a valid sentence.NorthSouth but was \"NorthSouth(0)\"",
+		},
+		"Invalid FixQuality": {
+			modify: func(g *GGA) { g.FixQuality = 42 },
+			errMsg: "sentence segment [6] must be a valid gga.FixQuality but was \"FixQuality(42)\"",
+		},
+	} {
+		t.Run(title, func(t *testing.T) {
+			g := valid
+			vec.modify(&g)
+
+			encoded, err := g.MarshalNMEA()
+			if err == nil || err.Error() != vec.errMsg {
+				t.Errorf("expected error %q but was %v (encoded %q)", vec.errMsg, err, encoded)
+			}
+		})
+	}
+}
+
+func TestGGA_GetSentenceType(t *testing.T) {
+	gga := &GGA{TalkerID: "GN"}
+	if st := gga.GetSentenceType(); st != "GNGGA" {
+		t.Errorf("GetSentenceType() should have returned \"GNGGA\" but returned \"%v\"", st)
+	}
+}
+
+func TestGGA_Talker(t *testing.T) {
+	gga := &GGA{TalkerID: "GL"}
+	if talker := gga.Talker(); talker != "GL" {
+		t.Errorf("Talker() should have returned \"GL\" but returned \"%v\"", talker)
+	}
+}
+
+func ExampleParse() {
+	s := "$GNGGA,092725.00,4717.11399,N,00833.91590,E,1,08,1.01,499.6,M,48.0,M,,*45"
+	gga, err := Parse(s)
+	_ = err
+
+	fmt.Printf("%s: %+v", gga.Talker(), gga)
+	// Output:
+	// GN: &{TalkerID:GN FixTime:{NMEATime:092725.00 Valid:true} Latitude:4717.11399,N Longitude:00833.91590,E FixQuality:1 SatCount:{Int:8 Valid:true} HDOP:{Float32:1.01 Valid:true} Altitude:{Float32:499.6 Valid:true} AltitudeUOM:M GeoidHeight:{Float32:48 Valid:true} GeoidHeightUOM:M DGPSUpdateAge:{Float32:0 Valid:false} DGPSStationID:{Int:0 Valid:false} Version:2.0 TagBlock:<nil>}
+}
+
+func ExampleGGA_MarshalNMEA() {
+	gga, err := Parse("$GNGGA,092725.00,4717.11399,N,00833.91590,E,1,08,1.01,499.6,M,48.0,M,,*45")
+	_ = err
+
+	encoded, err := gga.MarshalNMEA()
+	_ = err
+
+	fmt.Println(encoded)
+	// Output:
+	// $GNGGA,092725.00,4717.11399,N,00833.91590,E,1,08,1.01,499.6,M,48.0,M,,*45
+}
@@ -0,0 +1,42 @@
+package gga
+
+import (
+	"fmt"
+
+	"github.com/mab-go/nmea/sentence"
+)
+
+// SegmentParser extends sentence.SegmentParser to provide GGA-specific segment parsing methods.
+type SegmentParser struct {
+	sentence.SegmentParser
+}
+
+// AsFixQuality parses the input segment at the specified index as a FixQuality value. If p.Err()
+// is not nil, this function returns FixQuality(0) and leaves the error unchanged.
+func (p *SegmentParser) AsFixQuality(i int8) FixQuality {
+	s, ok := p.Segment(i)
+	if !ok {
+		return FixQuality(0)
+	}
+
+	ds, err := FixQualityString(s)
+	if err != nil || !p.AcceptsCase(s, ds.String()) {
+		p.Fail(i, "FixQuality", fmt.Sprintf("must be parsable as a FixQuality but was \"%s\"", s))
+
+		return FixQuality(0)
+	}
+
+	return ds
+}
+
+// AsMeters parses the input segment at the specified index as a unit of measure, which must be "M"
+// (meters, case-insensitive). If required is false (i.e., the measurement it qualifies is empty),
+// the segment may also be empty. If p.Err() is not nil, this function returns an empty string and
+// leaves the error unchanged.
+func (p *SegmentParser) AsMeters(i int8, required bool) string {
+	if !required && p.AsString(i) == "" {
+		return ""
+	}
+
+	return p.RequireString(i, "M")
+}
@@ -0,0 +1,76 @@
+package gll
+
+import (
+	"github.com/mab-go/nmea/sentence"
+)
+
+// NorthSouth is an alias for [sentence.NorthSouth].
+type NorthSouth = sentence.NorthSouth
+
+// NorthSouth values.
+const (
+	North = sentence.North
+	South = sentence.South
+)
+
+// NorthSouthString is an alias for [sentence.NorthSouthString].
+func NorthSouthString(s string) (NorthSouth, error) { return sentence.NorthSouthString(s) }
+
+// NorthSouthValues is an alias for [sentence.NorthSouthValues].
+func NorthSouthValues() []NorthSouth { return sentence.NorthSouthValues() }
+
+// NorthSouthStrings is an alias for [sentence.NorthSouthStrings].
+func NorthSouthStrings() []string { return sentence.NorthSouthStrings() }
+
+// EastWest is an alias for [sentence.EastWest].
+type EastWest = sentence.EastWest
+
+// EastWest values.
+const (
+	East = sentence.East
+	West = sentence.West
+)
+
+// EastWestString is an alias for [sentence.EastWestString].
+func EastWestString(s string) (EastWest, error) { return sentence.EastWestString(s) }
+
+// EastWestValues is an alias for [sentence.EastWestValues].
+func EastWestValues() []EastWest { return sentence.EastWestValues() }
+
+// EastWestStrings is an alias for [sentence.EastWestStrings].
+func EastWestStrings() []string { return sentence.EastWestStrings() }
+
+// DataStatus represents the status of a GPS fix. It can be either "A" (valid) or
+// "V" (invalid).
+type DataStatus int
+
+const (
+	// ValidDataStatus represents a valid GPS fix.
+	ValidDataStatus DataStatus = iota + 1 // A
+
+	// InvalidDataStatus represents an invalid GPS fix.
+	InvalidDataStatus // V
+)
+
+// Mode indicates the operating mode of a positioning system. It can be one of "A", "D",
+// "E", "M", or "N".
+type Mode int
+
+const (
+	// AutonomousMode represents an autonomous operating mode.
+	AutonomousMode Mode = iota + 1 // A
+
+	// DifferentialMode represents a differential operating mode.
+	DifferentialMode // D
+
+	// EstimatedMode represents an estimated (dead reckoning) operating mode.
+	EstimatedMode // E
+
+	// ManualInputMode represents a "manual input" operating mode.
+	ManualInputMode // M
+
+	// InvalidMode represents an invalid operating mode.
+	InvalidMode // N
+)
+
+//go:generate go run github.com/dmarkham/enumer@v1.6.3 -type=DataStatus,Mode -json -text -values -linecomment -transform=first-upper -output=enum_gen.go
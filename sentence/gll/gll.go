@@ -0,0 +1,200 @@
+// Package gll contains data structures and functions related to NMEA sentences of type "GLL"
+// (geographic position, latitude/longitude), as sent by any talker (e.g. "$GPGLL", "$GNGLL" or
+// "$GLGLL").
+package gll // import "github.com/mab-go/nmea/sentence/gll"
+
+import (
+	"encoding/json"
+
+	"github.com/mab-go/nmea/sentence"
+)
+
+//go:generate go run github.com/mab-go/nmea/tools/nmeaschema gll
+
+// GLL represents an NMEA input of type "GLL". It contains a position fix location (latitude and
+// longitude), the time of the position fix, and the fix status.
+type GLL struct {
+	// TalkerID identifies the talker that sent the input (e.g. "GP" or "GN"). It is the first two
+	// characters of element [0] of a GLL input.
+	TalkerID string `json:"talkerId"`
+
+	// Latitude is the "latitude" component of a GPS fix, e.g. 51° 6.7198674' N for
+	// "5106.7198674,N". It is elements [1] (the ddmm.mmmm value) and [2] (the hemisphere) of a GLL
+	// input. It is not valid if both are empty, as they are when the receiver has no fix.
+	Latitude sentence.Latitude `json:"latitude"`
+
+	// Longitude is the "longitude" component of a GPS fix, e.g. 114° 2.3587526' W for
+	// "11402.3587526,W". It is elements [3] (the dddmm.mmmm value) and [4] (the hemisphere) of a
+	// GLL input. It is not valid if both are empty.
+	Longitude sentence.Longitude `json:"longitude"`
+
+	// FixTime is the time at which the GPS fix was acquired (typically UTC). It is element [5] of
+	// a GLL input. It is not valid if the field is empty, which distinguishes a missing time from
+	// midnight. Wire format and validation: see [sentence.NMEATime].
+	FixTime sentence.Time `json:"fixTime"`
+
+	// DataStatus represents the status of the GPS fix. It can be either "A" (valid) or "V"
+	// (invalid). It is element [6] of a GLL input.
+	DataStatus DataStatus `json:"dataStatus,omitzero"`
+
+	// Mode indicates the operating mode of a positioning system. It is element [7] of a GLL
+	// input. It was added in NMEA 0183 version 2.3; if the input predates it, it is the zero
+	// value.
+	Mode Mode `json:"mode,omitzero"`
+
+	// Version is the NMEA 0183 version of the GLL input, as far as its fields tell:
+	// [sentence.Version23] if it has a Mode, and [sentence.Version20] otherwise. MarshalNMEA
+	// writes the fields of this version; if it is the zero Version, it writes Mode only if Mode is
+	// set. It is not part of the GLL input itself.
+	Version sentence.Version `json:"version,omitzero"`
+
+	// TagBlock is the tag block (see [sentence.TagBlock]) that preceded the GLL input, or nil if
+	// there was none. It is not part of the GLL input itself.
+	TagBlock *sentence.TagBlock `json:"tagBlock,omitzero"`
+}
+
+// GetSentenceType returns the type of NMEA input represented by the struct GLL: its talker
+// identifier followed by "GLL" (e.g. "GNGLL"). It represents element [0] of a GLL input.
+func (g GLL) GetSentenceType() string {
+	return g.TalkerID + "GLL"
+}
+
+// Talker returns the talker identifier of the GLL input (e.g. "GP" or "GN").
+func (g GLL) Talker() string {
+	return g.TalkerID
+}
+
+// MarshalNMEA encodes g as a GLL input (including its checksum), e.g.
+// "$GNGLL,4717.11364,N,00833.91565,E,092321.00,A,A*7E", with the fields of g.Version. It returns
+// an error if g.TalkerID is not a valid talker identifier or if an enum field that is written does
+// not hold one of its defined values.
+func (g GLL) MarshalNMEA() (string, error) {
+	w := &sentence.SegmentWriter{}
+	w.WriteTagBlock(g.TagBlock)
+	w.WriteSentenceType(g.TalkerID, "GLL")
+	w.WriteLatitude(g.Latitude)
+	w.WriteLongitude(g.Longitude)
+	w.WriteOptionalNMEATime(g.FixTime)
+	w.WriteEnum(g.DataStatus, g.DataStatus.IsADataStatus())
+
+	if g.Version.Encodes(sentence.Version23, g.Mode != 0) {
+		w.WriteEnum(g.Mode, g.Mode.IsAMode())
+	}
+
+	return w.Sentence()
+}
+
+// MarshalJSON encodes g as a JSON object whose members are "sentenceType" (e.g. "GNGLL") and the
+// fields of g, named by their json struct tags. See [sentence.MarshalSentenceJSON] for the encoding
+// of each type of field, and gll.schema.json for its JSON Schema.
+func (g GLL) MarshalJSON() ([]byte, error) {
+	type fields GLL // Has the fields of GLL, but not its MarshalJSON method
+
+	return sentence.MarshalSentenceJSON(g.GetSentenceType(), fields(g))
+}
+
+// UnmarshalJSON decodes a JSON object encoded by MarshalJSON into g, which is only modified if
+// data is valid. Its "sentenceType" member may be omitted.
+func (g *GLL) UnmarshalJSON(data []byte) error {
+	type fields GLL
+
+	var decoded GLL
+	if err := sentence.UnmarshalSentenceJSON(data, (*fields)(&decoded), &decoded); err != nil {
+		return err
+	}
+
+	*g = decoded
+
+	return nil
+}
+
+// Ensure that GLL properly implements the NMEASentence, Marshaler and JSON interfaces
+var (
+	_ sentence.NMEASentence = GLL{}
+	_ sentence.Marshaler    = GLL{}
+	_ json.Marshaler        = GLL{}
+	_ json.Unmarshaler      = (*GLL)(nil)
+)
+
+func init() {
+	sentence.Register("GLL", func(p *sentence.SegmentParser) (sentence.NMEASentence, error) {
+		gll, err := Decode(p)
+		if err != nil {
+			return nil, err
+		}
+
+		return gll, nil
+	})
+}
+
+// Parse parses a GLL input string from any talker and returns a pointer to a GLL struct (or an
+// error if the input is invalid).
+func Parse(s string) (*GLL, error) {
+	return ParseWithOptions(s)
+}
+
+// ParseWithOptions is like Parse, but parses the input according to opts (see
+// [sentence.ParseOptions]), e.g. to accept an input that has no checksum. With no options, it
+// is identical to Parse.
+func ParseWithOptions(s string, opts ...sentence.ParseOption) (*GLL, error) {
+	p := &sentence.SegmentParser{}
+	if err := p.ParseWithOptions(s, opts...); err != nil {
+		return nil, err
+	}
+
+	return Decode(p)
+}
+
+// Decode decodes a GLL input from p, which must already have parsed the input (see
+// [sentence.SegmentParser.Parse]). It returns a pointer to a GLL struct (or an error if the input
+// is invalid).
+func Decode(p *sentence.SegmentParser) (*GLL, error) {
+	gll := &GLL{}
+	if err := DecodeInto(p, gll); err != nil {
+		return nil, err
+	}
+
+	return gll, nil
+}
+
+// DecodeInto is like Decode, but decodes the sentence into dst, which is only modified if the
+// sentence is valid. Used with a reused [sentence.SegmentParser] and its ParseBytes method, it
+// decodes a sentence without a tag block without allocating.
+func DecodeInto(p *sentence.SegmentParser, dst *GLL) error {
+	segments := &SegmentParser{SegmentParser: *p}
+	segments.NameFields(fieldNames...)
+	gll := GLL{
+		TalkerID:   segments.RequireFormatter("GLL"), // Verify input type
+		Latitude:   segments.AsLatitude(1),
+		Longitude:  segments.AsLongitude(3),
+		FixTime:    segments.AsOptionalNMEATime(5),
+		DataStatus: segments.AsDataStatus(6),
+		Version:    versions.Detect(segments.Len()),
+		TagBlock:   p.TagBlock(),
+	}
+
+	if gll.Version >= sentence.Version23 {
+		gll.Mode = segments.AsMode(7)
+	}
+
+	if err := segments.Err(); err != nil {
+		return err
+	}
+
+	*dst = gll
+
+	return nil
+}
+
+// versions declares the NMEA 0183 versions of the GLL fields: version 2.3 appended Mode.
+var versions = sentence.FieldVersions{
+	{Version: sentence.Version20, Len: 7},
+	{Version: sentence.Version23, Len: 8},
+}
+
+// fieldNames names the GLL field held by each sentence segment, for use in errors (see
+// [sentence.FieldError]).
+var fieldNames = []string{
+	"TalkerID", "Latitude", "Latitude", "Longitude", "Longitude", "FixTime", "DataStatus",
+	"Mode",
+}
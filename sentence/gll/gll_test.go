@@ -0,0 +1,251 @@
+package gll
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mab-go/nmea/sentence"
+)
+
+type testVec struct {
+	input    string
+	expected GLL
+	json     string
+	errMsg   string
+}
+
+var goodTestData = map[string]testVec{
+	"GPS (GP)": {
+		input: "$GPGLL,3723.2475,N,12158.3416,W,161229.487,A,A*41",
+		json:  `{"sentenceType":"GPGLL","talkerId":"GP","latitude":"3723.2475,N","longitude":"12158.3416,W","fixTime":"161229.487","dataStatus":"A","mode":"A","version":"2.3"}`,
+		expected: GLL{
+			TalkerID:   "GP",
+			Latitude:   sentence.MustParseLatitude("3723.2475,N"),
+			Longitude:  sentence.MustParseLongitude("12158.3416,W"),
+			FixTime:    sentence.NewTime(sentence.NMEATime{Hour: 16, Minute: 12, Second: 29, Millisecond: 487}),
+			DataStatus: ValidDataStatus,
+			Mode:       AutonomousMode,
+			Version:    sentence.Version23,
+		},
+	},
+	"Multi-Constellation GNSS (GN)": {
+		input: "$GNGLL,4717.11364,N,00833.91565,E,092321.00,A,A*7E",
+		json:  `{"sentenceType":"GNGLL","talkerId":"GN","latitude":"4717.11364,N","longitude":"00833.91565,E","fixTime":"092321.00","dataStatus":"A","mode":"A","version":"2.3"}`,
+		expected: GLL{
+			TalkerID:   "GN",
+			Latitude:   sentence.MustParseLatitude("4717.11364,N"),
+			Longitude:  sentence.MustParseLongitude("00833.91565,E"),
+			FixTime:    sentence.NewTime(sentence.NMEATime{Hour: 9, Minute: 23, Second: 21, Millisecond: 0, FractionDigits: 2}),
+			DataStatus: ValidDataStatus,
+			Mode:       AutonomousMode,
+			Version:    sentence.Version23,
+		},
+	},
+	"Before NMEA 2.3 (No Mode)": {
+		input: "$GPGLL,3723.2475,N,12158.3416,W,161229.487,A*2C",
+		json:  `{"sentenceType":"GPGLL","talkerId":"GP","latitude":"3723.2475,N","longitude":"12158.3416,W","fixTime":"161229.487","dataStatus":"A","version":"2.0"}`,
+		expected: GLL{
+			TalkerID:   "GP",
+			Latitude:   sentence.MustParseLatitude("3723.2475,N"),
+			Longitude:  sentence.MustParseLongitude("12158.3416,W"),
+			FixTime:    sentence.NewTime(sentence.NMEATime{Hour: 16, Minute: 12, Second: 29, Millisecond: 487}),
+			DataStatus: ValidDataStatus,
+			Version:    sentence.Version20,
+		},
+	},
+}
+
+var badTestData = map[string]testVec{
+	"Bad Formatter": {
+		input:  "$GNGLX,4717.11364,N,00833.91565,E,092321.00,A,A*6A",
+		errMsg: "sentence segment [0] must be \"--GLL\" (case insensitive) but was \"GNGLX\"",
+	},
+	"Bad Mode": {
+		input:  "$GPGLL,3723.2475,N,12158.3416,W,161229.487,A,bad_Mode*1B",
+		errMsg: "sentence segment [7] must be parsable as a Mode but was \"bad_Mode\"",
+	},
+}
+
+func TestParse_goodData(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			actual, err := Parse(vec.input)
+			if err != nil {
+				t.Fatalf("error creating GLL from NMEA input \"%v\": %v", title, err)
+			}
+
+			if *actual != vec.expected {
+				t.Errorf("result should have been %+v but was %+v for NMEA input \"%v\"", vec.expected, *actual, title)
+			}
+		})
+	}
+}
+
+func TestParse_badSegments(t *testing.T) {
+	for title, vec := range badTestData {
+		t.Run(title, func(t *testing.T) {
+			gll, err := Parse(vec.input)
+			if err == nil {
+				t.Fatalf("parsing succeeded (but should not have) for test sentence %q", title)
+			}
+
+			if gll != nil {
+				t.Fatalf("result should have been <nil> but was %v for test sentence %q", gll, title)
+			}
+
+			if err.Error() != vec.errMsg {
+				t.Fatalf("error message should have been '%v' but was '%v' for test sentence %q", vec.errMsg, err.Error(), title)
+			}
+		})
+	}
+}
+
+func TestParse_registered(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			s, err := sentence.Parse(vec.input)
+			if err != nil {
+				t.Fatalf("sentence.Parse failed: %v", err)
+			}
+
+			actual, ok := s.(*GLL)
+			if !ok {
+				t.Fatalf("sentence.Parse should have returned a *GLL but returned %T", s)
+			}
+
+			if *actual != vec.expected {
+				t.Errorf("sentence.Parse result should have been %+v but was %+v", vec.expected, *actual)
+			}
+		})
+	}
+}
+
+func TestGLL_MarshalNMEA_roundTrips(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			encoded, err := vec.expected.MarshalNMEA()
+			if err != nil {
+				t.Fatalf("MarshalNMEA failed: %v", err)
+			}
+
+			if err := sentence.VerifyChecksum(encoded); err != nil {
+				t.Fatalf("MarshalNMEA produced an invalid checksum: %v", err)
+			}
+
+			actual, err := Parse(encoded)
+			if err != nil {
+				t.Fatalf("error re-parsing %q: %v", encoded, err)
+			}
+
+			if *actual != vec.expected {
+				t.Errorf("round trip through %q should have produced %+v but produced %+v", encoded, vec.expected, *actual)
+			}
+		})
+	}
+}
+
+func TestGLL_MarshalJSON(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			encoded, err := json.Marshal(vec.expected)
+			if err != nil {
+				t.Fatalf("json.Marshal failed: %v", err)
+			}
+
+			if string(encoded) != vec.json {
+				t.Errorf("expected %s but was %s", vec.json, encoded)
+			}
+
+			var actual GLL
+			if err := json.Unmarshal([]byte(vec.json), &actual); err != nil {
+				t.Fatalf("error decoding %s: %v", vec.json, err)
+			}
+
+			if actual != vec.expected {
+				t.Errorf("decoding %s should have produced %+v but produced %+v", vec.json, vec.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGLL_MarshalNMEA_errors(t *testing.T) {
+	g := goodTestData["GPS (GP)"].expected
+	g.Mode = 0
+
+	expected := "sentence segment [7] must be a valid gll.Mode but was \"Mode(0)\""
+	if _, err := g.MarshalNMEA(); err == nil || err.Error() != expected {
+		t.Errorf("expected error %q but was %v", expected, err)
+	}
+}
+
+func TestGLL_MarshalNMEA_version(t *testing.T) {
+	g := goodTestData["GPS (GP)"].expected
+	for _, vec := range []struct {
+		title    string
+		version  sentence.Version
+		mode     Mode
+		expected string
+	}{
+		{"Version 2.3", sentence.Version23, AutonomousMode, "$GPGLL,3723.2475,N,12158.3416,W,161229.487,A,A*41"},
+		{"Version 2.0", sentence.Version20, AutonomousMode, "$GPGLL,3723.2475,N,12158.3416,W,161229.487,A*2C"},
+		{"Later Version", sentence.Version411, AutonomousMode, "$GPGLL,3723.2475,N,12158.3416,W,161229.487,A,A*41"},
+		{"No Version", 0, AutonomousMode, "$GPGLL,3723.2475,N,12158.3416,W,161229.487,A,A*41"},
+		{"No Version or Mode", 0, 0, "$GPGLL,3723.2475,N,12158.3416,W,161229.487,A*2C"},
+	} {
+		t.Run(vec.title, func(t *testing.T) {
+			g.Version, g.Mode = vec.version, vec.mode
+			if actual, err := g.MarshalNMEA(); err != nil || actual != vec.expected {
+				t.Errorf("expected %q but was %q (error %v)", vec.expected, actual, err)
+			}
+		})
+	}
+}
+
+func TestGLL_GetSentenceType(t *testing.T) {
+	gll := &GLL{TalkerID: "GN"}
+	if st := gll.GetSentenceType(); st != "GNGLL" {
+		t.Errorf("GetSentenceType() should have returned \"GNGLL\" but returned \"%v\"", st)
+	}
+}
+
+func TestGLL_Talker(t *testing.T) {
+	gll := &GLL{TalkerID: "GA"}
+	if talker := gll.Talker(); talker != "GA" {
+		t.Errorf("Talker() should have returned \"GA\" but returned \"%v\"", talker)
+	}
+}
+
+func ExampleParse() {
+	s := "$GNGLL,4717.11364,N,00833.91565,E,092321.00,A,A*7E"
+	gll, err := Parse(s)
+	_ = err
+
+	fmt.Printf("%s: %+v", gll.Talker(), gll)
+	// Output:
+	// GN: &{TalkerID:GN Latitude:4717.11364,N Longitude:00833.91565,E FixTime:{NMEATime:092321.00 Valid:true} DataStatus:A Mode:A Version:2.3 TagBlock:<nil>}
+}
+
+func ExampleGLL_MarshalNMEA() {
+	gll, err := Parse("$GNGLL,4717.11364,N,00833.91565,E,092321.00,A,A*7E")
+	_ = err
+
+	encoded, err := gll.MarshalNMEA()
+	_ = err
+
+	fmt.Println(encoded)
+	// Output:
+	// $GNGLL,4717.11364,N,00833.91565,E,092321.00,A,A*7E
+}
+
+func ExampleGLL_MarshalJSON() {
+	gll, err := Parse("\\s:r3669961*0F\\$GNGLL,4717.11364,N,00833.91565,E,092321.00,A,A*7E")
+	_ = err
+
+	encoded, err := json.Marshal(gll)
+	_ = err
+
+	fmt.Println(string(encoded))
+	// Output:
+	// {"sentenceType":"GNGLL","talkerId":"GN","latitude":"4717.11364,N","longitude":"00833.91565,E","fixTime":"092321.00","dataStatus":"A","mode":"A","version":"2.3","tagBlock":{"source":"r3669961"}}
+}
@@ -0,0 +1,48 @@
+package gll
+
+import (
+	"fmt"
+
+	"github.com/mab-go/nmea/sentence"
+)
+
+// SegmentParser extends sentence.SegmentParser to provide GLL-specific segment parsing methods.
+type SegmentParser struct {
+	sentence.SegmentParser
+}
+
+// AsDataStatus parses the input segment at the specified index as a DataStatus value. If p.Err()
+// is not nil, this function returns DataStatus(0) and leaves the error unchanged.
+func (p *SegmentParser) AsDataStatus(i int8) DataStatus {
+	s, ok := p.Segment(i)
+	if !ok {
+		return DataStatus(0)
+	}
+
+	ds, err := DataStatusString(s)
+	if err != nil || !p.AcceptsCase(s, ds.String()) {
+		p.Fail(i, "DataStatus", fmt.Sprintf("must be parsable as a DataStatus but was \"%s\"", s))
+
+		return DataStatus(0)
+	}
+
+	return ds
+}
+
+// AsMode parses the input segment at the specified index as a Mode value. If p.Err() is not
+// nil, this function returns Mode(0) and leaves the error unchanged.
+func (p *SegmentParser) AsMode(i int8) Mode {
+	s, ok := p.Segment(i)
+	if !ok {
+		return Mode(0)
+	}
+
+	m, err := ModeString(s)
+	if err != nil || !p.AcceptsCase(s, m.String()) {
+		p.Fail(i, "Mode", fmt.Sprintf("must be parsable as a Mode but was \"%s\"", s))
+
+		return Mode(0)
+	}
+
+	return m
+}
@@ -1,59 +1,66 @@
 package gpgga
 
-// NorthSouth indicates the hemisphere in which a latitude value resides. It can be either
-// "N" or "S".
-type NorthSouth int
-
-const (
-	// North represents the northern hemisphere.
-	North NorthSouth = iota + 1 // N
-
-	// South represents the southern hemisphere.
-	South // S
+import (
+	"github.com/mab-go/nmea/sentence/gga"
 )
 
-// EastWest indicates the hemisphere in which a longitude value resides. It can be either
-// "E" or "W".
-type EastWest int
+// NorthSouth is an alias for [gga.NorthSouth].
+type NorthSouth = gga.NorthSouth
 
+// NorthSouth values.
 const (
-	// East represents the eastern hemisphere.
-	East EastWest = iota + 1 // E
-
-	// West represents the western hemisphere.
-	West // W
+	North = gga.North
+	South = gga.South
 )
 
-// FixQuality indicates the type/quality of a GPS fix.
-type FixQuality int
+// NorthSouthString is an alias for [gga.NorthSouthString].
+func NorthSouthString(s string) (NorthSouth, error) { return gga.NorthSouthString(s) }
 
-const (
-	// InvalidFixQuality represents an invalid GPS fix quality. Its value is 0.
-	InvalidFixQuality FixQuality = iota + 1 // 0
+// NorthSouthValues is an alias for [gga.NorthSouthValues].
+func NorthSouthValues() []NorthSouth { return gga.NorthSouthValues() }
 
-	// GPSFixQuality represents a standard GPS fix quality. Its value is 1.
-	GPSFixQuality // 1
+// NorthSouthStrings is an alias for [gga.NorthSouthStrings].
+func NorthSouthStrings() []string { return gga.NorthSouthStrings() }
 
-	// DGPSFixQuality represents a differential GPS (DGPS) fix quality. Its value is 2.
-	DGPSFixQuality // 2
+// EastWest is an alias for [gga.EastWest].
+type EastWest = gga.EastWest
 
-	// PPSFixQuality represents a precise positioning system (PPS) fix quality. Its value is 3.
-	PPSFixQuality // 3
+// EastWest values.
+const (
+	East = gga.East
+	West = gga.West
+)
 
-	// RTKFixQuality represents a Real Time Kinematic fix quality. Its value is 4.
-	RTKFixQuality // 4
+// EastWestString is an alias for [gga.EastWestString].
+func EastWestString(s string) (EastWest, error) { return gga.EastWestString(s) }
 
-	// FloatRTKFixQuality represents a Float Real Time Kinematic fix quality. Its value is 5.
-	FloatRTKFixQuality // 5
+// EastWestValues is an alias for [gga.EastWestValues].
+func EastWestValues() []EastWest { return gga.EastWestValues() }
 
-	// EstimatedFixQuality represents an estimated (dead reckoning) fix quality. Its value is 6.
-	EstimatedFixQuality // 6
+// EastWestStrings is an alias for [gga.EastWestStrings].
+func EastWestStrings() []string { return gga.EastWestStrings() }
 
-	// ManualInputFixQuality represents a "manual input mode" fix quality. Its value is 7.
-	ManualInputFixQuality // 7
+// FixQuality is an alias for [gga.FixQuality].
+type FixQuality = gga.FixQuality
 
-	// SimulationFixQuality represents a "simulation mode" fix quality. Its value is 8.
-	SimulationFixQuality // 8
+// FixQuality values.
+const (
+	InvalidFixQuality     = gga.InvalidFixQuality
+	GPSFixQuality         = gga.GPSFixQuality
+	DGPSFixQuality        = gga.DGPSFixQuality
+	PPSFixQuality         = gga.PPSFixQuality
+	RTKFixQuality         = gga.RTKFixQuality
+	FloatRTKFixQuality    = gga.FloatRTKFixQuality
+	EstimatedFixQuality   = gga.EstimatedFixQuality
+	ManualInputFixQuality = gga.ManualInputFixQuality
+	SimulationFixQuality  = gga.SimulationFixQuality
 )
 
-//go:generate go run github.com/dmarkham/enumer@v1.6.3 -type=NorthSouth,EastWest,FixQuality -text -linecomment -transform=first-upper -output=enum_gen.go
+// FixQualityString is an alias for [gga.FixQualityString].
+func FixQualityString(s string) (FixQuality, error) { return gga.FixQualityString(s) }
+
+// FixQualityValues is an alias for [gga.FixQualityValues].
+func FixQualityValues() []FixQuality { return gga.FixQualityValues() }
+
+// FixQualityStrings is an alias for [gga.FixQualityStrings].
+func FixQualityStrings() []string { return gga.FixQualityStrings() }
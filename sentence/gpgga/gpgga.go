@@ -1,79 +1,22 @@
 // Package gpgga contains data structures and functions related to NMEA sentences
 // of type "GPGGA".
+//
+// The types in this package are thin, GPS-only ("GP" talker) views of those in package gga, which
+// accepts GGA sentences from any talker.
 package gpgga // import "github.com/mab-go/nmea/sentence/gpgga"
 
 import (
+	"encoding/json"
+
 	"github.com/mab-go/nmea/sentence"
+	"github.com/mab-go/nmea/sentence/gga"
 )
 
-// GPGGA represents an NMEA sentence of type "GPGGA".
-type GPGGA struct {
-	// FixTime is the time at which the GPS fix was acquired (typically UTC). It is element [1] of
-	// a GPGGA sentence. An empty time field yields a zero [sentence.NMEATime] without error. Wire
-	// format and validation: see [sentence.NMEATime].
-	FixTime sentence.NMEATime
-
-	// Latitude is the "latitude" component of a GPS fix. The format is (d)ddmm.mmmm. For example,
-	// the value 4807.038 represents a latitude value of 48° 7.038'. It is element [2] of a GPGGA
-	// sentence.
-	Latitude float64
-
-	// NorthSouth indicates the hemisphere in which the latitude value resides. It is element [3] of
-	// a GPGGA sentence.
-	NorthSouth NorthSouth
-
-	// Longitude is the "longitude" component of a GPS fix. The format is (d)ddmm.mmmm. For example,
-	// the value 01131.215 represents a longitude value of 11° 31.215'. It is element [4] of a GPGGA
-	// sentence.
-	Longitude float64
-
-	// EastWest indicates the hemisphere in which the longitude value resides. It is element [5] of
-	// a GPGGA sentence.
-	EastWest EastWest
-
-	// FixQuality indicates the type/quality of the GPS fix. It is element [6] of a GPGGA sentence.
-	FixQuality FixQuality
-
-	// SatCount is the number of satellites used to obtain the GPS fix. It is element [7] of a GPGGA
-	// sentence.
-	SatCount int8
-
-	// HDOP is the horizontal dilution of precision (HDOP) of the GPS fix. It indicates a relative
-	// "confidence" level in the precision reported. Generally an HDOP of 1.0 is the best possible
-	// value. It is element [8] of a GPGGA sentence.
-	//
-	// Refer to https://en.wikipedia.org/wiki/Dilution_of_precision_(navigation)#Meaning_of_DOP_Values
-	// for a better understanding of the meaning of HDOP values.
-	HDOP float32
-
-	// Altitude is the above or below mean sea level for the GPS fix. Its unit of measure is
-	// specified by the AltitudeUOM field. It is element [9] of a GPGGA sentence.
-	Altitude float32
-
-	// AltitudeUOM is the unit of measure in which Altitude is expressed. It should always be "M"
-	// (meters). It is element [10] of a GPGGA sentence.
-	AltitudeUOM string
-
-	// GeoidHeight is the height of the geoid above or below the WGS84 ellipsoid. Its unit of
-	// measure is specified by the GeoidHeightUOM field. It is element [11] of a GPGGA sentence.
-	GeoidHeight float32
-
-	// GeoidHeightUOM is the unit of measure in which GeoidHeight is expressed. It should always be
-	// "M" (meters). It is element [12] of a GPGGA sentence.
-	GeoidHeightUOM string
-
-	// DGPSUpdateAge is the age (in seconds) since the last update from a differential GPS reference
-	// station. It is element [13] of a GPGGA sentence. If differential GPS was not used to obtain
-	// the fix, (i.e., if FixQuality is not 2), then both DGPSUpdateAge and DGPSStationID should be
-	// 0.
-	DGPSUpdateAge float32
-
-	// DGPSStationID is the unique identifier for the differential GPS reference station that was
-	// used to obtain the GPS fix (if DGPS was used). It is element [14] of a GPGGA sentence. If
-	// differential GPS was not used to obtain the fix, (i.e., if FixQuality is not 2), then both
-	// DGPSUpdateAge and DGPSStationID should be 0.
-	DGPSStationID int16
-}
+//go:generate go run github.com/mab-go/nmea/tools/nmeaschema gpgga
+
+// GPGGA represents an NMEA sentence of type "GPGGA". It has the same fields as [gga.GGA]; see
+// that type for their documentation.
+type GPGGA gga.GGA
 
 // GetSentenceType returns the type of NMEA sentence represented by the struct GPGGA. It always
 // returns "GPGGA". It represents element [0] of a GPGGA sentence.
@@ -81,38 +24,75 @@ func (g GPGGA) GetSentenceType() string {
 	return "GPGGA"
 }
 
-// Ensure that GPGGA properly implements the NMEASentence interface
-var _ sentence.NMEASentence = GPGGA{}
+// Talker returns the talker identifier of the GPGGA sentence. It always returns "GP".
+func (g GPGGA) Talker() string {
+	return sentence.TalkerGPS
+}
+
+// MarshalNMEA encodes g as a GPGGA sentence (including its checksum). See [gga.GGA.MarshalNMEA].
+func (g GPGGA) MarshalNMEA() (string, error) {
+	g.TalkerID = sentence.TalkerGPS
+
+	return gga.GGA(g).MarshalNMEA()
+}
+
+// MarshalJSON encodes g as a JSON object whose "sentenceType" member is "GPGGA" and whose
+// "talkerId" member is "GP". See [gga.GGA.MarshalJSON].
+func (g GPGGA) MarshalJSON() ([]byte, error) {
+	type fields GPGGA // Has the fields of GPGGA, but not its MarshalJSON method
+
+	g.TalkerID = sentence.TalkerGPS
+
+	return sentence.MarshalSentenceJSON(g.GetSentenceType(), fields(g))
+}
+
+// UnmarshalJSON decodes a JSON object encoded by MarshalJSON into g, which is only modified if
+// data is valid. See [gga.GGA.UnmarshalJSON].
+func (g *GPGGA) UnmarshalJSON(data []byte) error {
+	type fields GPGGA
+
+	var decoded GPGGA
+	if err := sentence.UnmarshalSentenceJSON(data, (*fields)(&decoded), &decoded); err != nil {
+		return err
+	}
+
+	*g = decoded
+
+	return nil
+}
+
+// Ensure that GPGGA properly implements the NMEASentence, Marshaler and JSON interfaces
+var (
+	_ sentence.NMEASentence = GPGGA{}
+	_ sentence.Marshaler    = GPGGA{}
+	_ json.Marshaler        = GPGGA{}
+	_ json.Unmarshaler      = (*GPGGA)(nil)
+)
 
 // Parse parses a GPGGA sentence string and returns a pointer to a GPGGA struct (or an error if
-// the sentence is invalid).
+// the sentence is invalid). Use [gga.Parse] to accept GGA sentences from any talker.
 func Parse(s string) (*GPGGA, error) {
-	segments := &SegmentParser{}
-	if err := segments.Parse(s); err != nil {
+	return ParseWithOptions(s)
+}
+
+// ParseWithOptions is like Parse, but parses the sentence according to opts (see
+// [sentence.ParseOptions]), e.g. to accept a sentence that has no checksum. With no options, it
+// is identical to Parse.
+func ParseWithOptions(s string, opts ...sentence.ParseOption) (*GPGGA, error) {
+	p := &sentence.SegmentParser{}
+	if err := p.ParseWithOptions(s, opts...); err != nil {
 		return nil, err
 	}
 
-	_ = segments.RequireString(0, "GPGGA") // Verify sentence type
-	gpgga := &GPGGA{
-		FixTime:        segments.AsNMEATime(1),
-		Latitude:       segments.AsFloat64(2),
-		NorthSouth:     segments.AsNorthSouth(3),
-		Longitude:      segments.AsFloat64(4),
-		EastWest:       segments.AsEastWest(5),
-		FixQuality:     segments.AsFixQuality(6),
-		SatCount:       segments.AsInt8(7),
-		HDOP:           segments.AsFloat32(8),
-		Altitude:       segments.AsFloat32(9),
-		AltitudeUOM:    segments.RequireString(10, "M"),
-		GeoidHeight:    segments.AsFloat32(11),
-		GeoidHeightUOM: segments.RequireString(12, "M"),
-		DGPSUpdateAge:  segments.AsFloat32(13),
-		DGPSStationID:  segments.AsInt16(14),
+	_ = p.RequireString(0, "GPGGA") // Verify sentence type
+	if err := p.Err(); err != nil {
+		return nil, err
 	}
 
-	if err := segments.Err(); err != nil {
+	g, err := gga.Decode(p)
+	if err != nil {
 		return nil, err
 	}
 
-	return gpgga, nil
+	return (*GPGGA)(g), nil
 }
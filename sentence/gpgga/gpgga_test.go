@@ -1,70 +1,73 @@
 package gpgga
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
 	"github.com/mab-go/nmea/sentence"
+	"github.com/mab-go/nmea/sentence/gga"
 )
 
 type testVec struct {
 	input    string
 	expected GPGGA
+	json     string
 	errMsg   string
 }
 
 var goodTestData = map[string]testVec{
 	"NMEA Generator [1/1]": {
 		input: "$GPGGA,174800.864,4002.741,N,07618.550,W,1,12,1.0,0.0,M,0.0,M,,*70",
+		json:  `{"sentenceType":"GPGGA","talkerId":"GP","fixTime":"174800.864","latitude":"4002.741,N","longitude":"07618.550,W","fixQuality":"1","satCount":12,"hdop":1,"altitude":0,"altitudeUom":"M","geoidHeight":0,"geoidHeightUom":"M","dgpsUpdateAge":null,"dgpsStationId":null,"version":"2.0"}`,
 		expected: GPGGA{
-			FixTime:        sentence.NMEATime{Hour: 17, Minute: 48, Second: 0, Millisecond: 864},
-			Latitude:       4002.741,
-			NorthSouth:     North,
-			Longitude:      7618.55,
-			EastWest:       West,
+			FixTime:        sentence.NewTime(sentence.NMEATime{Hour: 17, Minute: 48, Second: 0, Millisecond: 864}),
+			Latitude:       sentence.MustParseLatitude("4002.741,N"),
+			Longitude:      sentence.MustParseLongitude("07618.550,W"),
 			FixQuality:     GPSFixQuality,
-			SatCount:       12,
-			HDOP:           1.0,
-			Altitude:       0.0,
+			SatCount:       sentence.NewInt(12),
+			HDOP:           sentence.NewFloat32(1.0),
+			Altitude:       sentence.NewFloat32(0.0),
 			AltitudeUOM:    "M",
-			GeoidHeight:    0.0,
+			GeoidHeight:    sentence.NewFloat32(0.0),
 			GeoidHeightUOM: "M",
+			Version:        sentence.Version20,
 		},
 	},
 	"Garmin G12 (v 4.57)": {
 		input: "$GPGGA,183730,3907.356,N,12102.482,W,1,05,1.6,646.4,M,-24.1,M,300,123*76",
+		json:  `{"sentenceType":"GPGGA","talkerId":"GP","fixTime":"183730","latitude":"3907.356,N","longitude":"12102.482,W","fixQuality":"1","satCount":5,"hdop":1.6,"altitude":646.4,"altitudeUom":"M","geoidHeight":-24.1,"geoidHeightUom":"M","dgpsUpdateAge":300,"dgpsStationId":123,"version":"2.0"}`,
 		expected: GPGGA{
-			FixTime:        sentence.NMEATime{Hour: 18, Minute: 37, Second: 30, Millisecond: 0},
-			Latitude:       3907.356,
-			NorthSouth:     North,
-			Longitude:      12102.482,
-			EastWest:       West,
+			FixTime:        sentence.NewTime(sentence.NMEATime{Hour: 18, Minute: 37, Second: 30, Millisecond: 0, FractionDigits: sentence.NoFractionDigits}),
+			Latitude:       sentence.MustParseLatitude("3907.356,N"),
+			Longitude:      sentence.MustParseLongitude("12102.482,W"),
 			FixQuality:     GPSFixQuality,
-			SatCount:       5,
-			HDOP:           1.6,
-			Altitude:       646.4,
+			SatCount:       sentence.NewInt(5),
+			HDOP:           sentence.NewFloat32(1.6),
+			Altitude:       sentence.NewFloat32(646.4),
 			AltitudeUOM:    "M",
-			GeoidHeight:    -24.1,
+			GeoidHeight:    sentence.NewFloat32(-24.1),
 			GeoidHeightUOM: "M",
-			DGPSUpdateAge:  300.0,
-			DGPSStationID:  123,
+			DGPSUpdateAge:  sentence.NewFloat32(300.0),
+			DGPSStationID:  sentence.NewInt(123),
+			Version:        sentence.Version20,
 		},
 	},
 	"Garmin eTrex Summit": {
 		input: "$GPGGA,002454,3553.5295,N,13938.6570,E,1,05,2.2,18.3,M,39.0,M,,*7F",
+		json:  `{"sentenceType":"GPGGA","talkerId":"GP","fixTime":"002454","latitude":"3553.5295,N","longitude":"13938.6570,E","fixQuality":"1","satCount":5,"hdop":2.2,"altitude":18.3,"altitudeUom":"M","geoidHeight":39,"geoidHeightUom":"M","dgpsUpdateAge":null,"dgpsStationId":null,"version":"2.0"}`,
 		expected: GPGGA{
-			FixTime:        sentence.NMEATime{Hour: 0, Minute: 24, Second: 54, Millisecond: 0},
-			Latitude:       3553.5295,
-			NorthSouth:     North,
-			Longitude:      13938.657,
-			EastWest:       East,
+			FixTime:        sentence.NewTime(sentence.NMEATime{Hour: 0, Minute: 24, Second: 54, Millisecond: 0, FractionDigits: sentence.NoFractionDigits}),
+			Latitude:       sentence.MustParseLatitude("3553.5295,N"),
+			Longitude:      sentence.MustParseLongitude("13938.6570,E"),
 			FixQuality:     GPSFixQuality,
-			SatCount:       5,
-			HDOP:           2.2,
-			Altitude:       18.3,
+			SatCount:       sentence.NewInt(5),
+			HDOP:           sentence.NewFloat32(2.2),
+			Altitude:       sentence.NewFloat32(18.3),
 			AltitudeUOM:    "M",
-			GeoidHeight:    39.0,
+			GeoidHeight:    sentence.NewFloat32(39.0),
 			GeoidHeightUOM: "M",
+			Version:        sentence.Version20,
 		},
 	},
 }
@@ -80,7 +83,7 @@ var badTestData = map[string]testVec{
 	},
 	"Bad Latitude": {
 		input:  "$GPGGA,174800.864,bad_Latitude,N,07618.550,W,1,12,1.0,0.0,M,0.0,M,,*62",
-		errMsg: "sentence segment [2] must be parsable as a float64 but was \"bad_Latitude\"",
+		errMsg: "sentence segment [2] must be parsable as a Latitude but was \"bad_Latitude\"",
 	},
 	"Bad NorthSouth": {
 		input:  "$GPGGA,174800.864,4002.741,bad_NorthSouth,07618.550,W,1,12,1.0,0.0,M,0.0,M,,*1C",
@@ -88,7 +91,7 @@ var badTestData = map[string]testVec{
 	},
 	"Bad Longitude": {
 		input:  "$GPGGA,174800.864,4002.741,N,bad_Longitude,W,1,12,1.0,0.0,M,0.0,M,,*2D",
-		errMsg: "sentence segment [4] must be parsable as a float64 but was \"bad_Longitude\"",
+		errMsg: "sentence segment [4] must be parsable as a Longitude but was \"bad_Longitude\"",
 	},
 	"Bad EastWest": {
 		input:  "$GPGGA,174800.864,4002.741,N,07618.550,bad_EastWest,1,12,1.0,0.0,M,0.0,M,,*09",
@@ -158,9 +161,7 @@ func TestParse_goodData(t *testing.T) {
 			expected := vec.expected
 			assertMatches(t, title, "FixTime", expected.FixTime, actual.FixTime)
 			assertMatches(t, title, "Latitude", expected.Latitude, actual.Latitude)
-			assertMatches(t, title, "NorthSouth", expected.NorthSouth, actual.NorthSouth)
 			assertMatches(t, title, "Longitude", expected.Longitude, actual.Longitude)
-			assertMatches(t, title, "EastWest", expected.EastWest, actual.EastWest)
 			assertMatches(t, title, "FixQuality", expected.FixQuality, actual.FixQuality)
 			assertMatches(t, title, "SatCount", expected.SatCount, actual.SatCount)
 			assertMatches(t, title, "HDOP", expected.HDOP, actual.HDOP)
@@ -174,6 +175,42 @@ func TestParse_goodData(t *testing.T) {
 	}
 }
 
+func TestParseWithOptions(t *testing.T) {
+	// No checksum, padded fields, lower-case hemispheres and no DGPS fields
+	input := "$GPGGA, 183730,3907.356,n,12102.482,w,1,05,1.6,646.4,M,-24.1,M \r\n"
+
+	if _, err := Parse(input); err == nil {
+		t.Fatal("parsing succeeded (but should not have) without options")
+	}
+
+	actual, err := ParseWithOptions(input, sentence.Lenient())
+	if err != nil {
+		t.Fatalf("error creating GPGGA from lenient NMEA input: %v", err)
+	}
+
+	expected := goodTestData["Garmin G12 (v 4.57)"].expected
+	expected.TalkerID = sentence.TalkerGPS
+	expected.DGPSUpdateAge = sentence.Float32{}
+	expected.DGPSStationID = sentence.Int{}
+	if *actual != expected {
+		t.Errorf("result should have been %+v but was %+v", expected, *actual)
+	}
+}
+
+func TestParseWithOptions_caseSensitive(t *testing.T) {
+	input := "$GPGGA,183730,3907.356,n,12102.482,w,1,05,1.6,646.4,M,-24.1,M,,*75"
+
+	if _, err := ParseWithOptions(input); err != nil {
+		t.Fatalf("expected lower-case hemispheres to be accepted by default but got %v", err)
+	}
+
+	_, err := ParseWithOptions(input, sentence.WithCaseSensitive())
+	expected := "sentence segment [3] must be parsable as a NorthSouth but was \"n\""
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error %q but was %v", expected, err)
+	}
+}
+
 func TestParse_invalidChecksum(t *testing.T) {
 	gpgga, err := Parse("$GPGGA,174800.864,4002.741,N,07618.550,W,1,12,1.0,0.0,M,0.0,M,,*42")
 	if err == nil {
@@ -209,6 +246,78 @@ func TestParse_badSegments(t *testing.T) {
 	}
 }
 
+// TestParse_registered verifies that sentence.Parse decodes a GPGGA sentence with the decoder that
+// package gga registers for every talker, so it returns a *gga.GGA rather than a *GPGGA.
+func TestParse_registered(t *testing.T) {
+	input := "$GPGGA,174800.864,4002.741,N,07618.550,W,1,12,1.0,0.0,M,0.0,M,,*70"
+	s, err := sentence.Parse(input)
+	if err != nil {
+		t.Fatalf("sentence.Parse failed: %v", err)
+	}
+
+	actual, ok := s.(*gga.GGA)
+	if !ok {
+		t.Fatalf("sentence.Parse should have returned a *gga.GGA but returned %T", s)
+	}
+
+	expected, err := Parse(input)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if *actual != gga.GGA(*expected) {
+		t.Errorf("sentence.Parse result should have been %+v but was %+v", expected, actual)
+	}
+}
+
+func TestGPGGA_MarshalNMEA_roundTrips(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			encoded, err := vec.expected.MarshalNMEA() // TalkerID is unset; "GP" is implied
+			if err != nil {
+				t.Fatalf("MarshalNMEA failed: %v", err)
+			}
+
+			actual, err := Parse(encoded)
+			if err != nil {
+				t.Fatalf("error re-parsing %q: %v", encoded, err)
+			}
+
+			expected := vec.expected
+			expected.TalkerID = "GP"
+			if *actual != expected {
+				t.Errorf("round trip through %q should have produced %+v but produced %+v", encoded, expected, *actual)
+			}
+		})
+	}
+}
+
+func TestGPGGA_MarshalJSON(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			encoded, err := json.Marshal(vec.expected) // TalkerID is unset; "GP" is implied
+			if err != nil {
+				t.Fatalf("json.Marshal failed: %v", err)
+			}
+
+			if string(encoded) != vec.json {
+				t.Errorf("expected %s but was %s", vec.json, encoded)
+			}
+
+			var actual GPGGA
+			if err := json.Unmarshal([]byte(vec.json), &actual); err != nil {
+				t.Fatalf("error decoding %s: %v", vec.json, err)
+			}
+
+			expected := vec.expected
+			expected.TalkerID = "GP"
+			if actual != expected {
+				t.Errorf("decoding %s should have produced %+v but produced %+v", vec.json, expected, actual)
+			}
+		})
+	}
+}
+
 func TestGPGGA_GetSentenceType(t *testing.T) {
 	gpgga := &GPGGA{}
 	if st := gpgga.GetSentenceType(); st != "GPGGA" {
@@ -216,6 +325,13 @@ func TestGPGGA_GetSentenceType(t *testing.T) {
 	}
 }
 
+func TestGPGGA_Talker(t *testing.T) {
+	gpgga := &GPGGA{}
+	if talker := gpgga.Talker(); talker != "GP" {
+		t.Errorf("Talker() should have returned \"GP\" but returned \"%v\"", talker)
+	}
+}
+
 func ExampleParse() {
 	s := "$GPGGA,023042,3907.3837,N,12102.4684,W,1,04,2.3,507.3,M,-24.1,M,,*75"
 	gpgga, err := Parse(s)
@@ -223,5 +339,5 @@ func ExampleParse() {
 
 	fmt.Printf("%+v", gpgga)
 	// Output:
-	// &{FixTime:023042.000 Latitude:3907.3837 NorthSouth:N Longitude:12102.4684 EastWest:W FixQuality:1 SatCount:4 HDOP:2.3 Altitude:507.3 AltitudeUOM:M GeoidHeight:-24.1 GeoidHeightUOM:M DGPSUpdateAge:0 DGPSStationID:0}
+	// &{TalkerID:GP FixTime:{NMEATime:023042 Valid:true} Latitude:3907.3837,N Longitude:12102.4684,W FixQuality:1 SatCount:{Int:4 Valid:true} HDOP:{Float32:2.3 Valid:true} Altitude:{Float32:507.3 Valid:true} AltitudeUOM:M GeoidHeight:{Float32:-24.1 Valid:true} GeoidHeightUOM:M DGPSUpdateAge:{Float32:0 Valid:false} DGPSStationID:{Int:0 Valid:false} Version:2.0 TagBlock:<nil>}
 }
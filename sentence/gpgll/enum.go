@@ -1,60 +1,80 @@
 package gpgll
 
-// NorthSouth indicates the hemisphere in which a latitude value resides. It can be either
-// "N" or "S".
-type NorthSouth int
+import (
+	"github.com/mab-go/nmea/sentence/gll"
+)
 
-const (
-	// North represents the northern hemisphere.
-	North NorthSouth = iota + 1 // N
+// NorthSouth is an alias for [gll.NorthSouth].
+type NorthSouth = gll.NorthSouth
 
-	// South represents the southern hemisphere.
-	South // S
+// NorthSouth values.
+const (
+	North = gll.North
+	South = gll.South
 )
 
-// EastWest indicates the hemisphere in which a longitude value resides. It can be either
-// "E" or "W".
-type EastWest int
+// NorthSouthString is an alias for [gll.NorthSouthString].
+func NorthSouthString(s string) (NorthSouth, error) { return gll.NorthSouthString(s) }
 
-const (
-	// East represents the eastern hemisphere.
-	East EastWest = iota + 1 // E
+// NorthSouthValues is an alias for [gll.NorthSouthValues].
+func NorthSouthValues() []NorthSouth { return gll.NorthSouthValues() }
 
-	// West represents the western hemisphere.
-	West // W
-)
+// NorthSouthStrings is an alias for [gll.NorthSouthStrings].
+func NorthSouthStrings() []string { return gll.NorthSouthStrings() }
 
-// DataStatus represents the status of a GPS fix. It can be either "A" (valid) or
-// "V" (invalid).
-type DataStatus int
+// EastWest is an alias for [gll.EastWest].
+type EastWest = gll.EastWest
 
+// EastWest values.
 const (
-	// ValidDataStatus represents a valid GPS fix.
-	ValidDataStatus DataStatus = iota + 1 // A
-
-	// InvalidDataStatus represents an invalid GPS fix.
-	InvalidDataStatus // V
+	East = gll.East
+	West = gll.West
 )
 
-// Mode indicates the operating mode of a positioning system. It can be one of "A", "D",
-// "E", "M", or "N".
-type Mode int
+// EastWestString is an alias for [gll.EastWestString].
+func EastWestString(s string) (EastWest, error) { return gll.EastWestString(s) }
+
+// EastWestValues is an alias for [gll.EastWestValues].
+func EastWestValues() []EastWest { return gll.EastWestValues() }
 
+// EastWestStrings is an alias for [gll.EastWestStrings].
+func EastWestStrings() []string { return gll.EastWestStrings() }
+
+// DataStatus is an alias for [gll.DataStatus].
+type DataStatus = gll.DataStatus
+
+// DataStatus values.
 const (
-	// AutonomousMode represents an autonomous operating mode.
-	AutonomousMode Mode = iota + 1 // A
+	ValidDataStatus   = gll.ValidDataStatus
+	InvalidDataStatus = gll.InvalidDataStatus
+)
+
+// DataStatusString is an alias for [gll.DataStatusString].
+func DataStatusString(s string) (DataStatus, error) { return gll.DataStatusString(s) }
 
-	// DifferentialMode represents a differential operating mode.
-	DifferentialMode // D
+// DataStatusValues is an alias for [gll.DataStatusValues].
+func DataStatusValues() []DataStatus { return gll.DataStatusValues() }
 
-	// EstimatedMode represents an estimated (dead reckoning) operating mode.
-	EstimatedMode // E
+// DataStatusStrings is an alias for [gll.DataStatusStrings].
+func DataStatusStrings() []string { return gll.DataStatusStrings() }
 
-	// ManualInputMode represents a "manual input" operating mode.
-	ManualInputMode // M
+// Mode is an alias for [gll.Mode].
+type Mode = gll.Mode
 
-	// InvalidMode represents an invalid operating mode.
-	InvalidMode // N
+// Mode values.
+const (
+	AutonomousMode   = gll.AutonomousMode
+	DifferentialMode = gll.DifferentialMode
+	EstimatedMode    = gll.EstimatedMode
+	ManualInputMode  = gll.ManualInputMode
+	InvalidMode      = gll.InvalidMode
 )
 
-//go:generate go run github.com/dmarkham/enumer@v1.6.3 -type=NorthSouth,EastWest,DataStatus,Mode -text -linecomment -transform=first-upper -output=enum_gen.go
+// ModeString is an alias for [gll.ModeString].
+func ModeString(s string) (Mode, error) { return gll.ModeString(s) }
+
+// ModeValues is an alias for [gll.ModeValues].
+func ModeValues() []Mode { return gll.ModeValues() }
+
+// ModeStrings is an alias for [gll.ModeStrings].
+func ModeStrings() []string { return gll.ModeStrings() }
@@ -1,45 +1,22 @@
 // Package gpgll contains data structures and functions related to NMEA sentences
 // of type "GPGLL".
+//
+// The types in this package are thin, GPS-only ("GP" talker) views of those in package gll, which
+// accepts GLL sentences from any talker.
 package gpgll // import "github.com/mab-go/nmea/sentence/gpgll"
 
 import (
+	"encoding/json"
+
 	"github.com/mab-go/nmea/sentence"
+	"github.com/mab-go/nmea/sentence/gll"
 )
 
-// GPGLL represents an NMEA input of type "$GPGLL". It contains a position fix location (latitude
-// and longitude), the time of the position fix, and the fix status.
-type GPGLL struct {
-	// Latitude is the "latitude" component of a GPS fix. The format is (d)ddmm.mmmmmmm. For
-	// example, the value 5106.7198674 represents a latitude value of 51° 6.7198674'. It is element
-	// [1] of a GPGLL input.
-	Latitude float64
-
-	// NorthSouth indicates the hemisphere in which the latitude value resides. It is element [2] of
-	// a GPGLL input.
-	NorthSouth NorthSouth
-
-	// Longitude is the "longitude" component of a GPS fix. The format is (d)ddmm.mmmm. For example,
-	// the value 11402.3587526 represents a longitude value of 114° 2.3587526'. It is element [3] of
-	// a GPGLL input.
-	Longitude float64
-
-	// EastWest indicates the hemisphere in which the longitude value resides. It is element [4] of
-	// a GPGLL input.
-	EastWest EastWest
-
-	// FixTime is the time at which the GPS fix was acquired (typically UTC). It is element [5] of
-	// a GPGLL input. An empty time field yields a zero [sentence.NMEATime] without error. Wire
-	// format and validation: see [sentence.NMEATime].
-	FixTime sentence.NMEATime
-
-	// DataStatus represents the status of the GPS fix. It can be either "A" (valid) or "V"
-	// (invalid). It is element [6] of a GPGLL input.
-	DataStatus DataStatus
-
-	// Mode indicates the operating mode of a positioning system. It is element [7] of a GPGLL
-	// input.
-	Mode Mode
-}
+//go:generate go run github.com/mab-go/nmea/tools/nmeaschema gpgll
+
+// GPGLL represents an NMEA input of type "$GPGLL". It has the same fields as [gll.GLL]; see that
+// type for their documentation.
+type GPGLL gll.GLL
 
 // GetSentenceType returns the type of NMEA input represented by the struct GPGLL. It always
 // returns "GPGLL". It represents element [0] of a GPGLL input.
@@ -47,31 +24,75 @@ func (g GPGLL) GetSentenceType() string {
 	return "GPGLL"
 }
 
-// Ensure that GPGLL properly implements the NMEASentence interface
-var _ sentence.NMEASentence = GPGLL{}
+// Talker returns the talker identifier of the GPGLL input. It always returns "GP".
+func (g GPGLL) Talker() string {
+	return sentence.TalkerGPS
+}
+
+// MarshalNMEA encodes g as a GPGLL input (including its checksum). See [gll.GLL.MarshalNMEA].
+func (g GPGLL) MarshalNMEA() (string, error) {
+	g.TalkerID = sentence.TalkerGPS
+
+	return gll.GLL(g).MarshalNMEA()
+}
+
+// MarshalJSON encodes g as a JSON object whose "sentenceType" member is "GPGLL" and whose
+// "talkerId" member is "GP". See [gll.GLL.MarshalJSON].
+func (g GPGLL) MarshalJSON() ([]byte, error) {
+	type fields GPGLL // Has the fields of GPGLL, but not its MarshalJSON method
+
+	g.TalkerID = sentence.TalkerGPS
+
+	return sentence.MarshalSentenceJSON(g.GetSentenceType(), fields(g))
+}
+
+// UnmarshalJSON decodes a JSON object encoded by MarshalJSON into g, which is only modified if
+// data is valid. See [gll.GLL.UnmarshalJSON].
+func (g *GPGLL) UnmarshalJSON(data []byte) error {
+	type fields GPGLL
+
+	var decoded GPGLL
+	if err := sentence.UnmarshalSentenceJSON(data, (*fields)(&decoded), &decoded); err != nil {
+		return err
+	}
+
+	*g = decoded
+
+	return nil
+}
+
+// Ensure that GPGLL properly implements the NMEASentence, Marshaler and JSON interfaces
+var (
+	_ sentence.NMEASentence = GPGLL{}
+	_ sentence.Marshaler    = GPGLL{}
+	_ json.Marshaler        = GPGLL{}
+	_ json.Unmarshaler      = (*GPGLL)(nil)
+)
 
 // Parse parses a GPGLL input string and returns a pointer to a GPGLL struct (or an error if the
-// input is invalid).
+// input is invalid). Use [gll.Parse] to accept GLL inputs from any talker.
 func Parse(s string) (*GPGLL, error) {
-	segments := &SegmentParser{}
-	if err := segments.Parse(s); err != nil {
+	return ParseWithOptions(s)
+}
+
+// ParseWithOptions is like Parse, but parses the sentence according to opts (see
+// [sentence.ParseOptions]), e.g. to accept a sentence that has no checksum. With no options, it
+// is identical to Parse.
+func ParseWithOptions(s string, opts ...sentence.ParseOption) (*GPGLL, error) {
+	p := &sentence.SegmentParser{}
+	if err := p.ParseWithOptions(s, opts...); err != nil {
 		return nil, err
 	}
 
-	_ = segments.RequireString(0, "GPGLL") // Verify input type
-	gpgll := &GPGLL{
-		Latitude:   segments.AsFloat64(1),
-		NorthSouth: segments.AsNorthSouth(2),
-		Longitude:  segments.AsFloat64(3),
-		EastWest:   segments.AsEastWest(4),
-		FixTime:    segments.AsNMEATime(5),
-		DataStatus: segments.AsDataStatus(6),
-		Mode:       segments.AsMode(7),
+	_ = p.RequireString(0, "GPGLL") // Verify input type
+	if err := p.Err(); err != nil {
+		return nil, err
 	}
 
-	if err := segments.Err(); err != nil {
+	g, err := gll.Decode(p)
+	if err != nil {
 		return nil, err
 	}
 
-	return gpgll, nil
+	return (*GPGLL)(g), nil
 }
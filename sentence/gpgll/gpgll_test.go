@@ -1,78 +1,92 @@
 package gpgll
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
 	"github.com/mab-go/nmea/sentence"
+	"github.com/mab-go/nmea/sentence/gll"
 )
 
 type testVec struct {
 	input    string
 	expected GPGLL
+	json     string
 	errMsg   string
 }
 
 var goodTestData = map[string]testVec{
+	"Before NMEA 2.3 (No Mode)": {
+		input: "$GPGLL,3723.2475,N,12158.3416,W,161229.487,A*2C",
+		json:  `{"sentenceType":"GPGLL","talkerId":"GP","latitude":"3723.2475,N","longitude":"12158.3416,W","fixTime":"161229.487","dataStatus":"A","version":"2.0"}`,
+		expected: GPGLL{
+			Latitude:   sentence.MustParseLatitude("3723.2475,N"),
+			Longitude:  sentence.MustParseLongitude("12158.3416,W"),
+			FixTime:    sentence.NewTime(sentence.NMEATime{Hour: 16, Minute: 12, Second: 29, Millisecond: 487}),
+			DataStatus: ValidDataStatus,
+			Version:    sentence.Version20,
+		},
+	},
 	"NMEASimulator (Modified) [1/4]": {
 		input: "$GPGLL,3157.905722,S,11551.681852,E,215052.603,A,D*4F",
+		json:  `{"sentenceType":"GPGLL","talkerId":"GP","latitude":"3157.905722,S","longitude":"11551.681852,E","fixTime":"215052.603","dataStatus":"A","mode":"D","version":"2.3"}`,
 		expected: GPGLL{
-			Latitude:   3157.905722,
-			NorthSouth: South,
-			Longitude:  11551.681852,
-			EastWest:   East,
-			FixTime:    sentence.NMEATime{Hour: 21, Minute: 50, Second: 52, Millisecond: 603},
+			Latitude:   sentence.MustParseLatitude("3157.905722,S"),
+			Longitude:  sentence.MustParseLongitude("11551.681852,E"),
+			FixTime:    sentence.NewTime(sentence.NMEATime{Hour: 21, Minute: 50, Second: 52, Millisecond: 603}),
 			DataStatus: ValidDataStatus,
 			Mode:       DifferentialMode,
+			Version:    sentence.Version23,
 		},
 	},
 	"NMEA Simulator (Modified) [2/4]": {
 		input: "$GPGLL,3157.905722,S,11551.681852,E,215102.604,A,E*4D",
+		json:  `{"sentenceType":"GPGLL","talkerId":"GP","latitude":"3157.905722,S","longitude":"11551.681852,E","fixTime":"215102.604","dataStatus":"A","mode":"E","version":"2.3"}`,
 		expected: GPGLL{
-			Latitude:   3157.905722,
-			NorthSouth: South,
-			Longitude:  11551.681852,
-			EastWest:   East,
-			FixTime:    sentence.NMEATime{Hour: 21, Minute: 51, Second: 2, Millisecond: 604},
+			Latitude:   sentence.MustParseLatitude("3157.905722,S"),
+			Longitude:  sentence.MustParseLongitude("11551.681852,E"),
+			FixTime:    sentence.NewTime(sentence.NMEATime{Hour: 21, Minute: 51, Second: 2, Millisecond: 604}),
 			DataStatus: ValidDataStatus,
 			Mode:       EstimatedMode,
+			Version:    sentence.Version23,
 		},
 	},
 	"NMEA Simulator (Modified) [3/4]": {
 		input: "$GPGLL,3726.489023,N,12212.446039,W,214827.478,A,M*4C",
+		json:  `{"sentenceType":"GPGLL","talkerId":"GP","latitude":"3726.489023,N","longitude":"12212.446039,W","fixTime":"214827.478","dataStatus":"A","mode":"M","version":"2.3"}`,
 		expected: GPGLL{
-			Latitude:   3726.489023,
-			NorthSouth: North,
-			Longitude:  12212.446039,
-			EastWest:   West,
-			FixTime:    sentence.NMEATime{Hour: 21, Minute: 48, Second: 27, Millisecond: 478},
+			Latitude:   sentence.MustParseLatitude("3726.489023,N"),
+			Longitude:  sentence.MustParseLongitude("12212.446039,W"),
+			FixTime:    sentence.NewTime(sentence.NMEATime{Hour: 21, Minute: 48, Second: 27, Millisecond: 478}),
 			DataStatus: ValidDataStatus,
 			Mode:       ManualInputMode,
+			Version:    sentence.Version23,
 		},
 	},
 	"NMEA Simulator (Modified) [4/4]": {
 		input: "$GPGLL,3726.489023,N,12212.446039,W,214916.479,A,A*42",
+		json:  `{"sentenceType":"GPGLL","talkerId":"GP","latitude":"3726.489023,N","longitude":"12212.446039,W","fixTime":"214916.479","dataStatus":"A","mode":"A","version":"2.3"}`,
 		expected: GPGLL{
-			Latitude:   3726.489023,
-			NorthSouth: North,
-			Longitude:  12212.446039,
-			EastWest:   West,
-			FixTime:    sentence.NMEATime{Hour: 21, Minute: 49, Second: 16, Millisecond: 479},
+			Latitude:   sentence.MustParseLatitude("3726.489023,N"),
+			Longitude:  sentence.MustParseLongitude("12212.446039,W"),
+			FixTime:    sentence.NewTime(sentence.NMEATime{Hour: 21, Minute: 49, Second: 16, Millisecond: 479}),
 			DataStatus: ValidDataStatus,
 			Mode:       AutonomousMode,
+			Version:    sentence.Version23,
 		},
 	},
 	// Example from https://www.rfwireless-world.com/Terminology/GPS-sentences-or-NMEA-sentences.html
 	"RF Wireless World Example": {
 		input: "$GPGLL,3723.2475,N,12158.3416,W,161229.487,A,A*41",
+		json:  `{"sentenceType":"GPGLL","talkerId":"GP","latitude":"3723.2475,N","longitude":"12158.3416,W","fixTime":"161229.487","dataStatus":"A","mode":"A","version":"2.3"}`,
 		expected: GPGLL{
-			Latitude:   3723.2475,
-			NorthSouth: North,
-			Longitude:  12158.3416,
-			EastWest:   West,
-			FixTime:    sentence.NMEATime{Hour: 16, Minute: 12, Second: 29, Millisecond: 487},
+			Latitude:   sentence.MustParseLatitude("3723.2475,N"),
+			Longitude:  sentence.MustParseLongitude("12158.3416,W"),
+			FixTime:    sentence.NewTime(sentence.NMEATime{Hour: 16, Minute: 12, Second: 29, Millisecond: 487}),
 			DataStatus: ValidDataStatus,
 			Mode:       AutonomousMode,
+			Version:    sentence.Version23,
 		},
 	},
 }
@@ -84,7 +98,7 @@ var badTestData = map[string]testVec{
 	},
 	"Bad Latitude": {
 		input:  "$GPGLL,bad_Latitude,N,12158.3416,W,161229.487,A,A*66",
-		errMsg: "sentence segment [1] must be parsable as a float64 but was \"bad_Latitude\"",
+		errMsg: "sentence segment [1] must be parsable as a Latitude but was \"bad_Latitude\"",
 	},
 	"Bad NorthSouth": {
 		input:  "$GPGLL,3723.2475,bad_NorthSouth,12158.3416,W,161229.487,A,A*2D",
@@ -92,7 +106,7 @@ var badTestData = map[string]testVec{
 	},
 	"Bad Longitude": {
 		input:  "$GPGLL,3723.2475,N,bad_Longitude,W,161229.487,A,A*2B",
-		errMsg: "sentence segment [3] must be parsable as a float64 but was \"bad_Longitude\"",
+		errMsg: "sentence segment [3] must be parsable as a Longitude but was \"bad_Longitude\"",
 	},
 	"Bad EastWest": {
 		input:  "$GPGLL,3723.2475,N,12158.3416,bad_EastWest,161229.487,A,A*38",
@@ -129,9 +143,7 @@ func TestParse_goodData(t *testing.T) {
 
 			expected := vec.expected
 			assertMatches(t, title, "Latitude", expected.Latitude, actual.Latitude)
-			assertMatches(t, title, "NorthSouth", expected.NorthSouth, actual.NorthSouth)
 			assertMatches(t, title, "Longitude", expected.Longitude, actual.Longitude)
-			assertMatches(t, title, "EastWest", expected.EastWest, actual.EastWest)
 			assertMatches(t, title, "FixTime", expected.FixTime, actual.FixTime)
 			assertMatches(t, title, "DataStatus", expected.DataStatus, actual.DataStatus)
 			assertMatches(t, title, "Mode", expected.Mode, actual.Mode)
@@ -174,6 +186,95 @@ func TestParse_badSegments(t *testing.T) {
 	}
 }
 
+// TestParse_registered verifies that sentence.Parse decodes a GPGLL sentence with the decoder that
+// package gll registers for every talker, so it returns a *gll.GLL rather than a *GPGLL.
+func TestParse_registered(t *testing.T) {
+	input := "$GPGLL,3723.2475,N,12158.3416,W,161229.487,A,A*41"
+	s, err := sentence.Parse(input)
+	if err != nil {
+		t.Fatalf("sentence.Parse failed: %v", err)
+	}
+
+	actual, ok := s.(*gll.GLL)
+	if !ok {
+		t.Fatalf("sentence.Parse should have returned a *gll.GLL but returned %T", s)
+	}
+
+	expected, err := Parse(input)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if *actual != gll.GLL(*expected) {
+		t.Errorf("sentence.Parse result should have been %+v but was %+v", expected, actual)
+	}
+}
+
+func TestGPGLL_MarshalNMEA_roundTrips(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			encoded, err := vec.expected.MarshalNMEA() // TalkerID is unset; "GP" is implied
+			if err != nil {
+				t.Fatalf("MarshalNMEA failed: %v", err)
+			}
+
+			actual, err := Parse(encoded)
+			if err != nil {
+				t.Fatalf("error re-parsing %q: %v", encoded, err)
+			}
+
+			expected := vec.expected
+			expected.TalkerID = "GP"
+			if *actual != expected {
+				t.Errorf("round trip through %q should have produced %+v but produced %+v", encoded, expected, *actual)
+			}
+		})
+	}
+}
+
+func TestGPGLL_MarshalNMEA_exactCoordinates(t *testing.T) {
+	input := "$GPGLL,5106.7198674,N,11402.3587520,W,092321.000,A,D*4D"
+	gpgll, err := Parse(input)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	encoded, err := gpgll.MarshalNMEA()
+	if err != nil {
+		t.Fatalf("MarshalNMEA failed: %v", err)
+	}
+
+	if encoded != input {
+		t.Errorf("MarshalNMEA should have reproduced %q but produced %q", input, encoded)
+	}
+}
+
+func TestGPGLL_MarshalJSON(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			encoded, err := json.Marshal(vec.expected) // TalkerID is unset; "GP" is implied
+			if err != nil {
+				t.Fatalf("json.Marshal failed: %v", err)
+			}
+
+			if string(encoded) != vec.json {
+				t.Errorf("expected %s but was %s", vec.json, encoded)
+			}
+
+			var actual GPGLL
+			if err := json.Unmarshal([]byte(vec.json), &actual); err != nil {
+				t.Fatalf("error decoding %s: %v", vec.json, err)
+			}
+
+			expected := vec.expected
+			expected.TalkerID = "GP"
+			if actual != expected {
+				t.Errorf("decoding %s should have produced %+v but produced %+v", vec.json, expected, actual)
+			}
+		})
+	}
+}
+
 func TestGPGLL_GetSentenceType(t *testing.T) {
 	gpgll := &GPGLL{}
 	if st := gpgll.GetSentenceType(); st != "GPGLL" {
@@ -181,6 +282,13 @@ func TestGPGLL_GetSentenceType(t *testing.T) {
 	}
 }
 
+func TestGPGLL_Talker(t *testing.T) {
+	gpgll := &GPGLL{}
+	if talker := gpgll.Talker(); talker != "GP" {
+		t.Errorf("Talker() should have returned \"GP\" but returned \"%v\"", talker)
+	}
+}
+
 func ExampleParse() {
 	s := "$GPGLL,3723.2475,N,12158.3416,W,161229.487,A,A*41"
 	gpgll, err := Parse(s)
@@ -188,5 +296,5 @@ func ExampleParse() {
 
 	fmt.Printf("%+v", gpgll)
 	// Output:
-	// &{Latitude:3723.2475 NorthSouth:N Longitude:12158.3416 EastWest:W FixTime:161229.487 DataStatus:A Mode:A}
+	// &{TalkerID:GP Latitude:3723.2475,N Longitude:12158.3416,W FixTime:{NMEATime:161229.487 Valid:true} DataStatus:A Mode:A Version:2.3 TagBlock:<nil>}
 }
@@ -1,29 +1,42 @@
 package gpgsa
 
-// SelectionMode indicates whether satellite selection is automatic or manual.
-// It can be either "A" or "M".
-type SelectionMode int
+import (
+	"github.com/mab-go/nmea/sentence/gsa"
+)
 
-const (
-	// AutomaticSelectionMode represents automatic satellite selection.
-	AutomaticSelectionMode SelectionMode = iota + 1 // A
+// SelectionMode is an alias for [gsa.SelectionMode].
+type SelectionMode = gsa.SelectionMode
 
-	// ManualSelectionMode represents manual satellite selection.
-	ManualSelectionMode // M
+// SelectionMode values.
+const (
+	AutomaticSelectionMode = gsa.AutomaticSelectionMode
+	ManualSelectionMode    = gsa.ManualSelectionMode
 )
 
-// FixMode indicates the type of GPS fix: no fix, 2D, or 3D.
-type FixMode int
+// SelectionModeString is an alias for [gsa.SelectionModeString].
+func SelectionModeString(s string) (SelectionMode, error) { return gsa.SelectionModeString(s) }
 
-const (
-	// NoFix represents no GPS fix. Its NMEA wire value is 1.
-	NoFix FixMode = iota + 1 // 1
+// SelectionModeValues is an alias for [gsa.SelectionModeValues].
+func SelectionModeValues() []SelectionMode { return gsa.SelectionModeValues() }
 
-	// Fix2D represents a 2D GPS fix. Its NMEA wire value is 2.
-	Fix2D // 2
+// SelectionModeStrings is an alias for [gsa.SelectionModeStrings].
+func SelectionModeStrings() []string { return gsa.SelectionModeStrings() }
 
-	// Fix3D represents a 3D GPS fix. Its NMEA wire value is 3.
-	Fix3D // 3
+// FixMode is an alias for [gsa.FixMode].
+type FixMode = gsa.FixMode
+
+// FixMode values.
+const (
+	NoFix = gsa.NoFix
+	Fix2D = gsa.Fix2D
+	Fix3D = gsa.Fix3D
 )
 
-//go:generate go run github.com/dmarkham/enumer@v1.6.3 -type=SelectionMode,FixMode -text -linecomment -transform=first-upper -output=enum_gen.go
+// FixModeString is an alias for [gsa.FixModeString].
+func FixModeString(s string) (FixMode, error) { return gsa.FixModeString(s) }
+
+// FixModeValues is an alias for [gsa.FixModeValues].
+func FixModeValues() []FixMode { return gsa.FixModeValues() }
+
+// FixModeStrings is an alias for [gsa.FixModeStrings].
+func FixModeStrings() []string { return gsa.FixModeStrings() }
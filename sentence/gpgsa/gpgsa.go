@@ -1,78 +1,98 @@
 // Package gpgsa contains data structures and functions related to NMEA sentences
 // of type "GPGSA".
+//
+// The types in this package are thin, GPS-only ("GP" talker) views of those in package gsa, which
+// accepts GSA sentences from any talker.
 package gpgsa // import "github.com/mab-go/nmea/sentence/gpgsa"
 
 import (
+	"encoding/json"
+
 	"github.com/mab-go/nmea/sentence"
+	"github.com/mab-go/nmea/sentence/gsa"
 )
 
-// GPGSA represents an NMEA sentence of type "GPGSA".
-type GPGSA struct {
-	// SelectionMode indicates whether satellite selection is automatic or manual. It is element [1]
-	// of a GPGSA sentence.
-	SelectionMode SelectionMode
+//go:generate go run github.com/mab-go/nmea/tools/nmeaschema gpgsa
 
-	// FixMode indicates whether the GPS fix is no fix, 2D, or 3D. It is element [2] of a GPGSA
-	// sentence.
-	FixMode FixMode
+// GPGSA represents an NMEA sentence of type "GPGSA". It has the same fields as [gsa.GSA]; see
+// that type for their documentation.
+type GPGSA gsa.GSA
 
-	// PRNs contains the PRN IDs of the satellites used in the solution. The 12 slots are
-	// fixed-position; unused slots are 0. They are elements [3]–[14] of a GPGSA sentence.
-	PRNs [12]int8
+// GetSentenceType returns the type of NMEA sentence represented by the struct GPGSA. It always
+// returns "GPGSA". It represents element [0] of a GPGSA sentence.
+func (g GPGSA) GetSentenceType() string {
+	return "GPGSA"
+}
 
-	// PDOP is the position dilution of precision. It is element [15] of a GPGSA sentence.
-	PDOP float32
+// Talker returns the talker identifier of the GPGSA sentence. It always returns "GP".
+func (g GPGSA) Talker() string {
+	return sentence.TalkerGPS
+}
 
-	// HDOP is the horizontal dilution of precision. It is element [16] of a GPGSA sentence.
-	HDOP float32
+// MarshalNMEA encodes g as a GPGSA sentence (including its checksum). See [gsa.GSA.MarshalNMEA].
+func (g GPGSA) MarshalNMEA() (string, error) {
+	g.TalkerID = sentence.TalkerGPS
 
-	// VDOP is the vertical dilution of precision. It is element [17] of a GPGSA sentence.
-	VDOP float32
+	return gsa.GSA(g).MarshalNMEA()
 }
 
-// GetSentenceType returns the type of NMEA sentence represented by the struct GPGSA. It always
-// returns "GPGSA". It represents element [0] of a GPGSA sentence.
-func (g GPGSA) GetSentenceType() string {
-	return "GPGSA"
+// MarshalJSON encodes g as a JSON object whose "sentenceType" member is "GPGSA" and whose
+// "talkerId" member is "GP". See [gsa.GSA.MarshalJSON].
+func (g GPGSA) MarshalJSON() ([]byte, error) {
+	type fields GPGSA // Has the fields of GPGSA, but not its MarshalJSON method
+
+	g.TalkerID = sentence.TalkerGPS
+
+	return sentence.MarshalSentenceJSON(g.GetSentenceType(), fields(g))
 }
 
-// Ensure that GPGSA properly implements the NMEASentence interface
-var _ sentence.NMEASentence = GPGSA{}
+// UnmarshalJSON decodes a JSON object encoded by MarshalJSON into g, which is only modified if
+// data is valid. See [gsa.GSA.UnmarshalJSON].
+func (g *GPGSA) UnmarshalJSON(data []byte) error {
+	type fields GPGSA
+
+	var decoded GPGSA
+	if err := sentence.UnmarshalSentenceJSON(data, (*fields)(&decoded), &decoded); err != nil {
+		return err
+	}
+
+	*g = decoded
+
+	return nil
+}
+
+// Ensure that GPGSA properly implements the NMEASentence, Marshaler and JSON interfaces
+var (
+	_ sentence.NMEASentence = GPGSA{}
+	_ sentence.Marshaler    = GPGSA{}
+	_ json.Marshaler        = GPGSA{}
+	_ json.Unmarshaler      = (*GPGSA)(nil)
+)
 
 // Parse parses a GPGSA sentence string and returns a pointer to a GPGSA struct (or an error if
-// the sentence is invalid).
+// the sentence is invalid). Use [gsa.Parse] to accept GSA sentences from any talker.
 func Parse(s string) (*GPGSA, error) {
-	segments := &SegmentParser{}
-	if err := segments.Parse(s); err != nil {
+	return ParseWithOptions(s)
+}
+
+// ParseWithOptions is like Parse, but parses the sentence according to opts (see
+// [sentence.ParseOptions]), e.g. to accept a sentence that has no checksum. With no options, it
+// is identical to Parse.
+func ParseWithOptions(s string, opts ...sentence.ParseOption) (*GPGSA, error) {
+	p := &sentence.SegmentParser{}
+	if err := p.ParseWithOptions(s, opts...); err != nil {
 		return nil, err
 	}
 
-	_ = segments.RequireString(0, "GPGSA") // Verify sentence type
-	gpgsa := &GPGSA{
-		SelectionMode: segments.AsSelectionMode(1),
-		FixMode:       segments.AsFixMode(2),
-		PRNs: [12]int8{
-			segments.AsInt8(3),
-			segments.AsInt8(4),
-			segments.AsInt8(5),
-			segments.AsInt8(6),
-			segments.AsInt8(7),
-			segments.AsInt8(8),
-			segments.AsInt8(9),
-			segments.AsInt8(10),
-			segments.AsInt8(11),
-			segments.AsInt8(12),
-			segments.AsInt8(13),
-			segments.AsInt8(14),
-		},
-		PDOP: segments.AsFloat32(15),
-		HDOP: segments.AsFloat32(16),
-		VDOP: segments.AsFloat32(17),
+	_ = p.RequireString(0, "GPGSA") // Verify sentence type
+	if err := p.Err(); err != nil {
+		return nil, err
 	}
 
-	if err := segments.Err(); err != nil {
+	g, err := gsa.Decode(p)
+	if err != nil {
 		return nil, err
 	}
 
-	return gpgsa, nil
+	return (*GPGSA)(g), nil
 }
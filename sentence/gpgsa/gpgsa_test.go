@@ -1,13 +1,18 @@
 package gpgsa
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
+
+	"github.com/mab-go/nmea/sentence"
+	"github.com/mab-go/nmea/sentence/gsa"
 )
 
 type testVec struct {
 	input    string
 	expected GPGSA
+	json     string
 	errMsg   string
 }
 
@@ -16,39 +21,45 @@ var goodTestData = map[string]testVec{
 	// Scenario: 3D fix, 10 active satellites, DOP 1.8/0.8/1.6
 	"AMOD AGL3080 [3D/full]": {
 		input: "$GPGSA,A,3,03,22,06,19,11,14,32,01,28,18,,,1.8,0.8,1.6*3F",
+		json:  `{"sentenceType":"GPGSA","talkerId":"GP","selectionMode":"A","fixMode":"3","prns":[3,22,6,19,11,14,32,1,28,18,0,0],"pdop":1.8,"hdop":0.8,"vdop":1.6,"systemId":null,"version":"2.0"}`,
 		expected: GPGSA{
 			SelectionMode: AutomaticSelectionMode,
 			FixMode:       Fix3D,
-			PRNs:          [12]int8{3, 22, 6, 19, 11, 14, 32, 1, 28, 18, 0, 0},
-			PDOP:          1.8,
-			HDOP:          0.8,
-			VDOP:          1.6,
+			PRNs:          [12]int16{3, 22, 6, 19, 11, 14, 32, 1, 28, 18, 0, 0},
+			PDOP:          sentence.NewFloat32(1.8),
+			HDOP:          sentence.NewFloat32(0.8),
+			VDOP:          sentence.NewFloat32(1.6),
+			Version:       sentence.Version20,
 		},
 	},
 	// Source: AMOD_AGL3080_20121104_134730.txt, line 50
 	// Scenario: 3D fix, 8 active satellites, some slots empty
 	"AMOD AGL3080 [3D/sparse]": {
 		input: "$GPGSA,A,3,03,22,06,19,14,32,28,18,,,,,2.1,1.0,1.8*33",
+		json:  `{"sentenceType":"GPGSA","talkerId":"GP","selectionMode":"A","fixMode":"3","prns":[3,22,6,19,14,32,28,18,0,0,0,0],"pdop":2.1,"hdop":1,"vdop":1.8,"systemId":null,"version":"2.0"}`,
 		expected: GPGSA{
 			SelectionMode: AutomaticSelectionMode,
 			FixMode:       Fix3D,
-			PRNs:          [12]int8{3, 22, 6, 19, 14, 32, 28, 18, 0, 0, 0, 0},
-			PDOP:          2.1,
-			HDOP:          1.0,
-			VDOP:          1.8,
+			PRNs:          [12]int16{3, 22, 6, 19, 14, 32, 28, 18, 0, 0, 0, 0},
+			PDOP:          sentence.NewFloat32(2.1),
+			HDOP:          sentence.NewFloat32(1.0),
+			VDOP:          sentence.NewFloat32(1.8),
+			Version:       sentence.Version20,
 		},
 	},
 	// Source: AMOD_AGL3080_20121104_134730.txt, line 186
 	// Scenario: 2D fix, 3 active satellites, noticeably degraded DOP
 	"AMOD AGL3080 [2D/degraded]": {
 		input: "$GPGSA,A,2,03,32,18,,,,,,,,,,3.1,2.9,1.0*30",
+		json:  `{"sentenceType":"GPGSA","talkerId":"GP","selectionMode":"A","fixMode":"2","prns":[3,32,18,0,0,0,0,0,0,0,0,0],"pdop":3.1,"hdop":2.9,"vdop":1,"systemId":null,"version":"2.0"}`,
 		expected: GPGSA{
 			SelectionMode: AutomaticSelectionMode,
 			FixMode:       Fix2D,
-			PRNs:          [12]int8{3, 32, 18, 0, 0, 0, 0, 0, 0, 0, 0, 0},
-			PDOP:          3.1,
-			HDOP:          2.9,
-			VDOP:          1.0,
+			PRNs:          [12]int16{3, 32, 18, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			PDOP:          sentence.NewFloat32(3.1),
+			HDOP:          sentence.NewFloat32(2.9),
+			VDOP:          sentence.NewFloat32(1.0),
+			Version:       sentence.Version20,
 		},
 	},
 	// Source: AMOD_AGL3080_20121104_134730.txt, line 190
@@ -56,62 +67,72 @@ var goodTestData = map[string]testVec{
 	// Note: hardware reports FixMode 2 (Fix2D) during this state, not FixMode 1 (NoFix)
 	"AMOD AGL3080 [no-fix/sentinel]": {
 		input: "$GPGSA,A,2,,,,,,,,,,,,,50.0,50.0,50.0*06",
+		json:  `{"sentenceType":"GPGSA","talkerId":"GP","selectionMode":"A","fixMode":"2","prns":[0,0,0,0,0,0,0,0,0,0,0,0],"pdop":50,"hdop":50,"vdop":50,"systemId":null,"version":"2.0"}`,
 		expected: GPGSA{
 			SelectionMode: AutomaticSelectionMode,
 			FixMode:       Fix2D,
-			PRNs:          [12]int8{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
-			PDOP:          50.0,
-			HDOP:          50.0,
-			VDOP:          50.0,
+			PRNs:          [12]int16{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			PDOP:          sentence.NewFloat32(50.0),
+			HDOP:          sentence.NewFloat32(50.0),
+			VDOP:          sentence.NewFloat32(50.0),
+			Version:       sentence.Version20,
 		},
 	},
 	// Source: AMOD_AGL3080_20121104_134730.txt, line 255
 	// Scenario: Partial re-acquisition — 3 satellites, 2D fix, partial DOP recovery
 	"AMOD AGL3080 [partial-reacq]": {
 		input: "$GPGSA,A,2,03,06,32,,,,,,,,,,50.0,50.0,1.0*36",
+		json:  `{"sentenceType":"GPGSA","talkerId":"GP","selectionMode":"A","fixMode":"2","prns":[3,6,32,0,0,0,0,0,0,0,0,0],"pdop":50,"hdop":50,"vdop":1,"systemId":null,"version":"2.0"}`,
 		expected: GPGSA{
 			SelectionMode: AutomaticSelectionMode,
 			FixMode:       Fix2D,
-			PRNs:          [12]int8{3, 6, 32, 0, 0, 0, 0, 0, 0, 0, 0, 0},
-			PDOP:          50.0,
-			HDOP:          50.0,
-			VDOP:          1.0,
+			PRNs:          [12]int16{3, 6, 32, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			PDOP:          sentence.NewFloat32(50.0),
+			HDOP:          sentence.NewFloat32(50.0),
+			VDOP:          sentence.NewFloat32(1.0),
+			Version:       sentence.Version20,
 		},
 	},
 	// Scenario: Manual satellite selection, 3D fix
 	"Manual selection [3D]": {
 		input: "$GPGSA,M,3,03,22,06,19,11,14,32,01,28,18,,,1.8,0.8,1.6*33",
+		json:  `{"sentenceType":"GPGSA","talkerId":"GP","selectionMode":"M","fixMode":"3","prns":[3,22,6,19,11,14,32,1,28,18,0,0],"pdop":1.8,"hdop":0.8,"vdop":1.6,"systemId":null,"version":"2.0"}`,
 		expected: GPGSA{
 			SelectionMode: ManualSelectionMode,
 			FixMode:       Fix3D,
-			PRNs:          [12]int8{3, 22, 6, 19, 11, 14, 32, 1, 28, 18, 0, 0},
-			PDOP:          1.8,
-			HDOP:          0.8,
-			VDOP:          1.6,
+			PRNs:          [12]int16{3, 22, 6, 19, 11, 14, 32, 1, 28, 18, 0, 0},
+			PDOP:          sentence.NewFloat32(1.8),
+			HDOP:          sentence.NewFloat32(0.8),
+			VDOP:          sentence.NewFloat32(1.6),
+			Version:       sentence.Version20,
 		},
 	},
 	// Scenario: NoFix (NMEA wire value 1), no satellites tracked, sentinel DOP values
 	"No fix [NoFix/sentinel]": {
 		input: "$GPGSA,A,1,,,,,,,,,,,,,99.9,99.9,99.9*09",
+		json:  `{"sentenceType":"GPGSA","talkerId":"GP","selectionMode":"A","fixMode":"1","prns":[0,0,0,0,0,0,0,0,0,0,0,0],"pdop":99.9,"hdop":99.9,"vdop":99.9,"systemId":null,"version":"2.0"}`,
 		expected: GPGSA{
 			SelectionMode: AutomaticSelectionMode,
 			FixMode:       NoFix,
-			PRNs:          [12]int8{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
-			PDOP:          99.9,
-			HDOP:          99.9,
-			VDOP:          99.9,
+			PRNs:          [12]int16{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			PDOP:          sentence.NewFloat32(99.9),
+			HDOP:          sentence.NewFloat32(99.9),
+			VDOP:          sentence.NewFloat32(99.9),
+			Version:       sentence.Version20,
 		},
 	},
 	// Scenario: All 12 PRN slots populated, 3D fix
 	"All 12 PRNs [3D/full]": {
 		input: "$GPGSA,A,3,01,02,03,04,05,06,07,08,09,10,11,12,2.5,1.5,2.0*30",
+		json:  `{"sentenceType":"GPGSA","talkerId":"GP","selectionMode":"A","fixMode":"3","prns":[1,2,3,4,5,6,7,8,9,10,11,12],"pdop":2.5,"hdop":1.5,"vdop":2,"systemId":null,"version":"2.0"}`,
 		expected: GPGSA{
 			SelectionMode: AutomaticSelectionMode,
 			FixMode:       Fix3D,
-			PRNs:          [12]int8{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
-			PDOP:          2.5,
-			HDOP:          1.5,
-			VDOP:          2.0,
+			PRNs:          [12]int16{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+			PDOP:          sentence.NewFloat32(2.5),
+			HDOP:          sentence.NewFloat32(1.5),
+			VDOP:          sentence.NewFloat32(2.0),
+			Version:       sentence.Version20,
 		},
 	},
 }
@@ -139,7 +160,7 @@ var badTestData = map[string]testVec{
 	},
 	"Bad PRN": {
 		input:  "$GPGSA,A,3,bad_PRN,22,06,19,11,14,32,01,28,18,,,1.8,0.8,1.6*48",
-		errMsg: "sentence segment [3] must be parsable as an int8 but was \"bad_PRN\"",
+		errMsg: "sentence segment [3] must be parsable as an int16 but was \"bad_PRN\"",
 	},
 	"Bad PDOP": {
 		input:  "$GPGSA,A,3,03,22,06,19,11,14,32,01,28,18,,,bad_PDOP,0.8,1.6*2B",
@@ -216,6 +237,78 @@ func TestParse_badSegments(t *testing.T) {
 	}
 }
 
+// TestParse_registered verifies that sentence.Parse decodes a GPGSA sentence with the decoder that
+// package gsa registers for every talker, so it returns a *gsa.GSA rather than a *GPGSA.
+func TestParse_registered(t *testing.T) {
+	input := "$GPGSA,A,3,03,22,06,19,11,14,32,01,28,18,,,1.8,0.8,1.6*3F"
+	s, err := sentence.Parse(input)
+	if err != nil {
+		t.Fatalf("sentence.Parse failed: %v", err)
+	}
+
+	actual, ok := s.(*gsa.GSA)
+	if !ok {
+		t.Fatalf("sentence.Parse should have returned a *gsa.GSA but returned %T", s)
+	}
+
+	expected, err := Parse(input)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if *actual != gsa.GSA(*expected) {
+		t.Errorf("sentence.Parse result should have been %+v but was %+v", expected, actual)
+	}
+}
+
+func TestGPGSA_MarshalNMEA_roundTrips(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			encoded, err := vec.expected.MarshalNMEA() // TalkerID is unset; "GP" is implied
+			if err != nil {
+				t.Fatalf("MarshalNMEA failed: %v", err)
+			}
+
+			actual, err := Parse(encoded)
+			if err != nil {
+				t.Fatalf("error re-parsing %q: %v", encoded, err)
+			}
+
+			expected := vec.expected
+			expected.TalkerID = "GP"
+			if *actual != expected {
+				t.Errorf("round trip through %q should have produced %+v but produced %+v", encoded, expected, *actual)
+			}
+		})
+	}
+}
+
+func TestGPGSA_MarshalJSON(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			encoded, err := json.Marshal(vec.expected) // TalkerID is unset; "GP" is implied
+			if err != nil {
+				t.Fatalf("json.Marshal failed: %v", err)
+			}
+
+			if string(encoded) != vec.json {
+				t.Errorf("expected %s but was %s", vec.json, encoded)
+			}
+
+			var actual GPGSA
+			if err := json.Unmarshal([]byte(vec.json), &actual); err != nil {
+				t.Fatalf("error decoding %s: %v", vec.json, err)
+			}
+
+			expected := vec.expected
+			expected.TalkerID = "GP"
+			if actual != expected {
+				t.Errorf("decoding %s should have produced %+v but produced %+v", vec.json, expected, actual)
+			}
+		})
+	}
+}
+
 func TestGPGSA_GetSentenceType(t *testing.T) {
 	gpgsa := &GPGSA{}
 	if st := gpgsa.GetSentenceType(); st != "GPGSA" {
@@ -223,6 +316,13 @@ func TestGPGSA_GetSentenceType(t *testing.T) {
 	}
 }
 
+func TestGPGSA_Talker(t *testing.T) {
+	gpgsa := &GPGSA{}
+	if talker := gpgsa.Talker(); talker != "GP" {
+		t.Errorf("Talker() should have returned \"GP\" but returned \"%v\"", talker)
+	}
+}
+
 func ExampleParse() {
 	sentence := "$GPGSA,A,3,03,22,06,19,11,14,32,01,28,18,,,1.8,0.8,1.6*3F"
 	gpgsa, err := Parse(sentence)
@@ -230,5 +330,5 @@ func ExampleParse() {
 
 	fmt.Printf("%+v", gpgsa)
 	// Output:
-	// &{SelectionMode:A FixMode:3 PRNs:[3 22 6 19 11 14 32 1 28 18 0 0] PDOP:1.8 HDOP:0.8 VDOP:1.6}
+	// &{TalkerID:GP SelectionMode:A FixMode:3 PRNs:[3 22 6 19 11 14 32 1 28 18 0 0] PDOP:{Float32:1.8 Valid:true} HDOP:{Float32:0.8 Valid:true} VDOP:{Float32:1.6 Valid:true} SystemID:{Int:0 Valid:false} Version:2.0 TagBlock:<nil>}
 }
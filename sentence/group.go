@@ -0,0 +1,184 @@
+package sentence
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// --- Public ------------------------------------------------------------------
+
+// DefaultGroupTimeout is the default GroupAssembler.Timeout.
+const DefaultGroupTimeout = 5 * time.Second
+
+// MaxGroupSize is the largest number of parts that GroupAssembler accepts for a group. NMEA 0183
+// sentences number the parts of a group with at most two digits (e.g. TXT's "01" to "99"), so no
+// valid group is larger; the limit stops a corrupt or hostile total from allocating without bound.
+const MaxGroupSize = 99
+
+// GroupPart is implemented by a sentence type whose sentences may be parts of a group: a logical
+// message that is split across several sentences, such as a multi-sentence AIS message (VDM) or
+// the satellites in view (GSV).
+type GroupPart interface {
+	NMEASentence
+
+	// GroupPart returns the key that identifies the group to which the sentence belongs, among
+	// the groups that may be in progress at the same time (e.g. a VDM sentence's type, sequential
+	// message identifier and channel), the (1-based) number of the sentence within its group, and
+	// the number of sentences in the group.
+	GroupPart() (key string, number, total int)
+}
+
+// Group is a complete group of sentences (see GroupAssembler).
+type Group struct {
+	Key        string         // The group's key (see GroupPart)
+	Parts      []NMEASentence // The group's parts, in order
+	ReceivedAt time.Time      // The time at which the group's first part was added
+}
+
+// GroupAssembler assembles the parts of groups of sentences (see GroupPart), which may arrive out
+// of order, into complete Groups. Sentence packages can build their assembled types (e.g. a whole
+// AIS message) on the Groups that it returns.
+//
+// A group is abandoned, and reported as an *IncompleteGroupError, if it is not complete within
+// Timeout of its first part, or if a part arrives that cannot belong to it: one with a different
+// number of parts, or a repeat of a part that has already been added (which means that its key
+// has been reused by a new group, as a VDM sequential message identifier is).
+//
+// A GroupAssembler is not safe for concurrent use. Its zero value is ready to use.
+type GroupAssembler struct {
+	// Timeout is how long after its first part a group may take to complete. If it is 0,
+	// DefaultGroupTimeout is used.
+	Timeout time.Duration
+
+	pending map[string]*pendingGroup
+}
+
+// Add adds s, the part with the specified (1-based) number of the group with the specified key and
+// number of parts (total), which was received at the specified time. If s completes the group,
+// Add returns the Group; otherwise it returns nil and keeps s until the rest of the group arrives.
+//
+// If adding s abandons a group that has the same key (see GroupAssembler), Add also returns an
+// *IncompleteGroupError for it. It returns an error, and ignores s, if total is greater than
+// MaxGroupSize or if number is not between 1 and total.
+func (a *GroupAssembler) Add(key string, number, total int, s NMEASentence,
+	at time.Time) (*Group, error) {
+	if total > MaxGroupSize {
+		return nil, fmt.Errorf("sentence group \"%s\" must have at most %d parts but has %d", key,
+			MaxGroupSize, total)
+	}
+
+	if number < 1 || number > total {
+		return nil, fmt.Errorf("part %d of sentence group \"%s\" must be between 1 and %d", number,
+			key, total)
+	}
+
+	var err error
+	g := a.pending[key]
+	if g != nil {
+		var reason string
+		switch {
+		case at.Sub(g.receivedAt) > a.timeout():
+			reason = "timed out"
+		case len(g.parts) != total:
+			reason = fmt.Sprintf("a part of a group of %d sentences has the same key", total)
+		case g.parts[number-1] != nil:
+			reason = fmt.Sprintf("part %d was received again", number)
+		}
+
+		if reason != "" {
+			err, g = g.incomplete(key, reason), nil
+		}
+	}
+
+	if g == nil {
+		g = &pendingGroup{parts: make([]NMEASentence, total), receivedAt: at}
+		if a.pending == nil {
+			a.pending = make(map[string]*pendingGroup)
+		}
+
+		a.pending[key] = g
+	}
+
+	g.parts[number-1] = s
+	g.received++
+	if g.received < total {
+		return nil, err
+	}
+
+	delete(a.pending, key)
+
+	return &Group{Key: key, Parts: g.parts, ReceivedAt: g.receivedAt}, err
+}
+
+// AddPart is like Add, but takes the key, number and number of parts of s from its GroupPart
+// method.
+func (a *GroupAssembler) AddPart(s GroupPart, at time.Time) (*Group, error) {
+	key, number, total := s.GroupPart()
+
+	return a.Add(key, number, total, s, at)
+}
+
+// Expire abandons the groups that have not completed within Timeout of their first part, as of
+// now, and returns an *IncompleteGroupError for each of them (ordered by key, and joined with
+// errors.Join), or nil if there are none. Call it periodically, as Add only notices that a group
+// has timed out when another part with its key arrives.
+func (a *GroupAssembler) Expire(now time.Time) error {
+	return a.abandon("timed out", func(g *pendingGroup) bool {
+		return now.Sub(g.receivedAt) > a.timeout()
+	})
+}
+
+// Flush abandons every group that is in progress, e.g. at the end of a stream, and returns an
+// *IncompleteGroupError for each of them (ordered by key, and joined with errors.Join), or nil if
+// there are none.
+func (a *GroupAssembler) Flush() error {
+	return a.abandon("flushed", func(*pendingGroup) bool { return true })
+}
+
+// Len returns the number of groups that are in progress.
+func (a *GroupAssembler) Len() int {
+	return len(a.pending)
+}
+
+// --- Private -----------------------------------------------------------------
+
+// pendingGroup is a group that a GroupAssembler has received some of the parts of.
+type pendingGroup struct {
+	parts      []NMEASentence // The parts, by number; a part that has not been received is nil
+	received   int            // The number of parts that have been received
+	receivedAt time.Time      // The time at which the first part was received
+}
+
+func (g *pendingGroup) incomplete(key, reason string) *IncompleteGroupError {
+	return &IncompleteGroupError{Key: key, Parts: g.parts, Reason: reason}
+}
+
+func (a *GroupAssembler) timeout() time.Duration {
+	if a.Timeout == 0 {
+		return DefaultGroupTimeout
+	}
+
+	return a.Timeout
+}
+
+// abandon abandons the groups for which abandoned returns true; see Expire.
+func (a *GroupAssembler) abandon(reason string, abandoned func(*pendingGroup) bool) error {
+	var keys []string
+	for key, g := range a.pending {
+		if abandoned(g) {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	errs := make([]error, len(keys))
+	for i, key := range keys {
+		errs[i] = a.pending[key].incomplete(key, reason)
+		delete(a.pending, key)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,163 @@
+package sentence
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGroupAssembler_Add(t *testing.T) {
+	start := time.Date(2026, 3, 1, 18, 37, 30, 0, time.UTC)
+	parts := []NMEASentence{
+		&testSentence{TalkerID: "GP", FixTime: NMEATime{Second: 1}},
+		&testSentence{TalkerID: "GP", FixTime: NMEATime{Second: 2}},
+		&testSentence{TalkerID: "GP", FixTime: NMEATime{Second: 3}},
+	}
+
+	t.Run("In Order", func(t *testing.T) {
+		var a GroupAssembler
+		for i, part := range parts[:2] {
+			if g, err := a.Add("K", i+1, 3, part, start); g != nil || err != nil {
+				t.Fatalf("expected part %d to be kept but was %v (error %v)", i+1, g, err)
+			}
+		}
+
+		g, err := a.Add("K", 3, 3, parts[2], start.Add(time.Second))
+		if err != nil || g == nil {
+			t.Fatalf("expected a complete group but was %v (error %v)", g, err)
+		}
+
+		if g.Key != "K" || !g.ReceivedAt.Equal(start) || len(g.Parts) != 3 || g.Parts[2] != parts[2] {
+			t.Errorf("unexpected group %+v", g)
+		}
+
+		if a.Len() != 0 {
+			t.Errorf("expected no groups in progress but there were %d", a.Len())
+		}
+	})
+
+	t.Run("Out of Order", func(t *testing.T) {
+		var a GroupAssembler
+		a.Add("K", 3, 3, parts[2], start)
+		a.Add("K", 1, 3, parts[0], start)
+
+		g, err := a.Add("K", 2, 3, parts[1], start)
+		if err != nil || g == nil {
+			t.Fatalf("expected a complete group but was %v (error %v)", g, err)
+		}
+
+		for i, part := range parts {
+			if g.Parts[i] != part {
+				t.Errorf("expected part %d to be %v but was %v", i+1, part, g.Parts[i])
+			}
+		}
+	})
+
+	t.Run("Single Part", func(t *testing.T) {
+		var a GroupAssembler
+		if g, err := a.Add("K", 1, 1, parts[0], start); err != nil || g == nil || len(g.Parts) != 1 {
+			t.Errorf("expected a complete group but was %v (error %v)", g, err)
+		}
+	})
+
+	t.Run("Interleaved Keys", func(t *testing.T) {
+		var a GroupAssembler
+		a.Add("A", 1, 2, parts[0], start)
+		a.Add("B", 1, 2, parts[1], start)
+		if g, _ := a.Add("A", 2, 2, parts[2], start); g == nil || g.Key != "A" {
+			t.Errorf("expected group A to be complete but was %v", g)
+		}
+
+		if a.Len() != 1 {
+			t.Errorf("expected group B to be in progress but there were %d groups", a.Len())
+		}
+	})
+
+	for title, vec := range map[string]struct {
+		number, total int
+		at            time.Time
+		reason        string
+	}{
+		"Repeated Part":      {number: 1, total: 3, at: start, reason: "part 1 was received again"},
+		"Changed Part Count": {number: 2, total: 2, at: start, reason: "a part of a group of 2 sentences has the same key"},
+		"Timed Out":          {number: 2, total: 3, at: start.Add(DefaultGroupTimeout + 1), reason: "timed out"},
+	} {
+		t.Run(title, func(t *testing.T) {
+			var a GroupAssembler
+			a.Add("K", 1, 3, parts[0], start)
+
+			_, err := a.Add("K", vec.number, vec.total, parts[1], vec.at)
+
+			var incomplete *IncompleteGroupError
+			if !errors.As(err, &incomplete) || incomplete.Reason != vec.reason {
+				t.Fatalf("expected an *IncompleteGroupError (%s) but was %v", vec.reason, err)
+			}
+
+			if incomplete.Key != "K" || len(incomplete.Parts) != 3 || incomplete.Parts[0] != parts[0] {
+				t.Errorf("unexpected incomplete group %+v", incomplete)
+			}
+
+			if a.Len() != 1 {
+				t.Errorf("expected the new group to be in progress but there were %d groups", a.Len())
+			}
+		})
+	}
+
+	t.Run("Bad Part Number", func(t *testing.T) {
+		var a GroupAssembler
+		expected := "part 3 of sentence group \"K\" must be between 1 and 2"
+		if _, err := a.Add("K", 3, 2, parts[0], start); err == nil || err.Error() != expected {
+			t.Errorf("expected error %q but was %v", expected, err)
+		}
+
+		if a.Len() != 0 {
+			t.Errorf("expected the part to be ignored but there were %d groups", a.Len())
+		}
+	})
+
+	t.Run("Too Many Parts", func(t *testing.T) {
+		var a GroupAssembler
+		if g, err := a.Add("K", 1, MaxGroupSize, parts[0], start); g != nil || err != nil {
+			t.Fatalf("expected a group of %d parts to be kept but was %v (error %v)", MaxGroupSize,
+				g, err)
+		}
+
+		expected := "sentence group \"L\" must have at most 99 parts but has 100"
+		if _, err := a.Add("L", 1, MaxGroupSize+1, parts[0], start); err == nil || err.Error() != expected {
+			t.Errorf("expected error %q but was %v", expected, err)
+		}
+
+		if a.Len() != 1 {
+			t.Errorf("expected the part to be ignored but there were %d groups", a.Len())
+		}
+	})
+}
+
+func TestGroupAssembler_Expire(t *testing.T) {
+	start := time.Date(2026, 3, 1, 18, 37, 30, 0, time.UTC)
+	a := GroupAssembler{Timeout: time.Second}
+	a.Add("B", 1, 2, &testSentence{}, start)
+	a.Add("A", 2, 3, &testSentence{}, start)
+	a.Add("C", 1, 2, &testSentence{}, start.Add(time.Second))
+
+	if err := a.Expire(start.Add(time.Second)); err != nil {
+		t.Errorf("expected no groups to expire but got %v", err)
+	}
+
+	err := a.Expire(start.Add(time.Second + 1))
+	expected := "sentence group \"A\" is incomplete (timed out): missing parts [1 3] of 3\n" +
+		"sentence group \"B\" is incomplete (timed out): missing parts [2] of 2"
+	if err == nil || err.Error() != expected || !errors.Is(err, ErrIncompleteGroup) {
+		t.Errorf("expected error %q but was %v", expected, err)
+	}
+
+	err = a.Flush()
+	expected = "sentence group \"C\" is incomplete (flushed): missing parts [2] of 2"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error %q but was %v", expected, err)
+	}
+
+	if a.Len() != 0 || a.Flush() != nil {
+		t.Errorf("expected no groups in progress but there were %d", a.Len())
+	}
+}
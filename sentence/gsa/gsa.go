@@ -0,0 +1,232 @@
+// Package gsa contains data structures and functions related to NMEA sentences of type "GSA"
+// (GNSS DOP and active satellites), as sent by any talker (e.g. "$GPGSA", "$GLGSA", "$GAGSA" or
+// "$BDGSA").
+package gsa // import "github.com/mab-go/nmea/sentence/gsa"
+
+import (
+	"encoding/json"
+
+	"github.com/mab-go/nmea/sentence"
+)
+
+//go:generate go run github.com/mab-go/nmea/tools/nmeaschema gsa
+
+// GSA represents an NMEA sentence of type "GSA".
+type GSA struct {
+	// TalkerID identifies the talker that sent the sentence (e.g. "GP" or "GL"). It is the first
+	// two characters of element [0] of a GSA sentence.
+	TalkerID string `json:"talkerId"`
+
+	// SelectionMode indicates whether satellite selection is automatic or manual. It is element [1]
+	// of a GSA sentence.
+	SelectionMode SelectionMode `json:"selectionMode,omitzero"`
+
+	// FixMode indicates whether the GPS fix is no fix, 2D, or 3D. It is element [2] of a GSA
+	// sentence.
+	FixMode FixMode `json:"fixMode,omitzero"`
+
+	// PRNs contains the PRN IDs of the satellites used in the solution. The 12 slots are
+	// fixed-position; unused slots are 0. Satellite IDs above 127 (e.g. SBAS or BeiDou PRNs in
+	// the 120–237 range) are supported. They are elements [3]–[14] of a GSA sentence.
+	PRNs [12]int16 `json:"prns"`
+
+	// PDOP is the position dilution of precision. It is element [15] of a GSA sentence. It is not
+	// valid if the field is empty.
+	PDOP sentence.Float32 `json:"pdop"`
+
+	// HDOP is the horizontal dilution of precision. It is element [16] of a GSA sentence. It is not
+	// valid if the field is empty.
+	HDOP sentence.Float32 `json:"hdop"`
+
+	// VDOP is the vertical dilution of precision. It is element [17] of a GSA sentence. It is not
+	// valid if the field is empty.
+	VDOP sentence.Float32 `json:"vdop"`
+
+	// SystemID identifies the GNSS whose satellites PRNs lists: 1 for GPS, 2 for GLONASS, 3 for
+	// Galileo, 4 for BeiDou, and (since NMEA 0183 version 4.11) 5 for QZSS and 6 for NavIC. It is
+	// element [18] of a GSA sentence. It was added in NMEA 0183 version 4.10; if the sentence
+	// predates it, or if the field is empty, it is not valid.
+	SystemID sentence.Int `json:"systemId"`
+
+	// Version is the NMEA 0183 version of the GSA sentence, as far as its fields tell:
+	// [sentence.Version410] if it has a SystemID field, and [sentence.Version20] otherwise.
+	// MarshalNMEA writes the fields of this version; if it is the zero Version, it writes
+	// SystemID only if SystemID is valid. It is not part of the GSA sentence itself.
+	Version sentence.Version `json:"version,omitzero"`
+
+	// TagBlock is the tag block (see [sentence.TagBlock]) that preceded the GSA sentence, or nil if
+	// there was none. It is not part of the GSA sentence itself.
+	TagBlock *sentence.TagBlock `json:"tagBlock,omitzero"`
+}
+
+// GetSentenceType returns the type of NMEA sentence represented by the struct GSA: its talker
+// identifier followed by "GSA" (e.g. "GLGSA"). It represents element [0] of a GSA sentence.
+func (g GSA) GetSentenceType() string {
+	return g.TalkerID + "GSA"
+}
+
+// Talker returns the talker identifier of the GSA sentence (e.g. "GP" or "GL").
+func (g GSA) Talker() string {
+	return g.TalkerID
+}
+
+// MarshalNMEA encodes g as a GSA sentence (including its checksum), e.g.
+// "$GLGSA,A,3,65,67,80,81,82,88,66,,,,,,1.2,0.7,1.0*22". Unused (0) PRN slots are written as
+// empty segments, and only the fields of g.Version are written. It returns an error if g.TalkerID
+// is not a valid talker identifier or if an enum field does not hold one of its defined values.
+func (g GSA) MarshalNMEA() (string, error) {
+	w := &sentence.SegmentWriter{}
+	w.WriteTagBlock(g.TagBlock)
+	w.WriteSentenceType(g.TalkerID, "GSA")
+	w.WriteEnum(g.SelectionMode, g.SelectionMode.IsASelectionMode())
+	w.WriteEnum(g.FixMode, g.FixMode.IsAFixMode())
+
+	for _, prn := range g.PRNs {
+		if prn == 0 {
+			w.WriteString("")
+		} else {
+			w.WriteZeroPaddedInt(int64(prn), 2)
+		}
+	}
+
+	w.WriteOptionalFloat32(g.PDOP)
+	w.WriteOptionalFloat32(g.HDOP)
+	w.WriteOptionalFloat32(g.VDOP)
+
+	if g.Version.Encodes(sentence.Version410, g.SystemID.Valid) {
+		w.WriteOptionalInt(g.SystemID)
+	}
+
+	return w.Sentence()
+}
+
+// MarshalJSON encodes g as a JSON object whose members are "sentenceType" (e.g. "GLGSA") and the
+// fields of g, named by their json struct tags. See [sentence.MarshalSentenceJSON] for the encoding
+// of each type of field, and gsa.schema.json for its JSON Schema.
+func (g GSA) MarshalJSON() ([]byte, error) {
+	type fields GSA // Has the fields of GSA, but not its MarshalJSON method
+
+	return sentence.MarshalSentenceJSON(g.GetSentenceType(), fields(g))
+}
+
+// UnmarshalJSON decodes a JSON object encoded by MarshalJSON into g, which is only modified if
+// data is valid. Its "sentenceType" member may be omitted.
+func (g *GSA) UnmarshalJSON(data []byte) error {
+	type fields GSA
+
+	var decoded GSA
+	if err := sentence.UnmarshalSentenceJSON(data, (*fields)(&decoded), &decoded); err != nil {
+		return err
+	}
+
+	*g = decoded
+
+	return nil
+}
+
+// Ensure that GSA properly implements the NMEASentence, Marshaler and JSON interfaces
+var (
+	_ sentence.NMEASentence = GSA{}
+	_ sentence.Marshaler    = GSA{}
+	_ json.Marshaler        = GSA{}
+	_ json.Unmarshaler      = (*GSA)(nil)
+)
+
+func init() {
+	sentence.Register("GSA", func(p *sentence.SegmentParser) (sentence.NMEASentence, error) {
+		gsa, err := Decode(p)
+		if err != nil {
+			return nil, err
+		}
+
+		return gsa, nil
+	})
+}
+
+// Parse parses a GSA sentence string from any talker and returns a pointer to a GSA struct (or an
+// error if the sentence is invalid).
+func Parse(s string) (*GSA, error) {
+	return ParseWithOptions(s)
+}
+
+// ParseWithOptions is like Parse, but parses the sentence according to opts (see
+// [sentence.ParseOptions]), e.g. to accept a sentence that has no checksum. With no options, it
+// is identical to Parse.
+func ParseWithOptions(s string, opts ...sentence.ParseOption) (*GSA, error) {
+	p := &sentence.SegmentParser{}
+	if err := p.ParseWithOptions(s, opts...); err != nil {
+		return nil, err
+	}
+
+	return Decode(p)
+}
+
+// Decode decodes a GSA sentence from p, which must already have parsed the sentence (see
+// [sentence.SegmentParser.Parse]). It returns a pointer to a GSA struct (or an error if the
+// sentence is invalid).
+func Decode(p *sentence.SegmentParser) (*GSA, error) {
+	gsa := &GSA{}
+	if err := DecodeInto(p, gsa); err != nil {
+		return nil, err
+	}
+
+	return gsa, nil
+}
+
+// DecodeInto is like Decode, but decodes the sentence into dst, which is only modified if the
+// sentence is valid. Used with a reused [sentence.SegmentParser] and its ParseBytes method, it
+// decodes a sentence without a tag block without allocating.
+func DecodeInto(p *sentence.SegmentParser, dst *GSA) error {
+	segments := &SegmentParser{SegmentParser: *p}
+	segments.NameFields(fieldNames...)
+	gsa := GSA{
+		TalkerID:      segments.RequireFormatter("GSA"), // Verify sentence type
+		SelectionMode: segments.AsSelectionMode(1),
+		FixMode:       segments.AsFixMode(2),
+		PRNs: [12]int16{
+			segments.AsInt16(3),
+			segments.AsInt16(4),
+			segments.AsInt16(5),
+			segments.AsInt16(6),
+			segments.AsInt16(7),
+			segments.AsInt16(8),
+			segments.AsInt16(9),
+			segments.AsInt16(10),
+			segments.AsInt16(11),
+			segments.AsInt16(12),
+			segments.AsInt16(13),
+			segments.AsInt16(14),
+		},
+		PDOP:     segments.AsOptionalFloat32(15),
+		HDOP:     segments.AsOptionalFloat32(16),
+		VDOP:     segments.AsOptionalFloat32(17),
+		Version:  versions.Detect(segments.Len()),
+		TagBlock: p.TagBlock(),
+	}
+
+	if gsa.Version >= sentence.Version410 {
+		gsa.SystemID = segments.AsOptionalInt(18, 8)
+	}
+
+	if err := segments.Err(); err != nil {
+		return err
+	}
+
+	*dst = gsa
+
+	return nil
+}
+
+// versions declares the NMEA 0183 versions of the GSA fields: version 4.10 appended SystemID.
+var versions = sentence.FieldVersions{
+	{Version: sentence.Version20, Len: 18},
+	{Version: sentence.Version410, Len: 19},
+}
+
+// fieldNames names the GSA field held by each sentence segment, for use in errors (see
+// [sentence.FieldError]).
+var fieldNames = []string{
+	"TalkerID", "SelectionMode", "FixMode", "PRNs[0]", "PRNs[1]", "PRNs[2]", "PRNs[3]", "PRNs[4]",
+	"PRNs[5]", "PRNs[6]", "PRNs[7]", "PRNs[8]", "PRNs[9]", "PRNs[10]", "PRNs[11]", "PDOP", "HDOP",
+	"VDOP", "SystemID",
+}
@@ -0,0 +1,290 @@
+package gsa
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mab-go/nmea/sentence"
+)
+
+type testVec struct {
+	input    string
+	expected GSA
+	json     string
+	errMsg   string
+}
+
+var goodTestData = map[string]testVec{
+	"GPS (GP)": {
+		input: "$GPGSA,A,3,03,22,06,19,11,14,32,01,28,18,,,1.8,0.8,1.6*3F",
+		json:  `{"sentenceType":"GPGSA","talkerId":"GP","selectionMode":"A","fixMode":"3","prns":[3,22,6,19,11,14,32,1,28,18,0,0],"pdop":1.8,"hdop":0.8,"vdop":1.6,"systemId":null,"version":"2.0"}`,
+		expected: GSA{
+			TalkerID:      "GP",
+			SelectionMode: AutomaticSelectionMode,
+			FixMode:       Fix3D,
+			PRNs:          [12]int16{3, 22, 6, 19, 11, 14, 32, 1, 28, 18, 0, 0},
+			PDOP:          sentence.NewFloat32(1.8),
+			HDOP:          sentence.NewFloat32(0.8),
+			VDOP:          sentence.NewFloat32(1.6),
+			Version:       sentence.Version20,
+		},
+	},
+	"GLONASS (GL)": {
+		input: "$GLGSA,A,3,65,67,80,81,82,88,66,,,,,,1.2,0.7,1.0*22",
+		json:  `{"sentenceType":"GLGSA","talkerId":"GL","selectionMode":"A","fixMode":"3","prns":[65,67,80,81,82,88,66,0,0,0,0,0],"pdop":1.2,"hdop":0.7,"vdop":1,"systemId":null,"version":"2.0"}`,
+		expected: GSA{
+			TalkerID:      "GL",
+			SelectionMode: AutomaticSelectionMode,
+			FixMode:       Fix3D,
+			PRNs:          [12]int16{65, 67, 80, 81, 82, 88, 66, 0, 0, 0, 0, 0},
+			PDOP:          sentence.NewFloat32(1.2),
+			HDOP:          sentence.NewFloat32(0.7),
+			VDOP:          sentence.NewFloat32(1.0),
+			Version:       sentence.Version20,
+		},
+	},
+	"Galileo (GA)": {
+		input: "$GAGSA,A,3,03,05,13,,,,,,,,,,2.0,1.1,1.7*23",
+		json:  `{"sentenceType":"GAGSA","talkerId":"GA","selectionMode":"A","fixMode":"3","prns":[3,5,13,0,0,0,0,0,0,0,0,0],"pdop":2,"hdop":1.1,"vdop":1.7,"systemId":null,"version":"2.0"}`,
+		expected: GSA{
+			TalkerID:      "GA",
+			SelectionMode: AutomaticSelectionMode,
+			FixMode:       Fix3D,
+			PRNs:          [12]int16{3, 5, 13, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			PDOP:          sentence.NewFloat32(2.0),
+			HDOP:          sentence.NewFloat32(1.1),
+			VDOP:          sentence.NewFloat32(1.7),
+			Version:       sentence.Version20,
+		},
+	},
+	"BeiDou (BD)": {
+		input: "$BDGSA,A,3,201,202,,,,,,,,,,,2.0,1.1,1.7*24",
+		json:  `{"sentenceType":"BDGSA","talkerId":"BD","selectionMode":"A","fixMode":"3","prns":[201,202,0,0,0,0,0,0,0,0,0,0],"pdop":2,"hdop":1.1,"vdop":1.7,"systemId":null,"version":"2.0"}`,
+		expected: GSA{
+			TalkerID:      "BD",
+			SelectionMode: AutomaticSelectionMode,
+			FixMode:       Fix3D,
+			PRNs:          [12]int16{201, 202, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			PDOP:          sentence.NewFloat32(2.0),
+			HDOP:          sentence.NewFloat32(1.1),
+			VDOP:          sentence.NewFloat32(1.7),
+			Version:       sentence.Version20,
+		},
+	},
+	"Multi-Constellation GNSS (GN)": {
+		input: "$GNGSA,A,3,65,67,80,81,82,88,66,,,,,,1.2,0.7,1.0*20",
+		json:  `{"sentenceType":"GNGSA","talkerId":"GN","selectionMode":"A","fixMode":"3","prns":[65,67,80,81,82,88,66,0,0,0,0,0],"pdop":1.2,"hdop":0.7,"vdop":1,"systemId":null,"version":"2.0"}`,
+		expected: GSA{
+			TalkerID:      "GN",
+			SelectionMode: AutomaticSelectionMode,
+			FixMode:       Fix3D,
+			PRNs:          [12]int16{65, 67, 80, 81, 82, 88, 66, 0, 0, 0, 0, 0},
+			PDOP:          sentence.NewFloat32(1.2),
+			HDOP:          sentence.NewFloat32(0.7),
+			VDOP:          sentence.NewFloat32(1.0),
+			Version:       sentence.Version20,
+		},
+	},
+	"NMEA 4.10 (System ID)": {
+		input: "$GNGSA,A,3,80,71,73,79,69,,,,,,,,1.83,1.09,1.47,2*09",
+		json:  `{"sentenceType":"GNGSA","talkerId":"GN","selectionMode":"A","fixMode":"3","prns":[80,71,73,79,69,0,0,0,0,0,0,0],"pdop":1.83,"hdop":1.09,"vdop":1.47,"systemId":2,"version":"4.10"}`,
+		expected: GSA{
+			TalkerID:      "GN",
+			SelectionMode: AutomaticSelectionMode,
+			FixMode:       Fix3D,
+			PRNs:          [12]int16{80, 71, 73, 79, 69},
+			PDOP:          sentence.NewFloat32(1.83),
+			HDOP:          sentence.NewFloat32(1.09),
+			VDOP:          sentence.NewFloat32(1.47),
+			SystemID:      sentence.NewInt(2),
+			Version:       sentence.Version410,
+		},
+	},
+}
+
+var badTestData = map[string]testVec{
+	"Bad Formatter": {
+		input:  "$GNFOO,A,3,65,67,80,81,82,88,66,,,,,,1.2,0.7,1.0*33",
+		errMsg: "sentence segment [0] must be \"--GSA\" (case insensitive) but was \"GNFOO\"",
+	},
+	"Bad PRN": {
+		input:  "$GPGSA,A,3,bad_PRN,22,06,19,11,14,32,01,28,18,,,1.8,0.8,1.6*48",
+		errMsg: "sentence segment [3] must be parsable as an int16 but was \"bad_PRN\"",
+	},
+	"Bad System ID": {
+		input:  "$GNGSA,A,3,80,71,73,79,69,,,,,,,,1.83,1.09,1.47,X*63",
+		errMsg: "sentence segment [18] must be parsable as an int8 but was \"X\"",
+	},
+}
+
+func TestParse_goodData(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			actual, err := Parse(vec.input)
+			if err != nil {
+				t.Fatalf("error creating GSA from NMEA input \"%v\": %v", title, err)
+			}
+
+			if *actual != vec.expected {
+				t.Errorf("result should have been %+v but was %+v for NMEA input \"%v\"", vec.expected, *actual, title)
+			}
+		})
+	}
+}
+
+func TestParse_badSegments(t *testing.T) {
+	for title, vec := range badTestData {
+		t.Run(title, func(t *testing.T) {
+			gsa, err := Parse(vec.input)
+			if err == nil {
+				t.Fatalf("parsing succeeded (but should not have) for test sentence %q", title)
+			}
+
+			if gsa != nil {
+				t.Fatalf("result should have been <nil> but was %v for test sentence %q", gsa, title)
+			}
+
+			if err.Error() != vec.errMsg {
+				t.Fatalf("error message should have been '%v' but was '%v' for test sentence %q", vec.errMsg, err.Error(), title)
+			}
+		})
+	}
+}
+
+func TestParse_registered(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			s, err := sentence.Parse(vec.input)
+			if err != nil {
+				t.Fatalf("sentence.Parse failed: %v", err)
+			}
+
+			actual, ok := s.(*GSA)
+			if !ok {
+				t.Fatalf("sentence.Parse should have returned a *GSA but returned %T", s)
+			}
+
+			if *actual != vec.expected {
+				t.Errorf("sentence.Parse result should have been %+v but was %+v", vec.expected, *actual)
+			}
+		})
+	}
+}
+
+func TestGSA_MarshalNMEA_roundTrips(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			encoded, err := vec.expected.MarshalNMEA()
+			if err != nil {
+				t.Fatalf("MarshalNMEA failed: %v", err)
+			}
+
+			if err := sentence.VerifyChecksum(encoded); err != nil {
+				t.Fatalf("MarshalNMEA produced an invalid checksum: %v", err)
+			}
+
+			actual, err := Parse(encoded)
+			if err != nil {
+				t.Fatalf("error re-parsing %q: %v", encoded, err)
+			}
+
+			if *actual != vec.expected {
+				t.Errorf("round trip through %q should have produced %+v but produced %+v", encoded, vec.expected, *actual)
+			}
+		})
+	}
+}
+
+func TestGSA_MarshalJSON(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			encoded, err := json.Marshal(vec.expected)
+			if err != nil {
+				t.Fatalf("json.Marshal failed: %v", err)
+			}
+
+			if string(encoded) != vec.json {
+				t.Errorf("expected %s but was %s", vec.json, encoded)
+			}
+
+			var actual GSA
+			if err := json.Unmarshal([]byte(vec.json), &actual); err != nil {
+				t.Fatalf("error decoding %s: %v", vec.json, err)
+			}
+
+			if actual != vec.expected {
+				t.Errorf("decoding %s should have produced %+v but produced %+v", vec.json, vec.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGSA_MarshalNMEA_errors(t *testing.T) {
+	g := goodTestData["GPS (GP)"].expected
+	g.FixMode = 0
+
+	expected := "sentence segment [2] must be a valid gsa.FixMode but was \"FixMode(0)\""
+	if _, err := g.MarshalNMEA(); err == nil || err.Error() != expected {
+		t.Errorf("expected error %q but was %v", expected, err)
+	}
+}
+
+func TestGSA_MarshalNMEA_version(t *testing.T) {
+	g := goodTestData["NMEA 4.10 (System ID)"].expected
+	for _, vec := range []struct {
+		title    string
+		version  sentence.Version
+		systemID sentence.Int
+		expected string
+	}{
+		{"Version 4.10", sentence.Version410, sentence.NewInt(2), "$GNGSA,A,3,80,71,73,79,69,,,,,,,,1.83,1.09,1.47,2*09"},
+		{"Version 4.10 Without System ID", sentence.Version410, sentence.Int{}, "$GNGSA,A,3,80,71,73,79,69,,,,,,,,1.83,1.09,1.47,*3B"},
+		{"Version 2.3", sentence.Version23, sentence.NewInt(2), "$GNGSA,A,3,80,71,73,79,69,,,,,,,,1.83,1.09,1.47*17"},
+		{"No Version", 0, sentence.NewInt(2), "$GNGSA,A,3,80,71,73,79,69,,,,,,,,1.83,1.09,1.47,2*09"},
+		{"No Version or System ID", 0, sentence.Int{}, "$GNGSA,A,3,80,71,73,79,69,,,,,,,,1.83,1.09,1.47*17"},
+	} {
+		t.Run(vec.title, func(t *testing.T) {
+			g.Version, g.SystemID = vec.version, vec.systemID
+			if actual, err := g.MarshalNMEA(); err != nil || actual != vec.expected {
+				t.Errorf("expected %q but was %q (error %v)", vec.expected, actual, err)
+			}
+		})
+	}
+}
+
+func TestGSA_GetSentenceType(t *testing.T) {
+	gsa := &GSA{TalkerID: "BD"}
+	if st := gsa.GetSentenceType(); st != "BDGSA" {
+		t.Errorf("GetSentenceType() should have returned \"BDGSA\" but returned \"%v\"", st)
+	}
+}
+
+func TestGSA_Talker(t *testing.T) {
+	gsa := &GSA{TalkerID: "GQ"}
+	if talker := gsa.Talker(); talker != "GQ" {
+		t.Errorf("Talker() should have returned \"GQ\" but returned \"%v\"", talker)
+	}
+}
+
+func ExampleParse() {
+	s := "$GLGSA,A,3,65,67,80,81,82,88,66,,,,,,1.2,0.7,1.0*22"
+	gsa, err := Parse(s)
+	_ = err
+
+	fmt.Printf("%s: %+v", gsa.Talker(), gsa)
+	// Output:
+	// GL: &{TalkerID:GL SelectionMode:A FixMode:3 PRNs:[65 67 80 81 82 88 66 0 0 0 0 0] PDOP:{Float32:1.2 Valid:true} HDOP:{Float32:0.7 Valid:true} VDOP:{Float32:1 Valid:true} SystemID:{Int:0 Valid:false} Version:2.0 TagBlock:<nil>}
+}
+
+func ExampleGSA_MarshalNMEA() {
+	gsa, err := Parse("$GLGSA,A,3,65,67,80,81,82,88,66,,,,,,1.2,0.7,1.0*22")
+	_ = err
+
+	encoded, err := gsa.MarshalNMEA()
+	_ = err
+
+	fmt.Println(encoded)
+	// Output:
+	// $GLGSA,A,3,65,67,80,81,82,88,66,,,,,,1.2,0.7,1.0*22
+}
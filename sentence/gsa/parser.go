@@ -0,0 +1,48 @@
+package gsa
+
+import (
+	"fmt"
+
+	"github.com/mab-go/nmea/sentence"
+)
+
+// SegmentParser extends sentence.SegmentParser to provide GSA-specific segment parsing methods.
+type SegmentParser struct {
+	sentence.SegmentParser
+}
+
+// AsSelectionMode parses the input segment at the specified index as a SelectionMode value. If
+// p.Err() is not nil, this function returns SelectionMode(0) and leaves the error unchanged.
+func (p *SegmentParser) AsSelectionMode(i int8) SelectionMode {
+	s, ok := p.Segment(i)
+	if !ok {
+		return SelectionMode(0)
+	}
+
+	sm, err := SelectionModeString(s)
+	if err != nil || !p.AcceptsCase(s, sm.String()) {
+		p.Fail(i, "SelectionMode", fmt.Sprintf("must be parsable as a SelectionMode but was \"%s\"", s))
+
+		return SelectionMode(0)
+	}
+
+	return sm
+}
+
+// AsFixMode parses the input segment at the specified index as a FixMode value. If p.Err() is not
+// nil, this function returns FixMode(0) and leaves the error unchanged.
+func (p *SegmentParser) AsFixMode(i int8) FixMode {
+	s, ok := p.Segment(i)
+	if !ok {
+		return FixMode(0)
+	}
+
+	fm, err := FixModeString(s)
+	if err != nil || !p.AcceptsCase(s, fm.String()) {
+		p.Fail(i, "FixMode", fmt.Sprintf("must be parsable as a FixMode but was \"%s\"", s))
+
+		return FixMode(0)
+	}
+
+	return fm
+}
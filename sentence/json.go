@@ -0,0 +1,284 @@
+package sentence
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// --- Public ------------------------------------------------------------------
+
+// MarshalSentenceJSON returns the JSON encoding of fields, the fields of a sentence struct whose
+// sentence type is sentenceType (e.g. "GPGGA"), as an object whose first member is
+// "sentenceType". The MarshalJSON methods of the sentence packages use it to encode their
+// structs, whose JSON encoding is stable and documented:
+//
+//   - Each field is a member named by its json struct tag, in lower camel case (e.g. "talkerId",
+//     "fixTime" or "hdop").
+//   - An NMEATime is a string in its wire format, e.g. "161229.487".
+//   - An NMEADate is an ISO 8601 date string, e.g. "1994-03-23", or null if it is empty.
+//   - A Latitude or Longitude is a string holding its two segments, e.g. "3723.2475,N", or null
+//     if it is not valid. This keeps the value exact; see Latitude.Degrees.
+//   - A Float32, Float64 or Int is a number, or null if it is not valid.
+//   - A Time is a string like an NMEATime, or null if it is not valid.
+//   - An enum is a string holding its wire value (e.g. "A"). An enum field that holds the zero
+//     value (no value, e.g. a Mode that predates NMEA 0183 version 2.3) is omitted.
+//   - A tag block is an object, and is omitted if there is none.
+//
+// JSONSchema returns a JSON Schema that describes the encoding of a sentence struct.
+func MarshalSentenceJSON(sentenceType string, fields any) ([]byte, error) {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 2 || data[0] != '{' {
+		return nil, fmt.Errorf("the fields of a sentence must be a JSON object but were %s", data)
+	}
+
+	st, err := json.Marshal(sentenceType)
+	if err != nil {
+		return nil, err
+	}
+
+	out := append([]byte(`{"sentenceType":`), st...)
+	if len(data) > 2 {
+		out = append(out, ',')
+	}
+
+	return append(out, data[1:]...), nil
+}
+
+// UnmarshalSentenceJSON decodes data, a JSON object encoded by MarshalSentenceJSON, into fields,
+// a pointer to the fields of the sentence s (usually both point to the same struct). It returns an
+// error if the object's "sentenceType" member, which may be omitted, is not the sentence type of
+// the decoded s (see NMEASentence.GetSentenceType).
+func UnmarshalSentenceJSON(data []byte, fields any, s NMEASentence) error {
+	var header struct {
+		SentenceType *string `json:"sentenceType"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, fields); err != nil {
+		return err
+	}
+
+	if header.SentenceType != nil && *header.SentenceType != s.GetSentenceType() {
+		return fmt.Errorf("sentenceType must be \"%s\" but was \"%s\"", s.GetSentenceType(),
+			*header.SentenceType)
+	}
+
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for NMEATime. It returns the wire
+// format of t (see NMEATime.String).
+func (t NMEATime) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for NMEATime. It accepts the
+// formats accepted by SegmentParser.AsNMEATime.
+func (t *NMEATime) UnmarshalText(text []byte) error {
+	v, err := parseNMEATime(string(text))
+	if err != nil {
+		return fmt.Errorf("\"%s\" is not a valid NMEATime: %w", text, err)
+	}
+
+	*t = v
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for NMEADate. It returns an ISO 8601 date
+// string (e.g. "1994-03-23"), or null if d is the zero NMEADate.
+func (d NMEADate) MarshalJSON() ([]byte, error) {
+	if d == (NMEADate{}) {
+		return []byte("null"), nil
+	}
+
+	if d.Year < 0 || d.Year > 9999 || validateNMEADate(d) != nil {
+		return nil, fmt.Errorf("%+v is not a valid NMEADate", d)
+	}
+
+	return fmt.Appendf(nil, "\"%04d-%02d-%02d\"", d.Year, d.Month, d.Day), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for NMEADate.
+func (d *NMEADate) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = NMEADate{}
+
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("NMEADate should be a string, got %s", data)
+	}
+
+	tm, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return fmt.Errorf("\"%s\" is not a valid NMEADate (yyyy-mm-dd)", s)
+	}
+
+	*d = NMEADate{Year: tm.Year(), Month: int(tm.Month()), Day: tm.Day()}
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for Latitude. It returns the latitude's two
+// segments as a string (e.g. "3723.2475,N"), or null if l is not valid.
+func (l Latitude) MarshalJSON() ([]byte, error) {
+	if !l.Valid {
+		return []byte("null"), nil
+	}
+
+	if !l.Value.inRange(90) || !l.Hemisphere.IsANorthSouth() {
+		return nil, fmt.Errorf("%+v is not a valid Latitude", l)
+	}
+
+	return json.Marshal(l.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Latitude. It accepts the strings
+// accepted by ParseLatitude.
+func (l *Latitude) UnmarshalJSON(data []byte) error {
+	return unmarshalCoordinateJSON(data, l, ParseLatitude)
+}
+
+// MarshalJSON implements the json.Marshaler interface for Longitude. It returns the longitude's
+// two segments as a string (e.g. "12158.3416,W"), or null if l is not valid.
+func (l Longitude) MarshalJSON() ([]byte, error) {
+	if !l.Valid {
+		return []byte("null"), nil
+	}
+
+	if !l.Value.inRange(180) || !l.Hemisphere.IsAEastWest() {
+		return nil, fmt.Errorf("%+v is not a valid Longitude", l)
+	}
+
+	return json.Marshal(l.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Longitude. It accepts the strings
+// accepted by ParseLongitude.
+func (l *Longitude) UnmarshalJSON(data []byte) error {
+	return unmarshalCoordinateJSON(data, l, ParseLongitude)
+}
+
+// MarshalJSON implements the json.Marshaler interface for Float32. It returns null if f is not
+// valid.
+func (f Float32) MarshalJSON() ([]byte, error) {
+	return marshalNullableJSON(f.Float32, f.Valid)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Float32.
+func (f *Float32) UnmarshalJSON(data []byte) error {
+	return unmarshalNullableJSON(data, &f.Float32, &f.Valid)
+}
+
+// MarshalJSON implements the json.Marshaler interface for Float64. It returns null if f is not
+// valid.
+func (f Float64) MarshalJSON() ([]byte, error) {
+	return marshalNullableJSON(f.Float64, f.Valid)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Float64.
+func (f *Float64) UnmarshalJSON(data []byte) error {
+	return unmarshalNullableJSON(data, &f.Float64, &f.Valid)
+}
+
+// MarshalJSON implements the json.Marshaler interface for Int. It returns null if i is not valid.
+func (i Int) MarshalJSON() ([]byte, error) {
+	return marshalNullableJSON(i.Int, i.Valid)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Int.
+func (i *Int) UnmarshalJSON(data []byte) error {
+	return unmarshalNullableJSON(data, &i.Int, &i.Valid)
+}
+
+// MarshalJSON implements the json.Marshaler interface for Time. It returns the wire format of the
+// time as a string (e.g. "161229.487"), or null if t is not valid.
+func (t Time) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(t.NMEATime)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Time. It accepts null or the strings
+// accepted by NMEATime.UnmarshalText.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*t = Time{}
+
+		return nil
+	}
+
+	var v NMEATime
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*t = NewTime(v)
+
+	return nil
+}
+
+// --- Private -----------------------------------------------------------------
+
+// unmarshalCoordinateJSON decodes data, null or a string that parse accepts, into dst.
+func unmarshalCoordinateJSON[T Latitude | Longitude](data []byte, dst *T,
+	parse func(string) (T, error)) error {
+	if bytes.Equal(data, []byte("null")) {
+		*dst = *new(T)
+
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%T should be a string, got %s", *dst, data)
+	}
+
+	v, err := parse(s)
+	if err != nil {
+		return err
+	}
+
+	*dst = v
+
+	return nil
+}
+
+// marshalNullableJSON returns the JSON encoding of v, or null if it is not valid.
+func marshalNullableJSON(v any, valid bool) ([]byte, error) {
+	if !valid {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(v)
+}
+
+// unmarshalNullableJSON decodes data into *v, setting *valid to whether data is not null.
+func unmarshalNullableJSON[T float32 | float64 | int64](data []byte, v *T, valid *bool) error {
+	if bytes.Equal(data, []byte("null")) {
+		*v, *valid = 0, false
+
+		return nil
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	*valid = true
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package sentence
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// jsonFields is a struct with a field of each type that has a JSON encoding of its own.
+type jsonFields struct {
+	Time      NMEATime   `json:"time"`
+	Date      NMEADate   `json:"date"`
+	Latitude  Latitude   `json:"latitude"`
+	Longitude Longitude  `json:"longitude"`
+	Float32   Float32    `json:"float32"`
+	Float64   Float64    `json:"float64"`
+	Int       Int        `json:"int"`
+	FixTime   Time       `json:"fixTime"`
+	Hemi      NorthSouth `json:"hemi,omitzero"`
+}
+
+// jsonSentence is a sentence struct that has the fields of jsonFields.
+type jsonSentence struct {
+	TalkerID string `json:"talkerId"`
+	jsonFields
+}
+
+func (s jsonSentence) GetSentenceType() string { return s.TalkerID + "TST" }
+
+func (s jsonSentence) Talker() string { return s.TalkerID }
+
+func TestMarshalSentenceJSON(t *testing.T) {
+	for _, vec := range []struct {
+		title    string
+		fields   jsonFields
+		expected string
+	}{
+		{
+			title: "Valid",
+			fields: jsonFields{
+				Time:      NMEATime{Hour: 23, Minute: 59, Second: 60, Millisecond: 5},
+				Date:      NMEADate{Year: 1994, Month: 3, Day: 23},
+				Latitude:  MustParseLatitude("0510.5,S"),
+				Longitude: MustParseLongitude("12158.341600,W"),
+				Float32:   NewFloat32(1.1),
+				Float64:   NewFloat64(-0.25),
+				Int:       NewInt(0),
+				FixTime:   NewTime(NMEATime{FractionDigits: NoFractionDigits}),
+				Hemi:      North,
+			},
+			expected: `{"sentenceType":"GPTST","talkerId":"GP","time":"235960.005","date":"1994-03-23",` +
+				`"latitude":"0510.5,S","longitude":"12158.341600,W","float32":1.1,"float64":-0.25,` +
+				`"int":0,"fixTime":"000000","hemi":"N"}`,
+		},
+		{
+			title: "Empty",
+			expected: `{"sentenceType":"GPTST","talkerId":"GP","time":"000000.000","date":null,` +
+				`"latitude":null,"longitude":null,"float32":null,"float64":null,"int":null,` +
+				`"fixTime":null}`,
+		},
+	} {
+		t.Run(vec.title, func(t *testing.T) {
+			data, err := MarshalSentenceJSON("GPTST", jsonSentence{TalkerID: "GP", jsonFields: vec.fields})
+			if err != nil {
+				t.Fatalf("MarshalSentenceJSON failed: %v", err)
+			}
+
+			if string(data) != vec.expected {
+				t.Errorf("expected %s but was %s", vec.expected, data)
+			}
+
+			var actual jsonSentence
+			if err := UnmarshalSentenceJSON(data, &actual, &actual); err != nil {
+				t.Fatalf("UnmarshalSentenceJSON failed: %v", err)
+			}
+
+			if actual.jsonFields != vec.fields {
+				t.Errorf("round trip should have produced %+v but produced %+v", vec.fields,
+					actual.jsonFields)
+			}
+		})
+	}
+}
+
+func TestMarshalSentenceJSON_errors(t *testing.T) {
+	for title, fields := range map[string]any{
+		"Not An Object":     "GPTST",
+		"Invalid Date":      jsonFields{Date: NMEADate{Year: 2023, Month: 2, Day: 29}},
+		"Invalid Latitude":  jsonFields{Latitude: NewLatitude(Coordinate{Degrees: 91}, North)},
+		"Invalid Longitude": jsonFields{Longitude: Longitude{Value: Coordinate{Degrees: 1}, Valid: true}},
+	} {
+		if _, err := MarshalSentenceJSON("GPTST", fields); err == nil {
+			t.Errorf("%s: expected an error", title)
+		}
+	}
+}
+
+func TestUnmarshalSentenceJSON_errors(t *testing.T) {
+	for input, errMsg := range map[string]string{
+		`{"sentenceType":"GPGGA","talkerId":"GP"}`: `sentenceType must be "GPTST" but was "GPGGA"`,
+		`{"time":"250000"}`:                        `"250000" is not a valid NMEATime`,
+		`{"fixTime":"250000"}`:                     `"250000" is not a valid NMEATime`,
+		`{"date":"1994-02-30"}`:                    `"1994-02-30" is not a valid NMEADate (yyyy-mm-dd)`,
+		`{"date":19940323}`:                        "NMEADate should be a string, got 19940323",
+		`{"latitude":"9100,N"}`:                    "is not a valid latitude",
+		`{"longitude":1.5}`:                        "sentence.Longitude should be a string, got 1.5",
+		`{"float32":"1.5"}`:                        "cannot unmarshal string",
+		`{"hemi":"E"}`:                             "E does not belong to NorthSouth values",
+		`[]`:                                       "cannot unmarshal array",
+	} {
+		actual := jsonSentence{TalkerID: "GP"}
+		err := UnmarshalSentenceJSON([]byte(input), &actual, &actual)
+		if err == nil || !strings.Contains(err.Error(), errMsg) {
+			t.Errorf("expected an error containing %q for %s but was %v", errMsg, input, err)
+		}
+	}
+}
+
+func TestNMEATime_UnmarshalText(t *testing.T) {
+	var actual struct{ Time NMEATime }
+	if err := json.Unmarshal([]byte(`{"Time":"2454"}`), &actual); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+
+	if expected := (NMEATime{Minute: 24, Second: 54, FractionDigits: NoFractionDigits}); actual.Time != expected {
+		t.Errorf("expected %+v but was %+v", expected, actual.Time)
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	data, err := JSONSchema(jsonSentence{})
+	if err != nil {
+		t.Fatalf("JSONSchema failed: %v", err)
+	}
+
+	var schema struct {
+		Schema     string                     `json:"$schema"`
+		Title      string                     `json:"title"`
+		Properties map[string]json.RawMessage `json:"properties"`
+		Required   []string                   `json:"required"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("the schema is not valid JSON: %v\n%s", err, data)
+	}
+
+	if schema.Schema != JSONSchemaDialect || schema.Title != "sentence.jsonSentence" {
+		t.Errorf("unexpected $schema or title:\n%s", data)
+	}
+
+	for name, expected := range map[string]string{
+		"sentenceType": `{"type":"string"}`,
+		"date":         `{"type":["string","null"],"format":"date"}`,
+		"int":          `{"type":["integer","null"]}`,
+		"fixTime":      `{"type":["string","null"],"pattern":"^[0-9]{6}(\\.[0-9]{1,3})?$"}`,
+		"hemi":         `{"type":"string","enum":["N","S"]}`,
+	} {
+		var actual any
+		if err := json.Unmarshal(schema.Properties[name], &actual); err != nil {
+			t.Fatalf("property %s: %v", name, err)
+		}
+
+		compact, _ := json.Marshal(actual)
+		var want any
+		_ = json.Unmarshal([]byte(expected), &want)
+		wantCompact, _ := json.Marshal(want)
+		if string(compact) != string(wantCompact) {
+			t.Errorf("expected property %s to be %s but was %s", name, wantCompact, compact)
+		}
+	}
+
+	if strings.Join(schema.Required, ",") !=
+		"sentenceType,talkerId,time,date,latitude,longitude,float32,float64,int,fixTime" {
+		t.Errorf("unexpected required properties %v", schema.Required)
+	}
+}
+
+func TestJSONSchema_unsupportedType(t *testing.T) {
+	type withMap struct {
+		jsonSentence
+		Extra map[string]string `json:"extra"`
+	}
+
+	_, err := JSONSchema(withMap{})
+	expected := "field Extra of sentence.withMap: map[string]string has no JSON Schema"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error %q but was %v", expected, err)
+	}
+}
@@ -0,0 +1,228 @@
+package sentence
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// --- Public ------------------------------------------------------------------
+
+// JSONSchemaDialect is the JSON Schema dialect ("$schema") of the schemas returned by JSONSchema.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema returns a JSON Schema (see JSONSchemaDialect) that describes the JSON encoding of the
+// sentence struct s (see MarshalSentenceJSON), such as a gga.GGA, derived from its fields and
+// their json struct tags. The schema of each sentence package is also published beside its source
+// as <package>.schema.json, and those of RawSentence and Query as raw.schema.json and
+// query.schema.json.
+//
+// An enum field is described by the wire values returned by its Values method (which enumer
+// generates with the -values flag). It returns an error if s has a field of a type that has no
+// JSON Schema.
+func JSONSchema(s NMEASentence) ([]byte, error) {
+	t := reflect.TypeOf(s)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	schema, err := schemaFor(t)
+	if err != nil {
+		return nil, err
+	}
+
+	if schema.Properties == nil {
+		return nil, fmt.Errorf("%s is not a struct", t)
+	}
+
+	schema.Schema = JSONSchemaDialect
+	schema.Title = t.String()
+	schema.Properties = append(jsonProperties{{"sentenceType", &jsonSchema{Type: "string"}}},
+		schema.Properties...)
+	schema.Required = append([]string{"sentenceType"}, schema.Required...)
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+// --- Private -----------------------------------------------------------------
+
+// jsonSchema is a JSON Schema, with only the keywords that JSONSchema uses.
+type jsonSchema struct {
+	Schema               string         `json:"$schema,omitempty"`
+	Title                string         `json:"title,omitempty"`
+	Type                 any            `json:"type,omitempty"` // A string, or a []string
+	Format               string         `json:"format,omitempty"`
+	Pattern              string         `json:"pattern,omitempty"`
+	Enum                 []string       `json:"enum,omitempty"`
+	Minimum              *int64         `json:"minimum,omitempty"`
+	Maximum              *int64         `json:"maximum,omitempty"`
+	Items                *jsonSchema    `json:"items,omitempty"`
+	MinItems             *int           `json:"minItems,omitempty"`
+	MaxItems             *int           `json:"maxItems,omitempty"`
+	Properties           jsonProperties `json:"properties,omitempty"`
+	Required             []string       `json:"required,omitempty"`
+	AdditionalProperties *bool          `json:"additionalProperties,omitempty"`
+}
+
+// jsonProperties are the properties of an object's jsonSchema, in the order of its struct fields.
+type jsonProperties []jsonProperty
+
+type jsonProperty struct {
+	name   string
+	schema *jsonSchema
+}
+
+// MarshalJSON encodes p as a JSON object whose members are in the order of p.
+func (p jsonProperties) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, prop := range p {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		name, _ := json.Marshal(prop.name)
+		schema, err := json.Marshal(prop.schema)
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(name)
+		b.WriteByte(':')
+		b.Write(schema)
+	}
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
+
+// enumValuer is implemented by enums whose enumer-generated code includes a Values method.
+type enumValuer interface {
+	Values() []string
+}
+
+var enumValuerType = reflect.TypeFor[enumValuer]()
+
+// schemaFor returns the jsonSchema of the JSON encoding of a value of type t.
+func schemaFor(t reflect.Type) (*jsonSchema, error) {
+	const frac = `(\.[0-9]{1,15})?` // The decimals of a coordinate's minutes
+	const timePattern = `^[0-9]{6}(\.[0-9]{1,3})?$`
+	nullable := func(typ string) []string { return []string{typ, "null"} }
+
+	switch t {
+	case nmeaTimeType:
+		return &jsonSchema{Type: "string", Pattern: timePattern}, nil
+	case timeType:
+		return &jsonSchema{Type: nullable("string"), Pattern: timePattern}, nil
+	case nmeaDateType:
+		return &jsonSchema{Type: nullable("string"), Format: "date"}, nil
+	case latitudeType:
+		return &jsonSchema{Type: nullable("string"), Pattern: `^[0-9]{2,5}` + frac + `,[NS]$`}, nil
+	case longitudeType:
+		return &jsonSchema{Type: nullable("string"), Pattern: `^[0-9]{2,5}` + frac + `,[EW]$`}, nil
+	case float32Type, float64Type:
+		return &jsonSchema{Type: nullable("number")}, nil
+	case intType:
+		return &jsonSchema{Type: nullable("integer")}, nil
+	case startDelimiterType:
+		return &jsonSchema{Type: "string", Enum: []string{"$", "!"}}, nil
+	}
+
+	if t.Implements(enumValuerType) {
+		values := reflect.Zero(t).Interface().(enumValuer).Values()
+
+		return &jsonSchema{Type: "string", Enum: values}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &jsonSchema{Type: "string"}, nil
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		bits := t.Bits() - 1
+		lo, hi := int64(math.MinInt64)>>(63-bits), int64(math.MaxInt64)>>(63-bits)
+
+		return &jsonSchema{Type: "integer", Minimum: &lo, Maximum: &hi}, nil
+	case reflect.Int, reflect.Int64:
+		return &jsonSchema{Type: "integer"}, nil
+	case reflect.Array:
+		items, err := schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		n := t.Len()
+
+		return &jsonSchema{Type: "array", Items: items, MinItems: &n, MaxItems: &n}, nil
+	case reflect.Slice: // A nil slice is encoded as null
+		items, err := schemaFor(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		return &jsonSchema{Type: nullable("array"), Items: items}, nil
+	case reflect.Pointer:
+		return schemaFor(t.Elem())
+	case reflect.Struct:
+		return structSchemaFor(t)
+	}
+
+	return nil, fmt.Errorf("%s has no JSON Schema", t)
+}
+
+// structSchemaFor returns the jsonSchema of the JSON encoding of a struct of type t. A field
+// whose json struct tag does not have the omitzero (or omitempty) option is required.
+func structSchemaFor(t reflect.Type) (*jsonSchema, error) {
+	closed := false
+	schema := &jsonSchema{
+		Type:                 "object",
+		Properties:           jsonProperties{},
+		AdditionalProperties: &closed,
+	}
+
+	for f := range t.Fields() {
+		tag := f.Tag.Get("json")
+		if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct {
+			embedded, err := structSchemaFor(f.Type) // Its fields are promoted, as by encoding/json
+			if err != nil {
+				return nil, err
+			}
+
+			schema.Properties = append(schema.Properties, embedded.Properties...)
+			schema.Required = append(schema.Required, embedded.Required...)
+
+			continue
+		}
+
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+
+		fs, err := schemaFor(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s of %s: %w", f.Name, t, err)
+		}
+
+		schema.Properties = append(schema.Properties, jsonProperty{name, fs})
+		if !strings.Contains(","+opts+",", ",omitzero,") &&
+			!strings.Contains(","+opts+",", ",omitempty,") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema, nil
+}
@@ -0,0 +1,76 @@
+package sentence_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mab-go/nmea/nmeatest"
+	"github.com/mab-go/nmea/sentence"
+	_ "github.com/mab-go/nmea/sentence/gga"
+	_ "github.com/mab-go/nmea/sentence/gll"
+	_ "github.com/mab-go/nmea/sentence/gsa"
+)
+
+// lossyRoundTrips lists, by title, the good-data samples that the sentence format cannot
+// re-encode byte-for-byte, and why. They must still survive a decode -> MarshalNMEA -> decode
+// round trip unchanged.
+var lossyRoundTrips = map[string]string{
+	"GPGGA from Magellan 315 (Simulation Mode)": "Float32 altitudes are encoded canonically (\"-0047\" as \"-47.0\")",
+	"GPGGA from Raytheon RN300":                 "the trailing non-standard field is not decoded",
+}
+
+// TestMarshaler_roundTrips verifies that every sentence in the good-data corpus (a *RawSentence if
+// its type is not supported) is re-encoded by MarshalNMEA exactly as it was received or, if it is
+// listed in lossyRoundTrips, survives a decode -> MarshalNMEA -> decode round trip unchanged.
+func TestMarshaler_roundTrips(t *testing.T) {
+	for _, d := range nmeatest.Good() {
+		t.Run(d.Title, func(t *testing.T) {
+			decoded, err := sentence.Parse(d.Sentence)
+			if err != nil {
+				t.Fatalf("sentence.Parse failed: %v", err)
+			}
+
+			m, ok := decoded.(sentence.Marshaler)
+			if !ok {
+				t.Fatalf("%T does not implement sentence.Marshaler", decoded)
+			}
+
+			encoded, err := m.MarshalNMEA()
+			if err != nil {
+				t.Fatalf("MarshalNMEA failed: %v", err)
+			}
+
+			if _, lossy := lossyRoundTrips[d.Title]; !lossy {
+				if encoded != d.Sentence {
+					t.Errorf("MarshalNMEA should have returned %q but returned %q", d.Sentence, encoded)
+				}
+
+				return
+			}
+
+			redecoded, err := sentence.Parse(encoded)
+			if err != nil {
+				t.Fatalf("error re-parsing %q: %v", encoded, err)
+			}
+
+			if !reflect.DeepEqual(decoded, redecoded) {
+				t.Errorf("round trip through %q should have produced %+v but produced %+v", encoded, decoded, redecoded)
+			}
+		})
+	}
+}
+
+// TestMarshaler_lossyRoundTripsExist verifies that every entry of lossyRoundTrips names a sample
+// of the good-data corpus, so that the list cannot silently outlive the samples it excuses.
+func TestMarshaler_lossyRoundTripsExist(t *testing.T) {
+	titles := make(map[string]bool)
+	for _, d := range nmeatest.Good() {
+		titles[d.Title] = true
+	}
+
+	for title := range lossyRoundTrips {
+		if !titles[title] {
+			t.Errorf("lossyRoundTrips names %q, which is not a good-data sample", title)
+		}
+	}
+}
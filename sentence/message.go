@@ -0,0 +1,67 @@
+package sentence
+
+import "time"
+
+// --- Public ------------------------------------------------------------------
+
+// Message is a sentence together with the circumstances of its receipt: where and when it
+// arrived, and whether it could be decoded. Scanner.Message returns one for each sentence read
+// from a stream, and NewMessage makes one for a sentence received by other means (e.g. replayed
+// from a log), so that processing, logging and replay can share one record of each sentence.
+type Message struct {
+	// Sentence is the decoded sentence: the sentence package's own type (e.g. *gga.GGA), a
+	// *RawSentence if its type is unknown (see Parse), or nil if it could not be decoded.
+	Sentence NMEASentence
+
+	// Raw is the sentence as it was received, including its tag block but not its line
+	// terminator.
+	Raw string
+
+	// ReceivedAt is the time at which the sentence was received.
+	ReceivedAt time.Time
+
+	// Source identifies where the sentence was received from (e.g. "/dev/ttyUSB0" or
+	// "udp:10110"): the Scanner's Source or, if it has none, the source ("s") parameter of the
+	// sentence's tag block. It is empty if neither is known.
+	Source string
+
+	// TagBlock is the tag block (see [TagBlock]) that preceded the sentence, or nil if there was
+	// none or it was malformed.
+	TagBlock *TagBlock
+
+	// Err is the error, if any, with which the sentence failed to be framed, verified or decoded.
+	// It is nil for a sentence of an unknown type, which is decoded as a *RawSentence.
+	Err error
+}
+
+// NewMessage parses and decodes raw, a sentence (with its tag block, if any) that was received at
+// receivedAt from source, with Parse, and returns it as a Message. Problems with the sentence are
+// recorded in the Message's Err.
+func NewMessage(raw string, receivedAt time.Time, source string) Message {
+	tb, _, err := SplitTagBlock(raw)
+	m := Message{
+		Raw:        raw,
+		ReceivedAt: receivedAt,
+		Source:     messageSource(source, tb),
+		TagBlock:   tb,
+		Err:        err,
+	}
+
+	if err == nil {
+		m.Sentence, m.Err = Parse(raw)
+	}
+
+	return m
+}
+
+// --- Private -----------------------------------------------------------------
+
+// messageSource returns the Source of a Message that was received from source and that has the
+// tag block tb (see Message.Source).
+func messageSource(source string, tb *TagBlock) string {
+	if source == "" && tb != nil {
+		return tb.Source
+	}
+
+	return source
+}
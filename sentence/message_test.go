@@ -0,0 +1,52 @@
+package sentence
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewMessage(t *testing.T) {
+	receivedAt := time.Date(2026, 3, 1, 18, 37, 30, 0, time.UTC)
+
+	t.Run("Decoded", func(t *testing.T) {
+		m := NewMessage("$GPTST,183730*66", receivedAt, "udp:10110")
+		if m.Err != nil {
+			t.Fatalf("expected no error but got %v", m.Err)
+		}
+
+		ts, ok := m.Sentence.(*testSentence)
+		if !ok || ts.FixTime != (NMEATime{Hour: 18, Minute: 37, Second: 30, FractionDigits: NoFractionDigits}) {
+			t.Errorf("expected a decoded *testSentence but was %+v", m.Sentence)
+		}
+
+		if m.Raw != "$GPTST,183730*66" || !m.ReceivedAt.Equal(receivedAt) ||
+			m.Source != "udp:10110" || m.TagBlock != nil {
+			t.Errorf("unexpected message %+v", m)
+		}
+	})
+
+	t.Run("Source From Tag Block", func(t *testing.T) {
+		m := NewMessage(`\s:r3669961*0F\$GPTST,183730*66`, receivedAt, "")
+		if m.Err != nil || m.Source != "r3669961" || m.TagBlock == nil {
+			t.Errorf("expected the tag block's source but was %+v", m)
+		}
+
+		if m := NewMessage(`\s:r3669961*0F\$GPTST,183730*66`, receivedAt, "log"); m.Source != "log" {
+			t.Errorf("expected source %q to take precedence but was %q", "log", m.Source)
+		}
+	})
+
+	t.Run("Unknown Type", func(t *testing.T) {
+		m := NewMessage("$GPXYZ,1,2*4F", receivedAt, "")
+		if _, ok := m.Sentence.(*RawSentence); !ok || m.Err != nil {
+			t.Errorf("expected a *RawSentence and no error but was %+v", m)
+		}
+	})
+
+	t.Run("Malformed Tag Block", func(t *testing.T) {
+		m := NewMessage(`\s:r3669961*00\$GPTST,183730*66`, receivedAt, "")
+		if m.Err == nil || m.Sentence != nil || m.TagBlock != nil {
+			t.Errorf("expected a tag block error but was %+v", m)
+		}
+	})
+}
@@ -0,0 +1,232 @@
+package sentence
+
+import (
+	"fmt"
+	"time"
+)
+
+// --- Public ------------------------------------------------------------------
+
+// NMEADate represents a calendar date as reported in an NMEA sentence (UTC for GPS). It is sent
+// either as a single ddmmyy segment (e.g. "230394" in RMC), whose two-digit year is resolved to a
+// century by a pivot (see ParseOptions.CenturyPivot), or as separate day, month and four-digit
+// year segments (e.g. "23,03,1994" in ZDA). Parsing rules match [SegmentParser.AsNMEADate] and
+// [SegmentParser.AsNMEADateDMY].
+//
+// The zero NMEADate represents an empty (unknown) date.
+type NMEADate struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+// DefaultCenturyPivot is the default ParseOptions.CenturyPivot: the two-digit years 80 to 99 are
+// 1980 to 1999 (GPS time began in 1980), and 00 to 79 are 2000 to 2079.
+const DefaultCenturyPivot = 80
+
+// String returns the ddmmyy wire encoding of d (e.g. "230394"), or "" if d is the zero NMEADate.
+func (d NMEADate) String() string {
+	if d == (NMEADate{}) {
+		return ""
+	}
+
+	return fmt.Sprintf("%02d%02d%02d", d.Day, d.Month, d.Year%100)
+}
+
+// DateTime combines d and t into a time.Time in UTC. It returns an error if d is the zero
+// NMEADate or is not a valid date.
+//
+// A time.Time cannot represent a leap second, so 23:59:60.sss (which NMEATime accepts) becomes
+// 00:00:00.sss of the following day, as in POSIX time.
+func DateTime(d NMEADate, t NMEATime) (time.Time, error) {
+	if d == (NMEADate{}) {
+		return time.Time{}, fmt.Errorf("the date is empty")
+	}
+
+	if err := validateNMEADate(d); err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Date(d.Year, time.Month(d.Month), d.Day, t.Hour, t.Minute, t.Second,
+		t.Millisecond*int(time.Millisecond), time.UTC), nil
+}
+
+// SplitDateTime returns the NMEADate and NMEATime of tm in UTC, truncated to the millisecond. It
+// is the inverse of DateTime, for use when encoding sentences.
+func SplitDateTime(tm time.Time) (NMEADate, NMEATime) {
+	tm = tm.UTC()
+
+	return NMEADate{Year: tm.Year(), Month: int(tm.Month()), Day: tm.Day()},
+		NMEATime{
+			Hour:        tm.Hour(),
+			Minute:      tm.Minute(),
+			Second:      tm.Second(),
+			Millisecond: tm.Nanosecond() / int(time.Millisecond),
+		}
+}
+
+// AsNMEADate parses the sentence segment at the specified index as an NMEADate in the ddmmyy
+// format, resolving its two-digit year with ParseOptions.CenturyPivot. If p.Err() is not nil,
+// this function returns NMEADate{} and leaves the error unchanged. An empty segment returns
+// NMEADate{} with no error.
+func (p *SegmentParser) AsNMEADate(i int8) NMEADate {
+	v, ok := p.Segment(i)
+	if !ok || v == "" {
+		return NMEADate{}
+	}
+
+	d, err := parseNMEADate(v, p.options.CenturyPivot)
+	if err != nil {
+		p.fail(&FieldError{
+			Segment:  i,
+			Expected: "NMEADate",
+			Message:  fmt.Sprintf("must be parsable as an NMEADate (ddmmyy) but was \"%s\"", v),
+		})
+
+		return NMEADate{}
+	}
+
+	return d
+}
+
+// AsNMEADateDMY parses the sentence segments at the specified index and the two that follow it
+// as the day, month and four-digit year of an NMEADate (e.g. "23,03,1994" in ZDA). If p.Err() is
+// not nil, this function returns NMEADate{} and leaves the error unchanged. If all three segments
+// are empty, it returns NMEADate{} with no error.
+func (p *SegmentParser) AsNMEADateDMY(i int8) NMEADate {
+	day, ok := p.Segment(i)
+	if !ok {
+		return NMEADate{}
+	}
+
+	month, ok := p.Segment(i + 1)
+	if !ok {
+		return NMEADate{}
+	}
+
+	year, ok := p.Segment(i + 2)
+	if !ok || day == "" && month == "" && year == "" {
+		return NMEADate{}
+	}
+
+	for j, s := range []string{day, month, year} {
+		if width := 2 + 2*(j/2); len(s) != width || !isDigits(s) {
+			p.failNMEADateDMY(i+int8(j), s)
+
+			return NMEADate{}
+		}
+	}
+
+	d := NMEADate{
+		Year:  int(digitsValue(year)),
+		Month: int(digitsValue(month)),
+		Day:   int(digitsValue(day)),
+	}
+	if err := validateNMEADate(d); err != nil {
+		segment := i // The day is out of range for the month
+		if d.Month < 1 || d.Month > 12 {
+			segment = i + 1
+		}
+
+		p.failNMEADateDMY(segment, []string{day, month}[segment-i])
+
+		return NMEADate{}
+	}
+
+	return d
+}
+
+// WriteNMEADate appends d as the next segment in the ddmmyy format (e.g. "230394"), or an empty
+// segment if d is the zero NMEADate. The century of d's year is not encoded.
+func (w *SegmentWriter) WriteNMEADate(d NMEADate) {
+	if w.err != nil {
+		return
+	}
+
+	if d != (NMEADate{}) && validateNMEADate(d) != nil {
+		w.setErr(fmt.Sprintf("must be a valid NMEADate but was %+v", d))
+
+		return
+	}
+
+	w.append(d.String())
+}
+
+// WriteNMEADateDMY appends the day, month and four-digit year of d as the next three segments
+// (e.g. "23", "03" and "1994"), or three empty segments if d is the zero NMEADate.
+func (w *SegmentWriter) WriteNMEADateDMY(d NMEADate) {
+	if w.err != nil {
+		return
+	}
+
+	if d == (NMEADate{}) {
+		w.append("")
+		w.append("")
+		w.append("")
+
+		return
+	}
+
+	if d.Year < 0 || d.Year > 9999 || validateNMEADate(d) != nil {
+		w.setErr(fmt.Sprintf("must be a valid NMEADate but was %+v", d))
+
+		return
+	}
+
+	w.append(fmt.Sprintf("%02d", d.Day))
+	w.append(fmt.Sprintf("%02d", d.Month))
+	w.append(fmt.Sprintf("%04d", d.Year))
+}
+
+// --- Private -----------------------------------------------------------------
+
+// parseNMEADate parses a raw NMEA date segment string (format: ddmmyy) into an NMEADate, in the
+// century given by pivot (see ParseOptions.CenturyPivot). It does not allocate.
+func parseNMEADate(s string, pivot int) (NMEADate, error) {
+	if len(s) != 6 || !isDigits(s) {
+		return NMEADate{}, fmt.Errorf("must be 6 digits")
+	}
+
+	if pivot <= 0 || pivot > 100 {
+		pivot = DefaultCenturyPivot
+	}
+
+	year := int(digitsValue(s[4:6]))
+	if year < pivot {
+		year += 2000
+	} else {
+		year += 1900
+	}
+
+	d := NMEADate{Year: year, Month: int(digitsValue(s[2:4])), Day: int(digitsValue(s[0:2]))}
+	if err := validateNMEADate(d); err != nil {
+		return NMEADate{}, err
+	}
+
+	return d, nil
+}
+
+// validateNMEADate checks that d is a valid date of the Gregorian calendar.
+func validateNMEADate(d NMEADate) error {
+	if d.Month < 1 || d.Month > 12 {
+		return fmt.Errorf("month %d out of range [1, 12]", d.Month)
+	}
+
+	// The day before the first of the next month is the last day of this one
+	last := time.Date(d.Year, time.Month(d.Month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if d.Day < 1 || d.Day > last {
+		return fmt.Errorf("day %d out of range [1, %d]", d.Day, last)
+	}
+
+	return nil
+}
+
+// failNMEADateDMY records that the segment at index i, which holds value s, is not a valid day,
+// month or year of an NMEADate.
+func (p *SegmentParser) failNMEADateDMY(i int8, s string) {
+	p.fail(&FieldError{
+		Segment:  i,
+		Expected: "NMEADate",
+		Message:  fmt.Sprintf("must be part of a valid NMEADate (dd,mm,yyyy) but was \"%s\"", s),
+	})
+}
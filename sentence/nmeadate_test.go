@@ -0,0 +1,173 @@
+package sentence
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSegmentParser_AsNMEADate(t *testing.T) {
+	for _, vec := range []struct {
+		input    string
+		opts     []ParseOption
+		expected NMEADate
+	}{
+		{input: "230394", expected: NMEADate{Year: 1994, Month: 3, Day: 23}},
+		{input: "010180", expected: NMEADate{Year: 1980, Month: 1, Day: 1}},
+		{input: "311279", expected: NMEADate{Year: 2079, Month: 12, Day: 31}},
+		{input: "290224", expected: NMEADate{Year: 2024, Month: 2, Day: 29}},
+		{
+			input:    "230394",
+			opts:     []ParseOption{WithCenturyPivot(95)},
+			expected: NMEADate{Year: 2094, Month: 3, Day: 23},
+		},
+		{input: "", expected: NMEADate{}},
+	} {
+		p := &SegmentParser{}
+		opts := append([]ParseOption{WithChecksumOptional()}, vec.opts...)
+		if err := p.ParseWithOptions("$GPRMC,"+vec.input, opts...); err != nil {
+			t.Fatalf("failed to parse sentence: %v", err)
+		}
+
+		if actual := p.AsNMEADate(1); actual != vec.expected || p.Err() != nil {
+			t.Errorf("expected %q to be %+v but was %+v (%v)", vec.input, vec.expected, actual, p.Err())
+		}
+
+		if actual := vec.expected.String(); actual != vec.input {
+			t.Errorf("expected %+v to be encoded as %q but was %q", vec.expected, vec.input, actual)
+		}
+	}
+}
+
+func TestSegmentParser_AsNMEADate_errors(t *testing.T) {
+	for _, input := range []string{"23039", "2303945", "23O394", "320394", "001294", "231394",
+		"290223", "-10394"} {
+		p := &SegmentParser{}
+		if err := p.ParseWithOptions("$GPRMC,"+input, WithChecksumOptional()); err != nil {
+			t.Fatalf("failed to parse sentence: %v", err)
+		}
+
+		if actual := p.AsNMEADate(1); actual != (NMEADate{}) {
+			t.Errorf("expected a zero NMEADate for %q but was %+v", input, actual)
+		}
+
+		expected := "sentence segment [1] must be parsable as an NMEADate (ddmmyy) but was \"" +
+			input + "\""
+		if err := p.Err(); err == nil || err.Error() != expected {
+			t.Errorf("expected error %q but was %v", expected, err)
+		}
+	}
+}
+
+func TestSegmentParser_AsNMEADateDMY(t *testing.T) {
+	p := &SegmentParser{}
+	if err := p.ParseWithOptions("$GPZDA,201530.00,04,07,2002,00,00,,,",
+		WithChecksumOptional()); err != nil {
+		t.Fatalf("failed to parse sentence: %v", err)
+	}
+
+	expected := NMEADate{Year: 2002, Month: 7, Day: 4}
+	if actual := p.AsNMEADateDMY(2); actual != expected || p.Err() != nil {
+		t.Errorf("expected %+v but was %+v (%v)", expected, actual, p.Err())
+	}
+
+	if actual := p.AsNMEADateDMY(7); actual != (NMEADate{}) || p.Err() != nil {
+		t.Errorf("expected an empty date but was %+v (%v)", actual, p.Err())
+	}
+}
+
+func TestSegmentParser_AsNMEADateDMY_errors(t *testing.T) {
+	for segments, errMsg := range map[string]string{
+		"4,07,2002":  "sentence segment [1] must be part of a valid NMEADate (dd,mm,yyyy) but was \"4\"",
+		"04,,2002":   "sentence segment [2] must be part of a valid NMEADate (dd,mm,yyyy) but was \"\"",
+		"04,07,02":   "sentence segment [3] must be part of a valid NMEADate (dd,mm,yyyy) but was \"02\"",
+		"04,13,2002": "sentence segment [2] must be part of a valid NMEADate (dd,mm,yyyy) but was \"13\"",
+		"31,04,2002": "sentence segment [1] must be part of a valid NMEADate (dd,mm,yyyy) but was \"31\"",
+		"04,07":      "sentence segment [3] is out of range",
+	} {
+		p := &SegmentParser{}
+		if err := p.ParseWithOptions("$GPZDA,"+segments, WithChecksumOptional()); err != nil {
+			t.Fatalf("failed to parse sentence: %v", err)
+		}
+
+		if actual := p.AsNMEADateDMY(1); actual != (NMEADate{}) {
+			t.Errorf("expected a zero NMEADate for %q but was %+v", segments, actual)
+		}
+
+		if err := p.Err(); err == nil || err.Error() != errMsg {
+			t.Errorf("expected error %q but was %v", errMsg, err)
+		}
+	}
+}
+
+func TestDateTime(t *testing.T) {
+	d := NMEADate{Year: 1994, Month: 3, Day: 23}
+	actual, err := DateTime(d, NMEATime{Hour: 12, Minute: 35, Second: 19, Millisecond: 250})
+	if err != nil {
+		t.Fatalf("DateTime failed: %v", err)
+	}
+
+	expected := time.Date(1994, time.March, 23, 12, 35, 19, 250*int(time.Millisecond), time.UTC)
+	if !actual.Equal(expected) || actual.Location() != time.UTC {
+		t.Errorf("expected %v but was %v", expected, actual)
+	}
+
+	if d2, t2 := SplitDateTime(actual.In(time.FixedZone("X", 3600))); d2 != d ||
+		t2 != (NMEATime{Hour: 12, Minute: 35, Second: 19, Millisecond: 250}) {
+		t.Errorf("expected SplitDateTime to reverse DateTime but was %+v, %+v", d2, t2)
+	}
+}
+
+func TestDateTime_leapSecond(t *testing.T) {
+	actual, err := DateTime(NMEADate{Year: 2016, Month: 12, Day: 31},
+		NMEATime{Hour: 23, Minute: 59, Second: 60, Millisecond: 500})
+	if err != nil {
+		t.Fatalf("DateTime failed: %v", err)
+	}
+
+	expected := time.Date(2017, time.January, 1, 0, 0, 0, 500*int(time.Millisecond), time.UTC)
+	if !actual.Equal(expected) {
+		t.Errorf("expected the leap second to become %v but was %v", expected, actual)
+	}
+}
+
+func TestDateTime_errors(t *testing.T) {
+	for _, d := range []NMEADate{{}, {Year: 2023, Month: 2, Day: 29}, {Year: 2023, Month: 0, Day: 1}} {
+		if _, err := DateTime(d, NMEATime{}); err == nil {
+			t.Errorf("expected an error for %+v", d)
+		}
+	}
+}
+
+func TestSegmentWriter_WriteNMEADate(t *testing.T) {
+	w := &SegmentWriter{}
+	w.WriteNMEADate(NMEADate{Year: 2002, Month: 7, Day: 4})
+	w.WriteNMEADate(NMEADate{})
+	w.WriteNMEADateDMY(NMEADate{Year: 2002, Month: 7, Day: 4})
+	w.WriteNMEADateDMY(NMEADate{})
+
+	expected := []string{"040702", "", "04", "07", "2002", "", "", ""}
+	if len(w.segments) != len(expected) {
+		t.Fatalf("expected segments %q but were %q", expected, w.segments)
+	}
+
+	for i := range expected {
+		if w.segments[i] != expected[i] {
+			t.Fatalf("expected segments %q but were %q", expected, w.segments)
+		}
+	}
+
+	for _, write := range []func(w *SegmentWriter){
+		func(w *SegmentWriter) { w.WriteNMEADate(NMEADate{Year: 2002, Month: 2, Day: 30}) },
+		func(w *SegmentWriter) { w.WriteNMEADateDMY(NMEADate{Year: 12002, Month: 7, Day: 4}) },
+	} {
+		w := &SegmentWriter{}
+		w.WriteString("GPZDA")
+		write(w)
+
+		var encErr *EncodingError
+		if !errors.As(w.Err(), &encErr) || encErr.Segment != 1 {
+			t.Errorf("expected an *EncodingError for segment [1] but was %v", w.Err())
+		}
+	}
+}
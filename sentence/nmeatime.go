@@ -16,34 +16,54 @@ type NMEATime struct {
 	Minute      int
 	Second      int
 	Millisecond int
+
+	// FractionDigits is the number of fractional digits of the seconds as they were written: 1
+	// or 2 (e.g. 2 for "092725.00"), or NoFractionDigits if there were none (e.g. "183730"). It
+	// is 0, which writes the usual three digits, for a time written with three or more.
+	FractionDigits int
 }
 
-// String returns the canonical wire encoding of t: two digits each for hour, minute, and second,
-// a period, and exactly three fractional digits (e.g. "174800.864"). Inputs that omit the
-// fractional part parse as millisecond zero; String always emits ".sss", so serialization
-// normalizes that case to ".000".
+// NoFractionDigits is the NMEATime.FractionDigits of a time written without fractional seconds.
+const NoFractionDigits = -1
+
+// String returns the wire encoding of t: two digits each for hour, minute, and second, then a
+// period and the fractional seconds, with as many digits as t.FractionDigits calls for (e.g.
+// "174800.864", "092725.00" or "183730"), so that a parsed time is written as it was received.
+// Millisecond is truncated to that many digits. A time whose fractional part was empty or longer
+// than three digits (e.g. "174800." or "174800.86499") is written with exactly three.
 //
 // The 10-character shape (before any wider hour field) applies to values produced by successful
 // parsing via [SegmentParser.AsNMEATime]; manually populated structs are not re-validated here.
 func (t NMEATime) String() string {
-	return fmt.Sprintf("%02d%02d%02d.%03d", t.Hour, t.Minute, t.Second, t.Millisecond)
+	s := fmt.Sprintf("%02d%02d%02d", t.Hour, t.Minute, t.Second)
+	switch t.FractionDigits {
+	case NoFractionDigits:
+		return s
+	case 1:
+		return s + fmt.Sprintf(".%01d", t.Millisecond/100)
+	case 2:
+		return s + fmt.Sprintf(".%02d", t.Millisecond/10)
+	default:
+		return s + fmt.Sprintf(".%03d", t.Millisecond)
+	}
 }
 
 // parseNMEATime parses a raw NMEA time segment string (format: (h)hmmss[.s[s[s]]]) into an
 // NMEATime without any floating-point conversion. The integer part must be 4–6 digits. If more
-// than three fractional digits are present, the remainder is truncated (not rounded).
+// than three fractional digits are present, the remainder is truncated (not rounded). The number
+// of fractional digits is recorded in FractionDigits.
 func parseNMEATime(s string) (NMEATime, error) {
-	parts := strings.Split(s, ".")
-	if len(parts) > 2 {
+	intPart, fracPart, hasFrac := strings.Cut(s, ".")
+	if strings.Contains(fracPart, ".") {
 		return NMEATime{}, fmt.Errorf("too many decimal points")
 	}
 
-	hour, minute, second, err := parseNMEATimeIntPart(parts[0])
+	hour, minute, second, err := parseNMEATimeIntPart(intPart)
 	if err != nil {
 		return NMEATime{}, err
 	}
 
-	ms, err := parseNMEATimeFracPart(parts)
+	ms, err := parseNMEATimeFracPart(fracPart, hasFrac)
 	if err != nil {
 		return NMEATime{}, err
 	}
@@ -52,7 +72,15 @@ func parseNMEATime(s string) (NMEATime, error) {
 		return NMEATime{}, err
 	}
 
-	return NMEATime{Hour: hour, Minute: minute, Second: second, Millisecond: ms}, nil
+	t := NMEATime{Hour: hour, Minute: minute, Second: second, Millisecond: ms}
+	switch {
+	case !hasFrac:
+		t.FractionDigits = NoFractionDigits
+	case len(fracPart) == 1 || len(fracPart) == 2:
+		t.FractionDigits = len(fracPart)
+	}
+
+	return t, nil
 }
 
 // parseNMEATimeIntPart extracts hour, minute, and second from the integer portion of an NMEA time
@@ -87,24 +115,23 @@ func parseNMEATimeIntPart(intPart string) (hour, minute, second int, err error)
 	return hour, minute, second, nil
 }
 
-// parseNMEATimeFracPart extracts milliseconds from the split parts of an NMEA time string. If no
-// fractional part is present (len(parts) == 1), it returns 0. If the fractional run is longer than
-// three digits, only the first three are used; the rest are ignored.
-func parseNMEATimeFracPart(parts []string) (int, error) {
-	if len(parts) < 2 {
+// parseNMEATimeFracPart parses the fractional seconds of an NMEA time as milliseconds, as if
+// fracPart were padded with zeros to (at least) three digits. It does not allocate.
+func parseNMEATimeFracPart(fracPart string, hasFrac bool) (int, error) {
+	if !hasFrac || fracPart == "" {
 		return 0, nil
 	}
 
-	fracStr := parts[1]
-	for len(fracStr) < 3 {
-		fracStr += "0"
-	}
-
-	ms, err := strconv.Atoi(fracStr[:3])
+	digits := min(len(fracPart), 3)
+	ms, err := strconv.Atoi(fracPart[:digits])
 	if err != nil {
 		return 0, fmt.Errorf("invalid fractional seconds")
 	}
 
+	for ; digits < 3; digits++ {
+		ms *= 10
+	}
+
 	return ms, nil
 }
 
@@ -34,28 +34,28 @@ var validNMEATimeInputs = []nmeatimeVec{
 	},
 	{
 		input:    "002454",
-		expected: NMEATime{Hour: 0, Minute: 24, Second: 54, Millisecond: 0},
-		canon:    "002454.000",
+		expected: NMEATime{Hour: 0, Minute: 24, Second: 54, Millisecond: 0, FractionDigits: NoFractionDigits},
+		canon:    "002454",
 	},
 	{
 		input:    "2454",
-		expected: NMEATime{Hour: 0, Minute: 24, Second: 54, Millisecond: 0},
-		canon:    "002454.000",
+		expected: NMEATime{Hour: 0, Minute: 24, Second: 54, Millisecond: 0, FractionDigits: NoFractionDigits},
+		canon:    "002454",
 	},
 	{
 		input:    "183730",
-		expected: NMEATime{Hour: 18, Minute: 37, Second: 30, Millisecond: 0},
-		canon:    "183730.000",
+		expected: NMEATime{Hour: 18, Minute: 37, Second: 30, Millisecond: 0, FractionDigits: NoFractionDigits},
+		canon:    "183730",
 	},
 	{
 		input:    "30000.5",
-		expected: NMEATime{Hour: 3, Minute: 0, Second: 0, Millisecond: 500},
-		canon:    "030000.500",
+		expected: NMEATime{Hour: 3, Minute: 0, Second: 0, Millisecond: 500, FractionDigits: 1},
+		canon:    "030000.5",
 	},
 	{
 		input:    "30000.05",
-		expected: NMEATime{Hour: 3, Minute: 0, Second: 0, Millisecond: 50},
-		canon:    "030000.050",
+		expected: NMEATime{Hour: 3, Minute: 0, Second: 0, Millisecond: 50, FractionDigits: 2},
+		canon:    "030000.05",
 	},
 	{
 		input:    "235960.000",
@@ -181,7 +181,7 @@ func TestValidateNMEATimeFields_millisecondOver999(t *testing.T) {
 func TestSegmentParser_AsNMEATime(t *testing.T) {
 	t.Run("Good Data", func(t *testing.T) {
 		p := mustParse(t)
-		expected := NMEATime{Hour: 18, Minute: 37, Second: 30, Millisecond: 0}
+		expected := NMEATime{Hour: 18, Minute: 37, Second: 30, FractionDigits: NoFractionDigits}
 		actual := p.AsNMEATime(1) // segment [1] = "183730"
 		if actual != expected {
 			t.Errorf("expected %+v but got %+v", expected, actual)
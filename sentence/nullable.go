@@ -0,0 +1,51 @@
+package sentence
+
+// --- Public ------------------------------------------------------------------
+
+// Float32 represents a float32 sentence field that may be empty. Valid is false if the field was
+// empty, which distinguishes a missing value from a reported value of 0.
+type Float32 struct {
+	Float32 float32
+	Valid   bool
+}
+
+// NewFloat32 returns a valid (non-empty) Float32 holding v.
+func NewFloat32(v float32) Float32 {
+	return Float32{Float32: v, Valid: true}
+}
+
+// Float64 represents a float64 sentence field that may be empty. Valid is false if the field was
+// empty, which distinguishes a missing value from a reported value of 0.
+type Float64 struct {
+	Float64 float64
+	Valid   bool
+}
+
+// NewFloat64 returns a valid (non-empty) Float64 holding v.
+func NewFloat64(v float64) Float64 {
+	return Float64{Float64: v, Valid: true}
+}
+
+// Int represents an integer sentence field that may be empty. Valid is false if the field was
+// empty, which distinguishes a missing value from a reported value of 0.
+type Int struct {
+	Int   int64
+	Valid bool
+}
+
+// NewInt returns a valid (non-empty) Int holding v.
+func NewInt(v int64) Int {
+	return Int{Int: v, Valid: true}
+}
+
+// Time represents an NMEATime sentence field that may be empty. Valid is false if the field was
+// empty, which distinguishes a missing time from midnight ("000000.000").
+type Time struct {
+	NMEATime NMEATime
+	Valid    bool
+}
+
+// NewTime returns a valid (non-empty) Time holding t.
+func NewTime(t NMEATime) Time {
+	return Time{NMEATime: t, Valid: true}
+}
@@ -0,0 +1,126 @@
+package sentence
+
+// --- Public ------------------------------------------------------------------
+
+// ParseOptions controls how strictly a sentence is parsed. Its zero value is the default, strict
+// behaviour of Parse: a checksum is required, no whitespace is trimmed, every segment that a
+// sentence type reads must be present, sentences of any length are accepted, and parsing stops at
+// the first field that fails. Sentence types, talker identifiers and enum values are matched
+// case-insensitively unless CaseSensitive is set.
+//
+// ParseOptions are usually built from ParseOption values (e.g. WithChecksumOptional) passed to a
+// ParseWithOptions function, such as [SegmentParser.ParseWithOptions] or [ParseWithOptions].
+type ParseOptions struct {
+	// ChecksumOptional accepts sentences that have no "*hh" checksum at all. A checksum that is
+	// present is still verified.
+	ChecksumOptional bool
+
+	// TrimSpace removes leading and trailing whitespace from the sentence and from each of its
+	// segments (e.g. "$GPGGA, 183730,..." is read as "$GPGGA,183730,...").
+	TrimSpace bool
+
+	// CaseSensitive requires sentence types and enum values to match their expected case exactly
+	// (e.g. "N" but not "n" for a hemisphere).
+	CaseSensitive bool
+
+	// AllowMissingTrailingFields treats segments missing from the end of a sentence as empty,
+	// rather than reporting them as out of range. This accepts devices that stop sending a
+	// sentence's final (typically optional) fields.
+	AllowMissingTrailingFields bool
+
+	// MaxLength, if greater than 0, is the maximum length of a sentence, including its start
+	// delimiter and its terminating <CR><LF> (which need not be present) but excluding any tag
+	// block. Use MaxSentenceLength to enforce the limit set by the NMEA 0183 standard.
+	MaxLength int
+
+	// CollectErrors keeps parsing a sentence's segments after one of them fails, so that Err (and
+	// thus a Decoder) reports every failing field of the sentence, joined with errors.Join, rather
+	// than only the first. Each joined error is a *FieldError.
+	CollectErrors bool
+
+	// CenturyPivot, if between 1 and 100, is the two-digit year from which a ddmmyy NMEADate is
+	// in the 1900s; earlier two-digit years are in the 2000s. Otherwise DefaultCenturyPivot is
+	// used, so that "230394" is 23 March 1994 and "230324" is 23 March 2024.
+	CenturyPivot int
+}
+
+// ParseOption sets one of the ParseOptions.
+type ParseOption func(*ParseOptions)
+
+// NewParseOptions returns the ParseOptions that result from applying opts, in order, to the
+// (strict) zero value.
+func NewParseOptions(opts ...ParseOption) ParseOptions {
+	var o ParseOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithOptions sets every one of the ParseOptions to the values in o.
+func WithOptions(o ParseOptions) ParseOption {
+	return func(dst *ParseOptions) {
+		*dst = o
+	}
+}
+
+// WithChecksumOptional sets ParseOptions.ChecksumOptional.
+func WithChecksumOptional() ParseOption {
+	return func(o *ParseOptions) {
+		o.ChecksumOptional = true
+	}
+}
+
+// WithTrimSpace sets ParseOptions.TrimSpace.
+func WithTrimSpace() ParseOption {
+	return func(o *ParseOptions) {
+		o.TrimSpace = true
+	}
+}
+
+// WithCaseSensitive sets ParseOptions.CaseSensitive.
+func WithCaseSensitive() ParseOption {
+	return func(o *ParseOptions) {
+		o.CaseSensitive = true
+	}
+}
+
+// WithMissingTrailingFields sets ParseOptions.AllowMissingTrailingFields.
+func WithMissingTrailingFields() ParseOption {
+	return func(o *ParseOptions) {
+		o.AllowMissingTrailingFields = true
+	}
+}
+
+// WithMaxLength sets ParseOptions.MaxLength to n.
+func WithMaxLength(n int) ParseOption {
+	return func(o *ParseOptions) {
+		o.MaxLength = n
+	}
+}
+
+// WithCollectErrors sets ParseOptions.CollectErrors.
+func WithCollectErrors() ParseOption {
+	return func(o *ParseOptions) {
+		o.CollectErrors = true
+	}
+}
+
+// WithCenturyPivot sets ParseOptions.CenturyPivot to pivot.
+func WithCenturyPivot(pivot int) ParseOption {
+	return func(o *ParseOptions) {
+		o.CenturyPivot = pivot
+	}
+}
+
+// Lenient returns a ParseOption that accepts as much as possible from older or non-conforming
+// devices: it makes the checksum optional, trims whitespace and allows missing trailing fields.
+// The other ParseOptions are left unchanged, so Lenient may be combined with other options.
+func Lenient() ParseOption {
+	return func(o *ParseOptions) {
+		o.ChecksumOptional = true
+		o.TrimSpace = true
+		o.AllowMissingTrailingFields = true
+	}
+}
@@ -0,0 +1,58 @@
+package sentence
+
+import "testing"
+
+func TestNewParseOptions(t *testing.T) {
+	for title, vec := range map[string]struct {
+		opts     []ParseOption
+		expected ParseOptions
+	}{
+		"Default": {
+			expected: ParseOptions{},
+		},
+		"Individual Options": {
+			opts: []ParseOption{
+				WithChecksumOptional(),
+				WithTrimSpace(),
+				WithCaseSensitive(),
+				WithMissingTrailingFields(),
+				WithMaxLength(MaxSentenceLength),
+			},
+			expected: ParseOptions{
+				ChecksumOptional:           true,
+				TrimSpace:                  true,
+				CaseSensitive:              true,
+				AllowMissingTrailingFields: true,
+				MaxLength:                  82,
+			},
+		},
+		"Lenient": {
+			opts: []ParseOption{Lenient()},
+			expected: ParseOptions{
+				ChecksumOptional:           true,
+				TrimSpace:                  true,
+				AllowMissingTrailingFields: true,
+			},
+		},
+		"Lenient With Other Options": {
+			opts: []ParseOption{WithCaseSensitive(), Lenient(), WithMaxLength(82)},
+			expected: ParseOptions{
+				ChecksumOptional:           true,
+				TrimSpace:                  true,
+				CaseSensitive:              true,
+				AllowMissingTrailingFields: true,
+				MaxLength:                  82,
+			},
+		},
+		"Later Options Win": {
+			opts:     []ParseOption{WithMaxLength(82), WithOptions(ParseOptions{TrimSpace: true})},
+			expected: ParseOptions{TrimSpace: true},
+		},
+	} {
+		t.Run(title, func(t *testing.T) {
+			if actual := NewParseOptions(vec.opts...); actual != vec.expected {
+				t.Errorf("result should have been %+v but was %+v", vec.expected, actual)
+			}
+		})
+	}
+}
@@ -1,275 +1,680 @@
 package sentence
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"unsafe"
 )
 
 // --- Public ------------------------------------------------------------------
 
 // SegmentParser provides functionality for parsing individual segments of an NMEA sentence.
 type SegmentParser struct {
-	sentence string
-	segments []string
-	err      error
+	sentence  string // The parsed sentence, including its tag block
+	segments  []string
+	delimiter StartDelimiter
+	tagBlock  *TagBlock
+	options   ParseOptions
+	borrowed  bool
+	names     []string
+	err       error
+	errs      []error
 }
 
-// Parse parses the specified NMEA sentence into a series of sentence segments.
+// Parse parses the specified NMEA sentence into a series of sentence segments. The sentence may be
+// either a parametric ("$") or an encapsulation ("!") sentence; see StartDelimiter. It may be
+// preceded by a tag block (see [TagBlock]), whose checksum is verified separately; it is then
+// available from TagBlock.
 func (p *SegmentParser) Parse(s string) error {
-	if err := VerifyChecksum(s); err != nil {
-		return err
-	}
+	return p.ParseWithOptions(s)
+}
 
-	// Strip the first character ("$") and the last three characters (the checksum),
-	// and then split the remaining string on a comma (",").
-	segments := strings.Split(s[1:len(s)-3], ",")
+// ParseWithOptions is like Parse, but parses the sentence (and, later, its segments) according to
+// the ParseOptions that result from opts (see NewParseOptions). With no options, it is identical
+// to Parse.
+func (p *SegmentParser) ParseWithOptions(s string, opts ...ParseOption) error {
+	p.Reset()
 
-	p.sentence = s
-	p.segments = segments
+	return p.parse(s, opts)
+}
 
-	return nil
+// ParseBytes is like ParseWithOptions, but parses a sentence held in a byte slice, such as a line
+// read from a voyage log. It does not copy b: the segments are indexed in place, so b must not be
+// modified until p has been reset (see Reset) or has parsed another sentence. Strings returned by
+// the accessors (e.g. AsString) are copies, or constants, that remain valid afterwards.
+//
+// A SegmentParser that is reused with ParseBytes reuses its memory, so that parsing a sentence
+// without a tag block (and decoding its numeric, time and enum fields) does not allocate.
+func (p *SegmentParser) ParseBytes(b []byte, opts ...ParseOption) error {
+	p.Reset()
+	p.borrowed = true
+
+	return p.parse(unsafe.String(unsafe.SliceData(b), len(b)), opts)
 }
 
-// Err returns a SegmentParser's error value.
-func (p *SegmentParser) Err() error {
-	return p.err
+// Reset discards the parsed sentence and any recorded errors, so that p can be reused. It keeps
+// the memory that p has allocated.
+func (p *SegmentParser) Reset() {
+	*p = SegmentParser{segments: p.segments[:0], errs: p.errs[:0]}
 }
 
-// AsFloat32 parses the sentence segment at the specified index as a float32 value. If p.Err() is
-// not nil, this function returns 0 and leaves the error unchanged.
-func (p *SegmentParser) AsFloat32(i int8) float32 {
-	if p.checkInRange(i); p.err != nil {
-		return 0
-	}
+// Options returns the ParseOptions with which the sentence was parsed.
+func (p *SegmentParser) Options() ParseOptions {
+	return p.options
+}
 
-	if p.segments[i] == "" {
-		return 0
-	}
+// StartDelimiter returns the start delimiter of the parsed sentence: ParametricDelimiter ("$") or
+// EncapsulationDelimiter ("!").
+func (p *SegmentParser) StartDelimiter() StartDelimiter {
+	return p.delimiter
+}
 
-	val, err := strconv.ParseFloat(p.segments[i], 32)
-	if err != nil {
-		p.err = &ParsingError{
-			Segment: i,
-			Message: fmt.Sprintf("must be parsable as a float32 but was \"%s\"", p.segments[i]),
-		}
+// TagBlock returns the tag block that preceded the parsed sentence, or nil if there was none.
+func (p *SegmentParser) TagBlock() *TagBlock {
+	return p.tagBlock
+}
 
-		return 0
+// Err returns a SegmentParser's error value: the first error recorded while parsing its segments,
+// or nil if there was none. If the sentence was parsed with ParseOptions.CollectErrors, Err
+// instead returns every recorded error, joined with errors.Join.
+func (p *SegmentParser) Err() error {
+	if p.err != nil {
+		return p.err
 	}
 
-	return float32(val)
+	return errors.Join(p.errs...)
 }
 
-// AsFloat64 parses the sentence segment at the specified index as a float64 value. If p.Err() is
-// not nil, this function returns 0 and leaves the error unchanged.
-func (p *SegmentParser) AsFloat64(i int8) float64 {
-	if p.checkInRange(i); p.err != nil {
-		return 0
-	}
+// NameFields names the fields that the sentence's segments hold, in segment order (so names[0]
+// usually names segment [0], the sentence type). The names are used to fill in FieldError.Field
+// for the errors that are subsequently recorded; segments without a name leave it empty.
+func (p *SegmentParser) NameFields(names ...string) {
+	p.names = names
+}
 
-	if p.segments[i] == "" {
-		return 0
+// Fail records a *FieldError against the sentence segment at the specified index, noting the kind
+// of value that was expected (e.g. "NorthSouth") and describing the problem with message. Sentence
+// packages use it to report segments that fail their own parsing. If p.Err() is not nil (and the
+// sentence was not parsed with ParseOptions.CollectErrors), this function leaves the error
+// unchanged.
+func (p *SegmentParser) Fail(i int8, expected, message string) {
+	p.fail(&FieldError{Segment: i, Expected: expected, Message: message})
+}
+
+// Segment returns the raw value of the sentence segment at the specified index and true. Unlike
+// AsString, its value is never copied; after ParseBytes, it is only valid while the byte slice is.
+// If the index is out of range, it records an error and returns false. If p.Err() is not nil (and
+// the sentence was not parsed with ParseOptions.CollectErrors), this function returns false and
+// leaves the error unchanged.
+func (p *SegmentParser) Segment(i int8) (string, bool) {
+	if p.err != nil {
+		return "", false // There's already an error; exit early.
 	}
 
-	val, err := strconv.ParseFloat(p.segments[i], 64)
-	if err != nil {
-		p.err = &ParsingError{
-			Segment: i,
-			Message: fmt.Sprintf("must be parsable as a float64 but was \"%s\"", p.segments[i]),
+	if int8(len(p.segments)-1) < i {
+		if !p.options.AllowMissingTrailingFields || i < 0 {
+			p.fail(&FieldError{Segment: i, Message: "is out of range"})
+
+			return "", false
 		}
 
-		return 0
+		p.segments = append(p.segments, make([]string, int(i)+1-len(p.segments))...)
 	}
 
-	return val
+	return p.segments[i], true
+}
+
+// Len returns the number of segments in the parsed sentence, including element [0] but not the
+// checksum. Sentence packages use it to decode the fields that later versions of NMEA 0183
+// appended to a sentence only if they are present.
+func (p *SegmentParser) Len() int {
+	return len(p.segments)
+}
+
+// AsFloat32 parses the sentence segment at the specified index as a float32 value. If p.Err() is
+// not nil, this function returns 0 and leaves the error unchanged.
+func (p *SegmentParser) AsFloat32(i int8) float32 {
+	v, _ := p.parseFloat(i, 32)
+
+	return float32(v)
+}
+
+// AsFloat64 parses the sentence segment at the specified index as a float64 value. If p.Err() is
+// not nil, this function returns 0 and leaves the error unchanged.
+func (p *SegmentParser) AsFloat64(i int8) float64 {
+	v, _ := p.parseFloat(i, 64)
+
+	return v
 }
 
 // AsInt8 parses the sentence segment at the specified index as an int8 value. If p.Err() is not
 // nil, this function returns 0 and leaves the error unchanged.
 func (p *SegmentParser) AsInt8(i int8) int8 {
-	if val := p.asInt(i, 8); val == 0 {
-		return int8(val.(int))
-	}
+	v, _ := p.parseInt(i, 8)
 
-	return p.asInt(i, 8).(int8)
+	return int8(v)
 }
 
 // AsInt8InRange parses the sentence segment at the specified index as an int8 value and ensures
 // that it matches one of the required values in the range from l to u (lower and upper bound
 // inclusive). If p.Err() is not nil, this function returns 0 and leaves the error unchanged.
 func (p *SegmentParser) AsInt8InRange(i int8, l int8, u int8) int8 {
-	if p.checkInRange(i); p.err != nil {
-		return 0
-	}
-
-	val := p.AsInt8(i)
-	if p.err != nil {
+	v, ok := p.parseInt(i, 8)
+	if !ok {
 		return 0
 	}
 
-	if val < l || val > u {
-		p.err = &ParsingError{
-			Segment: i,
-			Message: fmt.Sprintf("must be within range [%d, %d] but was %s", l, u, p.segments[i]),
-		}
+	if val := int8(v); val < l || val > u {
+		p.fail(&FieldError{
+			Segment:  i,
+			Expected: fmt.Sprintf("int8 in [%d, %d]", l, u),
+			Message:  fmt.Sprintf("must be within range [%d, %d] but was %s", l, u, p.segments[i]),
+		})
 
 		return 0
 	}
 
-	return val
+	return int8(v)
 }
 
 // AsInt16 parses the sentence segment at the specified index as an int32 value. If p.Err() is not
 // nil, this function returns 0 and leaves the error unchanged.
 func (p *SegmentParser) AsInt16(i int8) int16 {
-	if val := p.asInt(i, 16); val == 0 {
-		return int16(val.(int))
-	}
+	v, _ := p.parseInt(i, 16)
 
-	return p.asInt(i, 16).(int16)
+	return int16(v)
 }
 
 // AsInt32 parses the sentence segment at the specified index as an int32 value. If p.Err() is not
 // nil, this function returns 0 and leaves the error unchanged.
 func (p *SegmentParser) AsInt32(i int8) int32 {
-	if val := p.asInt(i, 32); val == 0 {
-		return int32(val.(int))
-	}
+	v, _ := p.parseInt(i, 32)
 
-	return p.asInt(i, 32).(int32)
+	return int32(v)
 }
 
 // AsNMEATime parses the sentence segment at the specified index as an NMEATime value. If p.Err()
 // is not nil, this function returns NMEATime{} and leaves the error unchanged. An empty segment
 // returns NMEATime{} with no error.
 func (p *SegmentParser) AsNMEATime(i int8) NMEATime {
-	if p.checkInRange(i); p.err != nil {
-		return NMEATime{}
+	t, _ := p.parseNMEATime(i)
+
+	return t
+}
+
+// AsOptionalFloat32 parses the sentence segment at the specified index as a Float32 value, which
+// is invalid (Valid is false) if the segment is empty. If p.Err() is not nil, this function
+// returns Float32{} and leaves the error unchanged.
+func (p *SegmentParser) AsOptionalFloat32(i int8) Float32 {
+	v, ok := p.parseFloat(i, 32)
+	if !ok || p.segments[i] == "" {
+		return Float32{}
 	}
 
-	if p.segments[i] == "" {
-		return NMEATime{}
+	return NewFloat32(float32(v))
+}
+
+// AsOptionalFloat64 parses the sentence segment at the specified index as a Float64 value, which
+// is invalid (Valid is false) if the segment is empty. If p.Err() is not nil, this function
+// returns Float64{} and leaves the error unchanged.
+func (p *SegmentParser) AsOptionalFloat64(i int8) Float64 {
+	v, ok := p.parseFloat(i, 64)
+	if !ok || p.segments[i] == "" {
+		return Float64{}
 	}
 
-	t, err := parseNMEATime(p.segments[i])
-	if err != nil {
-		p.err = &ParsingError{
-			Segment: i,
-			Message: fmt.Sprintf("must be parsable as an NMEATime but was \"%s\"", p.segments[i]),
-		}
+	return NewFloat64(v)
+}
 
-		return NMEATime{}
+// AsOptionalInt parses the sentence segment at the specified index as an Int value of the
+// specified bit size (8, 16, 32 or 64), which is invalid (Valid is false) if the segment is empty.
+// If p.Err() is not nil, this function returns Int{} and leaves the error unchanged.
+func (p *SegmentParser) AsOptionalInt(i int8, bitSize int) Int {
+	v, ok := p.parseInt(i, bitSize)
+	if !ok || p.segments[i] == "" {
+		return Int{}
 	}
 
-	return t
+	return NewInt(v)
+}
+
+// AsOptionalNMEATime parses the sentence segment at the specified index as a Time value, which is
+// invalid (Valid is false) if the segment is empty. If p.Err() is not nil, this function returns
+// Time{} and leaves the error unchanged.
+func (p *SegmentParser) AsOptionalNMEATime(i int8) Time {
+	t, ok := p.parseNMEATime(i)
+	if !ok || p.segments[i] == "" {
+		return Time{}
+	}
+
+	return NewTime(t)
 }
 
 // AsString parses the sentence segment at the specified index as a string value. If p.Err() is not
 // nil, this function returns "" and leaves the error unchanged.
 func (p *SegmentParser) AsString(i int8) string {
-	if p.err != nil {
-		return "" // There's already an error; exit early.
+	v, _ := p.Segment(i)
+	if v == "" {
+		return ""
 	}
 
-	if p.checkInRange(i); p.err != nil {
+	return p.keep(v)
+}
+
+// AcceptsCase reports whether the segment value v may be accepted for a value whose canonical form
+// is canonical (e.g. an enum value's String). It is always true unless the sentence was parsed with
+// ParseOptions.CaseSensitive, in which case v must equal canonical exactly. Sentence packages use
+// it when parsing enum values, whose parsing is otherwise case-insensitive.
+func (p *SegmentParser) AcceptsCase(v, canonical string) bool {
+	return !p.options.CaseSensitive || v == canonical
+}
+
+// RequireString parses the sentence segment at the specified index as a string value and ensures
+// that it matches the required value s (case-insensitive, unless the sentence was parsed with
+// ParseOptions.CaseSensitive). If p.Err() is not nil, this function returns an empty string and
+// leaves the error unchanged.
+func (p *SegmentParser) RequireString(i int8, s string) string {
+	v, ok := p.Segment(i)
+	if !ok {
 		return ""
 	}
 
-	if p.segments[i] == "" {
+	if !p.matches(s, v) {
+		p.fail(&FieldError{
+			Segment:  i,
+			Expected: fmt.Sprintf("%q", s),
+			Message:  fmt.Sprintf("must be \"%s\" (%s) but was \"%s\"", s, p.caseNote(), v),
+		})
+
 		return ""
 	}
 
-	return p.segments[i]
+	if v == s {
+		return s
+	}
+
+	return p.keep(v)
 }
 
-// RequireString parses the sentence segment at the specified index as a string value and ensures
-// that it matches the required value s (case-insensitive). If p.Err() is not nil, this function
-// returns an empty string and leaves the error unchanged.
-func (p *SegmentParser) RequireString(i int8, s string) string {
-	if p.checkInRange(i); p.err != nil {
+// RequireFormatter parses sentence segment [0] as a standard sentence type (see
+// [SplitSentenceType]) and ensures that its sentence formatter matches the required value f
+// (case-insensitive, unless the sentence was parsed with ParseOptions.CaseSensitive, in which case
+// the whole sentence type must be upper case). Any talker identifier is accepted; it is returned
+// in upper case. If p.Err() is not nil, this function returns an empty string and leaves the error
+// unchanged.
+func (p *SegmentParser) RequireFormatter(f string) string {
+	v, ok := p.Segment(0)
+	if !ok {
 		return ""
 	}
 
-	if !strings.EqualFold(s, p.segments[i]) {
-		p.err = &ParsingError{
-			Segment: i,
-			Message: fmt.Sprintf("must be \"%s\" (case insensitive) but was \"%s\"", s, p.segments[i]),
-		}
+	talker, formatter, err := SplitSentenceType(v)
+	if err != nil || !strings.EqualFold(f, formatter) ||
+		(p.options.CaseSensitive && v != strings.ToUpper(v)) {
+		p.fail(&FieldError{
+			Segment:  0,
+			Expected: fmt.Sprintf("%q", "--"+f),
+			Message:  fmt.Sprintf("must be \"--%s\" (%s) but was \"%s\"", f, p.caseNote(), v),
+		})
+
+		return ""
+	}
+
+	return p.internTalker(talker)
+}
+
+// RequireQuery parses sentence segment [0] as a query sentence type (see [SplitQueryType]) and
+// returns the talker identifiers of its requester and destination in upper case. With
+// ParseOptions.CaseSensitive, the sentence type must be upper case. If p.Err() is not nil, this
+// function returns empty strings and leaves the error unchanged.
+func (p *SegmentParser) RequireQuery() (requester, destination string) {
+	v, ok := p.Segment(0)
+	if !ok {
+		return "", ""
+	}
+
+	requester, destination, err := SplitQueryType(v)
+	if err != nil || (p.options.CaseSensitive && v != strings.ToUpper(v)) {
+		p.fail(&FieldError{
+			Segment:  0,
+			Expected: fmt.Sprintf("%q", "----Q"),
+			Message:  fmt.Sprintf("must be \"----Q\" (%s) but was \"%s\"", p.caseNote(), v),
+		})
+
+		return "", ""
+	}
+
+	return p.internTalker(requester), p.internTalker(destination)
+}
+
+// RequireProprietary parses sentence segment [0] as a proprietary sentence type (see
+// [SplitProprietaryType]) and ensures that its manufacturer code matches the required value
+// manufacturer and, unless subType is empty, that its sub-type (see RegisterProprietary) matches
+// subType (case-insensitive, unless the sentence was parsed with ParseOptions.CaseSensitive). It
+// returns the sentence's sub-type in upper case. If p.Err() is not nil, this function returns an
+// empty string and leaves the error unchanged.
+func (p *SegmentParser) RequireProprietary(manufacturer, subType string) string {
+	v, ok := p.Segment(0)
+	if !ok {
+		return ""
+	}
+
+	m, rest, err := SplitProprietaryType(v)
+	if err != nil || !strings.EqualFold(manufacturer, m) ||
+		(p.options.CaseSensitive && v != strings.ToUpper(v)) {
+		p.fail(&FieldError{
+			Segment:  0,
+			Expected: fmt.Sprintf("%q", "P"+manufacturer),
+			Message: fmt.Sprintf("must be a proprietary sentence type of manufacturer \"%s\" (%s) "+
+				"but was \"%s\"", manufacturer, p.caseNote(), v),
+		})
+
+		return ""
+	}
+
+	i := int8(0) // The segment that holds the sub-type
+	if rest == "" {
+		i = 1
+	}
+
+	actual := p.proprietarySubType(rest)
+	if subType != "" && !p.matches(subType, actual) {
+		p.fail(&FieldError{
+			Segment:  i,
+			Expected: fmt.Sprintf("%q", subType),
+			Message: fmt.Sprintf("must be of proprietary sub-type \"%s\" (%s) but was \"%s\"",
+				subType, p.caseNote(), actual),
+		})
 
 		return ""
 	}
 
-	return p.segments[i]
+	return strings.ToUpper(p.keep(actual))
+}
+
+// RequireStartDelimiter ensures that the parsed sentence begins with the start delimiter d (see
+// StartDelimiter); a mismatch is recorded as an error against segment [0], which wraps a
+// *BadStartDelimiterError. If p.Err() is not nil, this function leaves the error unchanged.
+func (p *SegmentParser) RequireStartDelimiter(d StartDelimiter) {
+	if p.err != nil {
+		return
+	}
+
+	if p.delimiter != d {
+		cause := &BadStartDelimiterError{Expected: d.String(), Actual: p.delimiter.String()}
+		p.fail(&FieldError{
+			Segment:  0,
+			Expected: fmt.Sprintf("start delimiter %q", d.String()),
+			Message:  cause.Error(),
+			Err:      cause,
+		})
+	}
 }
 
 // RequireStrings parses the sentence segment at the specified index as a string value and ensures
-// that it matches one of the required values in s (case-insensitive). If p.Err() is not nil, this
-// function returns an empty string and leaves the error unchanged.
+// that it matches one of the required values in s (case-insensitive, unless the sentence was parsed
+// with ParseOptions.CaseSensitive). If p.Err() is not nil, this function returns an empty string
+// and leaves the error unchanged.
 func (p *SegmentParser) RequireStrings(i int8, s []string) string {
-	if p.checkInRange(i); p.err != nil {
+	v, ok := p.Segment(i)
+	if !ok {
 		return ""
 	}
 
 	for _, st := range s {
-		if strings.EqualFold(st, p.segments[i]) {
-			return p.segments[i] // The value matches
+		if v == st {
+			return st // The value matches exactly
+		}
+
+		if p.matches(st, v) {
+			return p.keep(v) // The value matches
 		}
 	}
 
 	// We didn't find a match
-	p.err = &ParsingError{
-		Segment: i,
-		Message: fmt.Sprintf("must be one of %v (case insensitive) but was \"%s\"", s, p.segments[i]),
-	}
+	p.fail(&FieldError{
+		Segment:  i,
+		Expected: fmt.Sprintf("one of %v", s),
+		Message:  fmt.Sprintf("must be one of %v (%s) but was \"%s\"", s, p.caseNote(), v),
+	})
 
 	return ""
 }
 
 // --- Private -----------------------------------------------------------------
 
-func (p *SegmentParser) asInt(i int8, bitSize int) interface{} {
-	if p.err != nil {
-		return 0 // There's already an error; exit early.
+// parse parses the sentence s according to the ParseOptions that result from opts; see
+// ParseWithOptions. It applies opts to p.options directly (rather than with NewParseOptions) so
+// that the options need not be allocated.
+func (p *SegmentParser) parse(s string, opts []ParseOption) error {
+	for _, opt := range opts {
+		opt(&p.options)
 	}
 
-	if p.checkInRange(i); p.err != nil {
-		return 0
+	o := p.options
+	if o.TrimSpace {
+		s = strings.TrimSpace(s)
 	}
 
-	if p.segments[i] == "" {
-		return 0
+	text := s
+
+	var tagBlock *TagBlock
+	if raw, rest := cutTagBlock(s); raw != "" {
+		tb, err := ParseTagBlock(p.keep(raw))
+		if err != nil {
+			return err
+		}
+
+		tagBlock, s = tb, rest
 	}
 
-	val, err := strconv.ParseInt(p.segments[i], 10, bitSize)
-	if err != nil {
-		p.err = &ParsingError{
-			Segment: i,
-			Message: fmt.Sprintf("must be parsable as an int%d but was \"%s\"", bitSize, p.segments[i]),
+	if o.MaxLength > 0 && len(s)+2 > o.MaxLength {
+		return fmt.Errorf("%w of %d characters", ErrSentenceTooLong, o.MaxLength)
+	}
+
+	// Strip the first character ("$" or "!") and the last three characters (the checksum, if
+	// present), and then split the remaining string on a comma (",").
+	var body string
+	if o.ChecksumOptional && s != "" && isStartDelimiter(s[0]) && strings.IndexByte(s, '*') < 0 {
+		body = s[1:]
+	} else {
+		if err := VerifyChecksum(s); err != nil {
+			return err
 		}
 
-		return 0
+		body = s[1 : len(s)-3]
+	}
+
+	for {
+		segment, rest, more := strings.Cut(body, ",")
+		if o.TrimSpace {
+			segment = strings.TrimSpace(segment)
+		}
+
+		p.segments = append(p.segments, segment)
+		if !more {
+			break
+		}
+
+		body = rest
 	}
 
-	switch bitSize {
-	case 8:
-		return int8(val)
-	case 16:
-		return int16(val)
-	case 32:
-		return int32(val)
-	case 64:
-		return val // Type is already int64
+	p.sentence = text
+	p.delimiter = StartDelimiter(s[0])
+	p.tagBlock = tagBlock
+
+	return nil
+}
+
+// proprietarySubType returns the sub-type of a proprietary sentence (see RegisterProprietary)
+// whose sentence type ends with rest (after its manufacturer code): rest itself, unless it is
+// empty, and otherwise sentence segment [1] (or "" if there is none).
+func (p *SegmentParser) proprietarySubType(rest string) string {
+	if rest != "" || len(p.segments) < 2 {
+		return rest
+	}
+
+	return p.segments[1]
+}
+
+// internTalker returns the constant for the talker identifier t if it is one of the well-known
+// talkers (e.g. TalkerGPS), so that it need not be copied (see keep).
+func (p *SegmentParser) internTalker(t string) string {
+	switch t {
+	case TalkerGPS:
+		return TalkerGPS
+	case TalkerGLONASS:
+		return TalkerGLONASS
+	case TalkerGalileo:
+		return TalkerGalileo
+	case TalkerBeiDou:
+		return TalkerBeiDou
+	case TalkerBeiDouLegacy:
+		return TalkerBeiDouLegacy
+	case TalkerQZSS:
+		return TalkerQZSS
+	case TalkerNavIC:
+		return TalkerNavIC
+	case TalkerGNSS:
+		return TalkerGNSS
+	}
+
+	return p.keep(t)
+}
+
+// keep returns v, or a copy of v if v refers to a byte slice passed to ParseBytes (which may be
+// modified once p has finished with it).
+func (p *SegmentParser) keep(v string) string {
+	if !p.borrowed {
+		return v
 	}
 
-	panic("should have returned (but did not) because bitSize was " + fmt.Sprintf("%d", bitSize))
+	return strings.Clone(v)
 }
 
-func (p *SegmentParser) checkInRange(i int8) {
+// fail fills in the sentence type, field name and raw value of e and records it: as p.err, unless
+// an error has already been recorded, or (with ParseOptions.CollectErrors) alongside any others.
+func (p *SegmentParser) fail(e *FieldError) {
 	if p.err != nil {
-		return // There's already an error; exit early.
+		return // There's already an error; keep it.
 	}
 
-	if int8(len(p.segments)-1) < i {
-		p.err = &ParsingError{Segment: i, Message: "is out of range"}
+	if len(p.segments) > 0 {
+		e.SentenceType = p.keep(p.segments[0])
+	}
+
+	if e.Segment >= 0 && int(e.Segment) < len(p.segments) {
+		e.Value = p.keep(p.segments[e.Segment])
+	}
+
+	if e.Segment >= 0 && int(e.Segment) < len(p.names) {
+		e.Field = p.names[e.Segment]
+	}
+
+	if p.options.CollectErrors {
+		p.errs = append(p.errs, e)
+
+		return
+	}
+
+	p.err = e
+}
+
+// parseFloat parses the sentence segment at the specified index as a float of the specified bit
+// size. An empty segment is 0. It returns false if no value could be parsed.
+func (p *SegmentParser) parseFloat(i int8, bitSize int) (float64, bool) {
+	v, ok := p.Segment(i)
+	if !ok {
+		return 0, false
+	}
+
+	if v == "" {
+		return 0, true
+	}
+
+	val, err := strconv.ParseFloat(v, bitSize)
+	if err != nil {
+		p.fail(&FieldError{
+			Segment:  i,
+			Expected: fmt.Sprintf("float%d", bitSize),
+			Message:  fmt.Sprintf("must be parsable as a float%d but was \"%s\"", bitSize, v),
+		})
+
+		return 0, false
+	}
+
+	return val, true
+}
+
+// parseNMEATime parses the sentence segment at the specified index as an NMEATime. An empty
+// segment is NMEATime{}. It returns false if no value could be parsed.
+func (p *SegmentParser) parseNMEATime(i int8) (NMEATime, bool) {
+	v, ok := p.Segment(i)
+	if !ok {
+		return NMEATime{}, false
+	}
+
+	if v == "" {
+		return NMEATime{}, true
 	}
+
+	t, err := parseNMEATime(v)
+	if err != nil {
+		p.fail(&FieldError{
+			Segment:  i,
+			Expected: "NMEATime",
+			Message:  fmt.Sprintf("must be parsable as an NMEATime but was \"%s\"", v),
+		})
+
+		return NMEATime{}, false
+	}
+
+	return t, true
+}
+
+// parseInt parses the sentence segment at the specified index as an integer of the specified bit
+// size. An empty segment is 0. It returns false if no value could be parsed.
+func (p *SegmentParser) parseInt(i int8, bitSize int) (int64, bool) {
+	v, ok := p.Segment(i)
+	if !ok {
+		return 0, false
+	}
+
+	if v == "" {
+		return 0, true
+	}
+
+	val, err := strconv.ParseInt(v, 10, bitSize)
+	if err != nil {
+		p.fail(&FieldError{
+			Segment:  i,
+			Expected: fmt.Sprintf("int%d", bitSize),
+			Message:  fmt.Sprintf("must be parsable as an int%d but was \"%s\"", bitSize, v),
+		})
+
+		return 0, false
+	}
+
+	return val, true
+}
+
+// matches reports whether the segment value v matches the expected value s, taking
+// ParseOptions.CaseSensitive into account.
+func (p *SegmentParser) matches(s, v string) bool {
+	if p.options.CaseSensitive {
+		return s == v
+	}
+
+	return strings.EqualFold(s, v)
+}
+
+// caseNote describes how matches compares values, for use in error messages.
+func (p *SegmentParser) caseNote() string {
+	if p.options.CaseSensitive {
+		return "case sensitive"
+	}
+
+	return "case insensitive"
 }
@@ -3,9 +3,10 @@ package sentence
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
-	"github.com/mab-go/nmea/sentence/testhelp"
+	"github.com/mab-go/nmea/nmeatest"
 )
 
 // referenceSentence is a valid GPGGA sentence used as the base for most
@@ -27,28 +28,8 @@ func mustParse(t *testing.T) *SegmentParser {
 	return p
 }
 
-type parseTestData struct {
-	Title, Sentence, ActualChecksum, AdvertisedChecksum, ErrMsg string
-}
-
-func mapParseTestData(title string, input map[string]interface{}) interface{} {
-	return parseTestData{
-		Title:              title,
-		Sentence:           testhelp.EnsureString(input["Sentence"]),
-		ActualChecksum:     testhelp.EnsureString(input["ActualChecksum"]),
-		AdvertisedChecksum: testhelp.OptString(input["AdvertisedChecksum"]),
-		ErrMsg:             testhelp.OptString(input["ErrMsg"]),
-	}
-}
-
-func sortParseTestData(result []interface{}, i, j int) bool {
-	return result[i].(parseTestData).Title < result[j].(parseTestData).Title
-}
-
 func TestSegmentParser_Parse_goodData(t *testing.T) {
-	for _, data := range testhelp.ReadTestData("good-data", mapParseTestData, sortParseTestData) {
-		d := data.(parseTestData)
-
+	for _, d := range nmeatest.Good() {
 		t.Run(d.Title, func(t *testing.T) {
 			parser := &SegmentParser{}
 			err := parser.Parse(d.Sentence) // Unit under test
@@ -60,9 +41,7 @@ func TestSegmentParser_Parse_goodData(t *testing.T) {
 }
 
 func TestSegmentParser_Parse_invalidChecksums(t *testing.T) {
-	for _, data := range testhelp.ReadTestData("bad-invalid-checksums", mapParseTestData, sortParseTestData) {
-		d := data.(parseTestData)
-
+	for _, d := range nmeatest.InvalidChecksums() {
 		t.Run(d.Title, func(t *testing.T) {
 			parser := &SegmentParser{}
 			err := parser.Parse(d.Sentence) // Unit under test
@@ -81,6 +60,200 @@ func TestSegmentParser_Parse_invalidChecksums(t *testing.T) {
 	}
 }
 
+func TestSegmentParser_Parse_tagBlock(t *testing.T) {
+	t.Run("With Tag Block", func(t *testing.T) {
+		p := &SegmentParser{}
+		if err := p.Parse("\\s:r3669961,c:1503394200*71\\" + referenceSentence); err != nil {
+			t.Fatalf("segment parsing failed: %v", err)
+		}
+
+		expected := TagBlock{Source: "r3669961", UnixTime: 1503394200}
+		if tb := p.TagBlock(); tb == nil || *tb != expected {
+			t.Errorf("tag block should have been %+v but was %+v", expected, tb)
+		}
+
+		if sentenceType := p.AsString(0); sentenceType != "GPGGA" {
+			t.Errorf("segment [0] should have been \"GPGGA\" but was %q", sentenceType)
+		}
+	})
+
+	t.Run("Without Tag Block", func(t *testing.T) {
+		if tb := mustParse(t).TagBlock(); tb != nil {
+			t.Errorf("expected no tag block but got %+v", tb)
+		}
+	})
+
+	t.Run("Invalid Tag Block Checksum", func(t *testing.T) {
+		p := &SegmentParser{}
+		err := p.Parse("\\s:r3669961,c:1503394200*4A\\" + referenceSentence)
+		if err == nil {
+			t.Fatal("segment parsing succeeded (but should not have)")
+		}
+
+		expectedMsg := "calculated tag block checksum value \"71\" does not match tag block-specified " +
+			"value of \"4A\""
+		if err.Error() != expectedMsg {
+			t.Errorf("error message should have been '%v' but was '%v'", expectedMsg, err.Error())
+		}
+	})
+}
+
+func TestSegmentParser_ParseWithOptions(t *testing.T) {
+	for title, vec := range map[string]struct {
+		input    string
+		opts     []ParseOption
+		segment  int8
+		expected string
+		errMsg   string
+	}{
+		"Checksum Required by Default": {
+			input:  "$GPGGA,183730,3907.356,N",
+			errMsg: "sentence does not contain a checksum",
+		},
+		"Checksum Optional": {
+			input:    "$GPGGA,183730,3907.356,N",
+			opts:     []ParseOption{WithChecksumOptional()},
+			segment:  3,
+			expected: "N",
+		},
+		"Checksum Optional but Invalid": {
+			input:  "$GPGGA,183730,3907.356,N*00",
+			opts:   []ParseOption{WithChecksumOptional()},
+			errMsg: "calculated checksum value \"29\" does not match sentence-specified value of \"00\"",
+		},
+		"Checksum Optional without Start Delimiter": {
+			input:  "GPGGA,183730,3907.356,N",
+			opts:   []ParseOption{WithChecksumOptional()},
+			errMsg: "character [0] must be \"$\" or \"!\" but was \"G\"",
+		},
+		"Whitespace Kept by Default": {
+			input:    "$GPGGA, 183730 ,3907.356,N*29",
+			segment:  1,
+			expected: " 183730 ",
+		},
+		"Trim Space": {
+			input:    "  $GPGGA, 183730 ,3907.356,N*29\r\n",
+			opts:     []ParseOption{WithTrimSpace()},
+			segment:  1,
+			expected: "183730",
+		},
+		"Missing Trailing Field": {
+			input:   "$GPGGA,183730,3907.356,N*29",
+			segment: 5,
+			errMsg:  "sentence segment [5] is out of range",
+		},
+		"Missing Trailing Field Allowed": {
+			input:    "$GPGGA,183730,3907.356,N*29",
+			opts:     []ParseOption{WithMissingTrailingFields()},
+			segment:  5,
+			expected: "",
+		},
+		"Within Maximum Length": {
+			input:    referenceSentence,
+			opts:     []ParseOption{WithMaxLength(MaxSentenceLength)},
+			segment:  2,
+			expected: "3907.356",
+		},
+		"Exceeds Maximum Length": {
+			input:  referenceSentence,
+			opts:   []ParseOption{WithMaxLength(40)},
+			errMsg: "sentence exceeds the maximum length of 40 characters",
+		},
+	} {
+		t.Run(title, func(t *testing.T) {
+			p := &SegmentParser{}
+			err := p.ParseWithOptions(vec.input, vec.opts...) // Unit under test
+
+			var actual string
+			if err == nil {
+				actual = p.AsString(vec.segment)
+				err = p.Err()
+			}
+
+			if vec.errMsg != "" {
+				if err == nil || err.Error() != vec.errMsg {
+					t.Errorf("error should have been '%v' but was '%v'", vec.errMsg, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if actual != vec.expected {
+				t.Errorf("segment [%d] should have been %q but was %q", vec.segment, vec.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSegmentParser_ParseWithOptions_caseSensitive(t *testing.T) {
+	const lower = "$gpgga,183730,3907.356,n*29"
+
+	p := &SegmentParser{}
+	if err := p.Parse(lower); err != nil {
+		t.Fatalf("segment parsing failed: %v", err)
+	}
+
+	if p.RequireFormatter("GGA"); p.Err() != nil {
+		t.Errorf("expected a lower-case sentence type to be accepted by default but got %v", p.Err())
+	}
+
+	if !p.AcceptsCase("n", "N") {
+		t.Error("expected AcceptsCase to accept any case by default")
+	}
+
+	p = &SegmentParser{}
+	if err := p.ParseWithOptions(lower, WithCaseSensitive()); err != nil {
+		t.Fatalf("segment parsing failed: %v", err)
+	}
+
+	if p.AcceptsCase("n", "N") {
+		t.Error("expected AcceptsCase to reject a case mismatch when case sensitive")
+	}
+
+	p.RequireFormatter("GGA")
+	expected := "sentence segment [0] must be \"--GGA\" (case sensitive) but was \"gpgga\""
+	if p.Err() == nil || p.Err().Error() != expected {
+		t.Errorf("expected error %q but was %v", expected, p.Err())
+	}
+}
+
+func TestSegmentParser_StartDelimiter(t *testing.T) {
+	for input, expected := range map[string]StartDelimiter{
+		referenceSentence: ParametricDelimiter,
+		"!AIVDM,1,1,,B,15MwkT1P37G?fl0EJbR0OwT0@MS,0*0E": EncapsulationDelimiter,
+	} {
+		p := &SegmentParser{}
+		if err := p.Parse(input); err != nil {
+			t.Fatalf("segment parsing failed: %v", err)
+		}
+
+		if actual := p.StartDelimiter(); actual != expected {
+			t.Errorf("start delimiter of %q should have been %q but was %q", input, expected, actual)
+		}
+	}
+}
+
+func TestSegmentParser_RequireStartDelimiter(t *testing.T) {
+	p := mustParse(t)
+	if p.RequireStartDelimiter(ParametricDelimiter); p.Err() != nil {
+		t.Errorf("expected no error but got %v", p.Err())
+	}
+
+	p.RequireStartDelimiter(EncapsulationDelimiter)
+	expected := "sentence segment [0] must be preceded by start delimiter \"!\" but was preceded by \"$\""
+	if p.Err() == nil || p.Err().Error() != expected {
+		t.Errorf("expected error %q but was %v", expected, p.Err())
+	}
+
+	if !errors.Is(p.Err(), ErrBadStartDelimiter) {
+		t.Errorf("expected %v to match ErrBadStartDelimiter", p.Err())
+	}
+}
+
 func TestSegmentParser_Err(t *testing.T) {
 	t.Run("No Error", func(t *testing.T) {
 		p := mustParse(t)
@@ -98,6 +271,98 @@ func TestSegmentParser_Err(t *testing.T) {
 	})
 }
 
+func TestSegmentParser_Err_fieldError(t *testing.T) {
+	p := mustParse(t)
+	p.NameFields("TalkerID", "FixTime", "Latitude", "NorthSouth")
+	p.RequireString(3, "S")
+
+	var fieldErr *FieldError
+	if !errors.As(p.Err(), &fieldErr) {
+		t.Fatalf("expected a *FieldError but was %v", p.Err())
+	}
+
+	expected := FieldError{
+		SentenceType: "GPGGA",
+		Field:        "NorthSouth",
+		Segment:      3,
+		Value:        "N",
+		Expected:     "\"S\"",
+		Message:      "must be \"S\" (case insensitive) but was \"N\"",
+	}
+	if *fieldErr != expected {
+		t.Errorf("error should have been %+v but was %+v", expected, *fieldErr)
+	}
+
+	if !errors.Is(p.Err(), ErrInvalidField) {
+		t.Errorf("expected %v to match ErrInvalidField", p.Err())
+	}
+}
+
+func TestSegmentParser_Fail(t *testing.T) {
+	p := mustParse(t)
+	p.Fail(6, "FixQuality", "must be parsable as a FixQuality but was \"1\"")
+	p.Fail(7, "int8", "is ignored, because there is already an error")
+
+	expected := "sentence segment [6] must be parsable as a FixQuality but was \"1\""
+	if p.Err() == nil || p.Err().Error() != expected {
+		t.Fatalf("expected error %q but was %v", expected, p.Err())
+	}
+
+	var fieldErr *FieldError
+	if !errors.As(p.Err(), &fieldErr) || fieldErr.Value != "1" || fieldErr.Expected != "FixQuality" {
+		t.Errorf("expected a *FieldError for value \"1\" of kind FixQuality but was %+v", fieldErr)
+	}
+}
+
+func TestSegmentParser_ParseWithOptions_collectErrors(t *testing.T) {
+	p := &SegmentParser{}
+	if err := p.ParseWithOptions(referenceSentence, WithCollectErrors()); err != nil {
+		t.Fatalf("segment parsing failed: %v", err)
+	}
+
+	p.AsInt8(2)             // "3907.356"
+	p.AsFloat32(3)          // "N"
+	p.AsNMEATime(5)         // "W"
+	p.AsFloat64(1)          // "183730" (valid)
+	p.RequireString(99, "") // out of range
+
+	expected := []string{
+		"sentence segment [2] must be parsable as an int8 but was \"3907.356\"",
+		"sentence segment [3] must be parsable as a float32 but was \"N\"",
+		"sentence segment [5] must be parsable as an NMEATime but was \"W\"",
+		"sentence segment [99] is out of range",
+	}
+	if actual := p.Err(); actual == nil || actual.Error() != strings.Join(expected, "\n") {
+		t.Fatalf("expected errors %q but was %v", expected, actual)
+	}
+
+	joined, ok := p.Err().(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) != len(expected) {
+		t.Fatalf("expected %d joined errors but was %v", len(expected), p.Err())
+	}
+
+	for _, err := range joined.Unwrap() {
+		if !errors.Is(err, ErrInvalidField) {
+			t.Errorf("expected %v to match ErrInvalidField", err)
+		}
+	}
+}
+
+func TestSegmentParser_Len(t *testing.T) {
+	if actual := mustParse(t).Len(); actual != 15 {
+		t.Errorf("expected 15 segments but was %d", actual)
+	}
+
+	p := &SegmentParser{}
+	if err := p.ParseWithOptions("$GPGLL,1", WithChecksumOptional()); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+
+	if actual := p.Len(); actual != 2 {
+		t.Errorf("expected 2 segments but was %d", actual)
+	}
+}
+
 func TestSegmentParser_AsFloat32(t *testing.T) {
 	t.Run("Good Data", func(t *testing.T) {
 		p := mustParse(t)
@@ -214,6 +479,96 @@ func TestSegmentParser_AsFloat64(t *testing.T) {
 	})
 }
 
+func TestSegmentParser_AsOptional(t *testing.T) {
+	t.Run("Good Data", func(t *testing.T) {
+		p := mustParse(t)
+		if actual, expected := p.AsOptionalFloat32(9), NewFloat32(646.4); actual != expected {
+			t.Errorf("expected %+v but was %+v", expected, actual)
+		}
+		if actual, expected := p.AsOptionalFloat64(11), NewFloat64(-24.1); actual != expected {
+			t.Errorf("expected %+v but was %+v", expected, actual)
+		}
+		if actual, expected := p.AsOptionalInt(7, 8), NewInt(5); actual != expected {
+			t.Errorf("expected %+v but was %+v", expected, actual)
+		}
+		expectedTime := NewTime(NMEATime{Hour: 18, Minute: 37, Second: 30, FractionDigits: NoFractionDigits})
+		if actual := p.AsOptionalNMEATime(1); actual != expectedTime {
+			t.Errorf("expected %+v but was %+v", expectedTime, actual)
+		}
+		if p.Err() != nil {
+			t.Errorf("expected no error but got %v", p.Err())
+		}
+	})
+
+	t.Run("Zero Value", func(t *testing.T) {
+		p := mustParse(t)
+		p.segments[13] = "0"
+		if actual, expected := p.AsOptionalFloat32(13), NewFloat32(0); actual != expected {
+			t.Errorf("expected %+v but was %+v", expected, actual)
+		}
+		if actual, expected := p.AsOptionalInt(13, 16), NewInt(0); actual != expected {
+			t.Errorf("expected %+v but was %+v", expected, actual)
+		}
+		p.segments[13] = "000000.000"
+		if actual, expected := p.AsOptionalNMEATime(13), NewTime(NMEATime{}); actual != expected {
+			t.Errorf("expected %+v but was %+v", expected, actual)
+		}
+	})
+
+	t.Run("Empty Segment", func(t *testing.T) {
+		p := mustParse(t)
+		if actual := p.AsOptionalFloat32(13); actual.Valid {
+			t.Errorf("expected an invalid Float32 for empty segment but was %+v", actual)
+		}
+		if actual := p.AsOptionalFloat64(13); actual.Valid {
+			t.Errorf("expected an invalid Float64 for empty segment but was %+v", actual)
+		}
+		if actual := p.AsOptionalInt(13, 16); actual.Valid {
+			t.Errorf("expected an invalid Int for empty segment but was %+v", actual)
+		}
+		if actual := p.AsOptionalNMEATime(13); actual.Valid {
+			t.Errorf("expected an invalid Time for empty segment but was %+v", actual)
+		}
+		if p.Err() != nil {
+			t.Errorf("expected no error for empty segment but got %v", p.Err())
+		}
+	})
+
+	t.Run("Unparsable Value", func(t *testing.T) {
+		p := mustParse(t)
+		p.segments[2] = "not_a_number"
+		if actual := p.AsOptionalInt(2, 32); actual != (Int{}) {
+			t.Errorf("expected Int{} on parse failure but was %+v", actual)
+		}
+		expected := "sentence segment [2] must be parsable as an int32 but was \"not_a_number\""
+		if p.Err() == nil || p.Err().Error() != expected {
+			t.Errorf("expected error %q but was %v", expected, p.Err())
+		}
+	})
+
+	t.Run("Unparsable Time", func(t *testing.T) {
+		p := mustParse(t)
+		p.segments[1] = "not_a_time"
+		if actual := p.AsOptionalNMEATime(1); actual != (Time{}) {
+			t.Errorf("expected Time{} on parse failure but was %+v", actual)
+		}
+		expected := "sentence segment [1] must be parsable as an NMEATime but was \"not_a_time\""
+		if p.Err() == nil || p.Err().Error() != expected {
+			t.Errorf("expected error %q but was %v", expected, p.Err())
+		}
+	})
+
+	t.Run("Out-of-Range Index", func(t *testing.T) {
+		p := mustParse(t)
+		if actual := p.AsOptionalFloat64(99); actual != (Float64{}) {
+			t.Errorf("expected Float64{} on out-of-range index but was %+v", actual)
+		}
+		if p.Err() == nil {
+			t.Error("expected an error for out-of-range index but got nil")
+		}
+	})
+}
+
 func TestSegmentParser_AsInt8(t *testing.T) {
 	t.Run("Good Data", func(t *testing.T) {
 		p := mustParse(t)
@@ -560,6 +915,94 @@ func TestSegmentParser_RequireString(t *testing.T) {
 	})
 }
 
+func TestSegmentParser_RequireFormatter(t *testing.T) {
+	t.Run("Match", func(t *testing.T) {
+		p := mustParse(t)
+		actual := p.RequireFormatter("GGA")
+		if actual != "GP" {
+			t.Errorf("expected %q but was %q", "GP", actual)
+		}
+		if p.Err() != nil {
+			t.Errorf("expected no error but got %v", p.Err())
+		}
+	})
+
+	t.Run("Case-Insensitive Match", func(t *testing.T) {
+		p := mustParse(t)
+		actual := p.RequireFormatter("gga")
+		if actual != "GP" {
+			t.Errorf("expected %q but was %q", "GP", actual)
+		}
+		if p.Err() != nil {
+			t.Errorf("expected no error on case-insensitive match but got %v", p.Err())
+		}
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		p := mustParse(t)
+		actual := p.RequireFormatter("GLL")
+		if actual != "" {
+			t.Errorf("expected empty string on mismatch but was %q", actual)
+		}
+
+		expected := "sentence segment [0] must be \"--GLL\" (case insensitive) but was \"GPGGA\""
+		if p.Err() == nil || p.Err().Error() != expected {
+			t.Errorf("expected error %q but was %v", expected, p.Err())
+		}
+	})
+
+	t.Run("Pre-existing Error", func(t *testing.T) {
+		p := mustParse(t)
+		p.RequireFormatter("GLL")
+		firstErr := p.Err()
+		p.RequireFormatter("GGA")
+		if !errors.Is(p.Err(), firstErr) {
+			t.Errorf("expected error to remain unchanged but it changed to %v", p.Err())
+		}
+	})
+}
+
+func TestSegmentParser_RequireQuery(t *testing.T) {
+	t.Run("Match", func(t *testing.T) {
+		p := &SegmentParser{}
+		if err := p.Parse("$gpecq,GGA*0D"); err != nil {
+			t.Fatalf("failed to parse: %v", err)
+		}
+
+		requester, destination := p.RequireQuery()
+		if requester != "GP" || destination != "EC" || p.Err() != nil {
+			t.Errorf("expected (\"GP\", \"EC\") but was (%q, %q) with error %v", requester,
+				destination, p.Err())
+		}
+	})
+
+	t.Run("Case-Sensitive Mismatch", func(t *testing.T) {
+		p := &SegmentParser{}
+		if err := p.ParseWithOptions("$gpecq,GGA*0D", WithCaseSensitive()); err != nil {
+			t.Fatalf("failed to parse: %v", err)
+		}
+
+		p.RequireQuery()
+		expected := "sentence segment [0] must be \"----Q\" (case sensitive) but was \"gpecq\""
+		if p.Err() == nil || p.Err().Error() != expected {
+			t.Errorf("expected error %q but was %v", expected, p.Err())
+		}
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		p := mustParse(t)
+		if requester, destination := p.RequireQuery(); requester != "" || destination != "" {
+			t.Errorf("expected empty strings on mismatch but were %q and %q", requester,
+				destination)
+		}
+
+		expected := "sentence segment [0] must be \"----Q\" (case insensitive) but was \"GPGGA\""
+		if p.Err() == nil || p.Err().Error() != expected {
+			t.Errorf("expected error %q but was %v", expected, p.Err())
+		}
+	})
+}
+
 func TestSegmentParser_RequireStrings(t *testing.T) {
 	t.Run("Match", func(t *testing.T) {
 		p := mustParse(t)
@@ -615,3 +1058,181 @@ func TestSegmentParser_RequireStrings(t *testing.T) {
 		}
 	})
 }
+
+func TestSegmentParser_RequireProprietary(t *testing.T) {
+	parse := func(t *testing.T, s string, opts ...ParseOption) *SegmentParser {
+		t.Helper()
+
+		p := &SegmentParser{}
+		if err := p.ParseWithOptions(s, append(opts, WithChecksumOptional())...); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		return p
+	}
+
+	for title, vec := range map[string]struct{ input, manufacturer, subType, expected string }{
+		"Sub-Type in Sentence Type":     {"$PGRME,1*50", "GRM", "E", "E"},
+		"Sub-Type in Segment 1":         {"$PUBX,00,1", "UBX", "00", "00"},
+		"Any Sub-Type":                  {"$PSRF100,1", "SRF", "", "100"},
+		"Case-Insensitive Manufacturer": {"$pgrme,1", "grm", "e", "E"},
+	} {
+		t.Run(title, func(t *testing.T) {
+			p := parse(t, vec.input)
+			if actual := p.RequireProprietary(vec.manufacturer, vec.subType); actual != vec.expected {
+				t.Errorf("expected %q but was %q", vec.expected, actual)
+			}
+			if p.Err() != nil {
+				t.Errorf("expected no error but got %v", p.Err())
+			}
+		})
+	}
+
+	for title, vec := range map[string]struct {
+		input, manufacturer, subType, expected string
+		opts                                   []ParseOption
+	}{
+		"Manufacturer Mismatch": {
+			input: "$PGRME,1", manufacturer: "SRF",
+			expected: "sentence segment [0] must be a proprietary sentence type of manufacturer " +
+				"\"SRF\" (case insensitive) but was \"PGRME\"",
+		},
+		"Not Proprietary": {
+			input: "$GPGGA,1", manufacturer: "GPG",
+			expected: "sentence segment [0] must be a proprietary sentence type of manufacturer " +
+				"\"GPG\" (case insensitive) but was \"GPGGA\"",
+		},
+		"Sub-Type Mismatch": {
+			input: "$PGRME,1", manufacturer: "GRM", subType: "M",
+			expected: "sentence segment [0] must be of proprietary sub-type \"M\" " +
+				"(case insensitive) but was \"E\"",
+		},
+		"Sub-Type Mismatch in Segment 1": {
+			input: "$PUBX,03,1", manufacturer: "UBX", subType: "00",
+			expected: "sentence segment [1] must be of proprietary sub-type \"00\" " +
+				"(case insensitive) but was \"03\"",
+		},
+		"Case-Sensitive": {
+			input: "$pgrme,1", manufacturer: "GRM", opts: []ParseOption{WithCaseSensitive()},
+			expected: "sentence segment [0] must be a proprietary sentence type of manufacturer " +
+				"\"GRM\" (case sensitive) but was \"pgrme\"",
+		},
+	} {
+		t.Run(title, func(t *testing.T) {
+			p := parse(t, vec.input, vec.opts...)
+			if actual := p.RequireProprietary(vec.manufacturer, vec.subType); actual != "" {
+				t.Errorf("expected empty string on mismatch but was %q", actual)
+			}
+			if p.Err() == nil || p.Err().Error() != vec.expected {
+				t.Errorf("expected error %q but was %v", vec.expected, p.Err())
+			}
+		})
+	}
+}
+
+func TestSegmentParser_ParseBytes(t *testing.T) {
+	b := []byte(referenceSentence)
+
+	p := &SegmentParser{}
+	if err := p.ParseBytes(b); err != nil {
+		t.Fatalf("segment parsing failed: %v", err)
+	}
+
+	talker := p.RequireFormatter("GGA")
+	uom := p.RequireString(10, "M")
+	fixTime := p.AsString(1)
+	if p.Err() != nil {
+		t.Fatalf("expected no error but got %v", p.Err())
+	}
+
+	// The strings returned by the accessors must not refer to b, which the caller may reuse
+	for i := range b {
+		b[i] = 'X'
+	}
+
+	if talker != "GP" || uom != "M" || fixTime != "183730" {
+		t.Errorf("expected \"GP\", \"M\" and \"183730\" but were %q, %q and %q", talker, uom, fixTime)
+	}
+}
+
+func TestSegmentParser_ParseBytes_errors(t *testing.T) {
+	p := &SegmentParser{}
+	err := p.ParseBytes([]byte("$GPGGA,183730,3907.356,N*00"))
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected a checksum mismatch but was %v", err)
+	}
+
+	if err := p.ParseBytes([]byte(referenceSentence)); err != nil {
+		t.Fatalf("segment parsing failed after an error: %v", err)
+	}
+
+	p.AsInt8(2)
+
+	var fieldErr *FieldError
+	if !errors.As(p.Err(), &fieldErr) || fieldErr.Value != "3907.356" || fieldErr.SentenceType != "GPGGA" {
+		t.Errorf("expected a *FieldError for value \"3907.356\" of a GPGGA but was %v", p.Err())
+	}
+}
+
+func TestSegmentParser_Reset(t *testing.T) {
+	p := mustParse(t)
+	p.AsFloat32(99) // out-of-range index sets an error
+
+	p.Reset()
+	if p.Err() != nil {
+		t.Errorf("expected Err() to be nil after Reset but was %v", p.Err())
+	}
+
+	if p.TagBlock() != nil || p.StartDelimiter() != 0 {
+		t.Errorf("expected Reset to discard the parsed sentence")
+	}
+
+	p.AsString(0)
+	if p.Err() == nil {
+		t.Errorf("expected an out-of-range error after Reset")
+	}
+}
+
+func TestSegmentParser_ParseBytes_allocations(t *testing.T) {
+	b := []byte(referenceSentence)
+	p := &SegmentParser{}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := p.ParseBytes(b); err != nil {
+			t.Fatalf("segment parsing failed: %v", err)
+		}
+
+		p.RequireFormatter("GGA")
+		p.AsNMEATime(1)
+		p.AsFloat64(2)
+		p.AsInt8(7)
+		p.AsOptionalFloat32(8)
+		p.RequireString(10, "M")
+		p.AsOptionalInt(14, 16)
+	})
+	if allocs != 0 {
+		t.Errorf("expected ParseBytes and the accessors not to allocate but there were %v allocations", allocs)
+	}
+}
+
+func BenchmarkSegmentParser_Parse(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		p := &SegmentParser{}
+		if err := p.Parse(referenceSentence); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSegmentParser_ParseBytes(b *testing.B) {
+	line := []byte(referenceSentence)
+	p := &SegmentParser{}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := p.ParseBytes(line); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package sentence
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+//go:generate go run github.com/mab-go/nmea/tools/nmeaschema query
+
+// --- Public ------------------------------------------------------------------
+
+// Query represents an NMEA query sentence, with which one device requests a sentence from
+// another, e.g. "$GPECQ,GGA*2D", with which a GPS receiver ("GP") asks an electronic chart display
+// ("EC") for its GGA sentence. Its sentence type (element [0]) is made up of the talker identifiers
+// of the requester and of the destination followed by "Q", and its only field (element [1]) is the
+// sentence formatter that is requested.
+//
+// Parse recognizes a query whose sentence type is not a registered formatter (see Register) and
+// returns it as a *Query.
+type Query struct {
+	// Requester is the talker identifier of the device that sent the query (e.g. "GP"). It is the
+	// first two characters of element [0] of a query.
+	Requester string `json:"requester"`
+
+	// Destination is the talker identifier of the device that is asked for data (e.g. "EC"). It is
+	// the third and fourth characters of element [0] of a query.
+	Destination string `json:"destination"`
+
+	// Formatter is the sentence formatter that is requested (e.g. "GGA"), in upper case. It is
+	// element [1] of a query.
+	Formatter string `json:"formatter"`
+
+	// TagBlock is the tag block (see [TagBlock]) that preceded the query, or nil if there was
+	// none. It is not part of the query itself.
+	TagBlock *TagBlock `json:"tagBlock,omitzero"`
+}
+
+// GetSentenceType returns the type of the query: the talker identifiers of its requester and of
+// its destination followed by "Q" (e.g. "GPECQ"). It represents element [0] of a query.
+func (q Query) GetSentenceType() string {
+	return q.Requester + q.Destination + "Q"
+}
+
+// Talker returns the talker identifier of the requester of the query (e.g. "GP").
+func (q Query) Talker() string {
+	return q.Requester
+}
+
+// MarshalNMEA encodes q as a query sentence (including its checksum), e.g. "$GPECQ,GGA*2D". It
+// returns an error if q.Requester or q.Destination is not a valid talker identifier or if
+// q.Formatter is not a valid sentence formatter.
+func (q Query) MarshalNMEA() (string, error) {
+	w := &SegmentWriter{}
+	w.WriteTagBlock(q.TagBlock)
+	w.WriteQueryType(q.Requester, q.Destination)
+	w.writeFormatter(q.Formatter)
+
+	return w.Sentence()
+}
+
+// MarshalJSON encodes q as a JSON object whose members are "sentenceType" (e.g. "GPECQ") and the
+// fields of q, named by their json struct tags. See MarshalSentenceJSON for the encoding of each
+// type of field, and query.schema.json for its JSON Schema.
+func (q Query) MarshalJSON() ([]byte, error) {
+	type fields Query // Has the fields of Query, but not its MarshalJSON method
+
+	return MarshalSentenceJSON(q.GetSentenceType(), fields(q))
+}
+
+// UnmarshalJSON decodes a JSON object encoded by MarshalJSON into q, which is only modified if
+// data is valid. Its "sentenceType" member may be omitted.
+func (q *Query) UnmarshalJSON(data []byte) error {
+	type fields Query
+
+	var decoded Query
+	if err := UnmarshalSentenceJSON(data, (*fields)(&decoded), &decoded); err != nil {
+		return err
+	}
+
+	*q = decoded
+
+	return nil
+}
+
+// Ensure that Query properly implements the NMEASentence, Marshaler and JSON interfaces
+var (
+	_ NMEASentence     = Query{}
+	_ Marshaler        = Query{}
+	_ json.Marshaler   = Query{}
+	_ json.Unmarshaler = (*Query)(nil)
+)
+
+// BuildQuery returns the query sentence (including its checksum) with which the device whose
+// talker identifier is requester asks the device whose talker identifier is destination for its
+// sentence of the specified formatter; e.g. BuildQuery("GP", "EC", "GGA") returns
+// "$GPECQ,GGA*2D". It returns an error if any of them is not valid.
+func BuildQuery(requester, destination, formatter string) (string, error) {
+	return Query{Requester: requester, Destination: destination, Formatter: formatter}.MarshalNMEA()
+}
+
+// ParseQuery parses a query sentence and returns a pointer to a Query struct (or an error if the
+// sentence is invalid). The sentence is parsed according to opts (see ParseOptions).
+func ParseQuery(s string, opts ...ParseOption) (*Query, error) {
+	p := &SegmentParser{}
+	if err := p.ParseWithOptions(s, opts...); err != nil {
+		return nil, err
+	}
+
+	return DecodeQuery(p)
+}
+
+// DecodeQuery decodes a query sentence from p, which must already have parsed the sentence (see
+// [SegmentParser.Parse]). It returns a pointer to a Query struct (or an error if the sentence is
+// invalid).
+func DecodeQuery(p *SegmentParser) (*Query, error) {
+	p.NameFields("Requester", "Formatter")
+	p.RequireStartDelimiter(ParametricDelimiter)
+	requester, destination := p.RequireQuery()
+	q := &Query{
+		Requester:   requester,
+		Destination: destination,
+		Formatter:   p.asFormatter(1),
+		TagBlock:    p.TagBlock(),
+	}
+
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// --- Private -----------------------------------------------------------------
+
+// asFormatter parses the sentence segment at the specified index as a sentence formatter (e.g.
+// "GGA") and returns it in upper case. With ParseOptions.CaseSensitive, it must be upper case.
+func (p *SegmentParser) asFormatter(i int8) string {
+	v, ok := p.Segment(i)
+	if !ok {
+		return ""
+	}
+
+	if !isFormatter(v) || (p.options.CaseSensitive && v != strings.ToUpper(v)) {
+		p.fail(&FieldError{
+			Segment:  i,
+			Expected: "formatter",
+			Message: fmt.Sprintf("must be a sentence formatter (three letters or digits, %s) "+
+				"but was \"%s\"", p.caseNote(), v),
+		})
+
+		return ""
+	}
+
+	return strings.ToUpper(p.keep(v))
+}
+
+// writeFormatter appends the sentence formatter f (e.g. "GGA") as the next segment. If it is not a
+// valid sentence formatter, the error is recorded.
+func (w *SegmentWriter) writeFormatter(f string) {
+	if w.err == nil && !isFormatter(f) {
+		w.setErr(fmt.Sprintf("must be a valid sentence formatter but was \"%s\"", f))
+
+		return
+	}
+
+	w.append(f)
+}
+
+// isFormatter reports whether s is a valid sentence formatter: three letters or digits.
+func isFormatter(s string) bool {
+	return len(s) == 3 && isAlphanumeric(s)
+}
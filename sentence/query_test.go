@@ -0,0 +1,158 @@
+package sentence
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	for _, vec := range []struct {
+		title, input string
+		expected     Query
+	}{
+		{
+			title:    "GPS Queries Chart Display",
+			input:    "$GPECQ,GGA*2D",
+			expected: Query{Requester: "GP", Destination: "EC", Formatter: "GGA"},
+		},
+		{
+			title:    "Lower Case",
+			input:    "$GPECQ,gga*0D",
+			expected: Query{Requester: "GP", Destination: "EC", Formatter: "GGA"},
+		},
+		{
+			title:    "Integrated Instrumentation Queries GPS",
+			input:    "$IIGPQ,RMC*36",
+			expected: Query{Requester: "II", Destination: "GP", Formatter: "RMC"},
+		},
+		{
+			title: "Tag Block",
+			input: `\s:r3669961*0F\$GPECQ,GGA*2D`,
+			expected: Query{
+				Requester: "GP", Destination: "EC", Formatter: "GGA",
+				TagBlock: &TagBlock{Source: "r3669961"},
+			},
+		},
+	} {
+		t.Run(vec.title, func(t *testing.T) {
+			actual, err := ParseQuery(vec.input)
+			if err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			}
+
+			if actual.Requester != vec.expected.Requester ||
+				actual.Destination != vec.expected.Destination ||
+				actual.Formatter != vec.expected.Formatter ||
+				(actual.TagBlock == nil) != (vec.expected.TagBlock == nil) {
+				t.Errorf("result should have been %+v but was %+v", vec.expected, *actual)
+			}
+		})
+	}
+}
+
+func TestParseQuery_badData(t *testing.T) {
+	for _, vec := range []struct {
+		title, input, errMsg string
+		opts                 []ParseOption
+	}{
+		{
+			title:  "Not a Query",
+			input:  "$GPECA,GGA*3D",
+			errMsg: "sentence segment [0] must be \"----Q\" (case insensitive) but was \"GPECA\"",
+		},
+		{
+			title: "Short Formatter",
+			input: "$GPECQ,GG*6C",
+			errMsg: "sentence segment [1] must be a sentence formatter (three letters or digits, " +
+				"case insensitive) but was \"GG\"",
+		},
+		{
+			title: "Lower Case Formatter",
+			input: "$GPECQ,gga*0D",
+			opts:  []ParseOption{WithCaseSensitive()},
+			errMsg: "sentence segment [1] must be a sentence formatter (three letters or digits, " +
+				"case sensitive) but was \"gga\"",
+		},
+		{
+			title:  "Missing Formatter",
+			input:  "$GPECQ*40",
+			errMsg: "sentence segment [1] is out of range",
+		},
+		{
+			title:  "Encapsulation Delimiter",
+			input:  "!GPECQ,GGA*2D",
+			errMsg: "sentence segment [0] " + (&BadStartDelimiterError{Expected: "$", Actual: "!"}).Error(),
+		},
+	} {
+		t.Run(vec.title, func(t *testing.T) {
+			if _, err := ParseQuery(vec.input, vec.opts...); err == nil || err.Error() != vec.errMsg {
+				t.Errorf("expected error %q but was %v", vec.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestParse_query(t *testing.T) {
+	s, err := Parse("$GPECQ,GGA*2D")
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+
+	expected := Query{Requester: "GP", Destination: "EC", Formatter: "GGA"}
+	if actual, ok := s.(*Query); !ok || *actual != expected {
+		t.Errorf("result should have been %+v but was %+v", expected, s)
+	}
+
+	var fieldErr *FieldError
+	if _, err := Parse("$GPECQ,GG*6C"); !errors.As(err, &fieldErr) || fieldErr.Field != "Formatter" {
+		t.Errorf("expected a *FieldError for the Formatter field but was %v", err)
+	}
+}
+
+func TestBuildQuery(t *testing.T) {
+	actual, err := BuildQuery("GP", "EC", "GGA")
+	if err != nil || actual != "$GPECQ,GGA*2D" {
+		t.Errorf("expected %q but was %q (error %v)", "$GPECQ,GGA*2D", actual, err)
+	}
+
+	for _, vec := range []struct{ requester, destination, formatter, errMsg string }{
+		{"G", "EC", "GGA", "sentence segment [0] must be a valid query sentence type but was \"GECQ\""},
+		{"GP", "EC", "GGAA", "sentence segment [1] must be a valid sentence formatter but was \"GGAA\""},
+		{"GP", "EC", "", "sentence segment [1] must be a valid sentence formatter but was \"\""},
+	} {
+		if _, err := BuildQuery(vec.requester, vec.destination, vec.formatter); err == nil ||
+			err.Error() != vec.errMsg {
+			t.Errorf("expected error %q but was %v", vec.errMsg, err)
+		}
+	}
+}
+
+func TestQuery_MarshalNMEA_roundTrips(t *testing.T) {
+	q, err := ParseQuery(`\s:r3669961*0F\$GPECQ,GGA*2D`)
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+
+	if actual, err := q.MarshalNMEA(); err != nil || actual != `\s:r3669961*0F\$GPECQ,GGA*2D` {
+		t.Errorf("expected the query to round-trip but was %q (error %v)", actual, err)
+	}
+}
+
+func TestQuery_MarshalJSON_roundTrips(t *testing.T) {
+	q := Query{Requester: "GP", Destination: "EC", Formatter: "GGA"}
+	data, err := json.Marshal(q)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+
+	expected := `{"sentenceType":"GPECQ","requester":"GP","destination":"EC","formatter":"GGA"}`
+	if string(data) != expected {
+		t.Errorf("expected %s but was %s", expected, data)
+	}
+
+	var actual Query
+	if err := json.Unmarshal(data, &actual); err != nil || actual != q {
+		t.Errorf("round trip should have produced %+v but produced %+v (error %v)", q, actual, err)
+	}
+}
@@ -0,0 +1,196 @@
+package sentence
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+//go:generate go run github.com/mab-go/nmea/tools/nmeaschema raw
+
+// --- Public ------------------------------------------------------------------
+
+// RawSentence represents an NMEA sentence as a list of undecoded fields. Parse returns a
+// *RawSentence (and no error) for a sentence whose type has no registered Decoder, and ParseRaw
+// returns one for any sentence, so that tools which pass sentences through or log them need not
+// drop the ones that this module cannot decode.
+//
+// MarshalNMEA re-encodes a RawSentence unchanged, except that it writes a checksum if the sentence
+// had none.
+type RawSentence struct {
+	// StartDelimiter is the start delimiter of the sentence: ParametricDelimiter ("$") or
+	// EncapsulationDelimiter ("!").
+	StartDelimiter StartDelimiter `json:"startDelimiter"`
+
+	// TalkerID is the talker identifier of a standard sentence (e.g. "GP"). It is the first two
+	// characters of element [0], and is empty for a proprietary sentence.
+	TalkerID string `json:"talkerId,omitempty"`
+
+	// Manufacturer is the manufacturer code of a proprietary sentence (e.g. "GRM" for "$PGRME").
+	// It is the second to fourth characters of element [0], and is empty for a standard sentence.
+	Manufacturer string `json:"manufacturer,omitempty"`
+
+	// Formatter is the sentence formatter of a standard sentence (e.g. "RMC"), or the rest of
+	// element [0] after the manufacturer code of a proprietary sentence (e.g. "E" for "$PGRME",
+	// or "" for "$PUBX"). If element [0] is neither a standard nor a proprietary sentence type,
+	// Formatter holds all of it.
+	Formatter string `json:"formatter"`
+
+	// Fields are the raw values of elements [1], [2], ... of the sentence, in order.
+	Fields []string `json:"fields"`
+
+	// Checksum is the checksum of the sentence as it was written (e.g. "6F" or "6f"), or "" if it
+	// had none (see ParseOptions.ChecksumOptional). MarshalNMEA writes it as it is if it is still
+	// the checksum of the sentence, and computes the checksum anew otherwise.
+	Checksum string `json:"checksum,omitempty"`
+
+	// TagBlock is the tag block (see [TagBlock]) that preceded the sentence, or nil if there was
+	// none.
+	TagBlock *TagBlock `json:"tagBlock,omitzero"`
+
+	// Text is the sentence as it was parsed, including its tag block, or "" if the RawSentence
+	// was not parsed. It is not encoded by MarshalNMEA.
+	Text string `json:"text,omitempty"`
+}
+
+// GetSentenceType returns the type of the sentence (element [0]), e.g. "GPRMC" or "PGRME".
+func (r RawSentence) GetSentenceType() string {
+	if r.Manufacturer != "" {
+		return "P" + r.Manufacturer + r.Formatter
+	}
+
+	return r.TalkerID + r.Formatter
+}
+
+// Talker returns the talker identifier of a standard sentence (e.g. "GP"), or "P" followed by the
+// manufacturer code of a proprietary sentence (e.g. "PGRM").
+func (r RawSentence) Talker() string {
+	if r.Manufacturer != "" {
+		return "P" + r.Manufacturer
+	}
+
+	return r.TalkerID
+}
+
+// MarshalNMEA encodes r as an NMEA sentence (including its checksum) made up of its sentence type
+// and its fields, e.g. "$GPRMC,183729,A,...*6F". Its checksum is written as in Checksum (e.g. in
+// lower case) unless the start delimiter, the sentence type or the fields have since been
+// changed, and its tag block, if any, is written exactly as it appears in Text unless TagBlock has
+// since been changed.
+func (r RawSentence) MarshalNMEA() (string, error) {
+	w := &SegmentWriter{}
+	if r.StartDelimiter != 0 {
+		w.WriteStartDelimiter(r.StartDelimiter)
+	}
+
+	w.WriteString(r.GetSentenceType())
+	for _, f := range r.Fields {
+		w.WriteString(f)
+	}
+
+	s, err := w.Sentence()
+	if err != nil {
+		return "", err
+	}
+
+	if n := len(s); strings.EqualFold(s[n-2:], r.Checksum) {
+		s = s[:n-2] + r.Checksum
+	}
+
+	if r.TagBlock == nil {
+		return s, nil
+	}
+
+	return r.tagBlockText() + s, nil
+}
+
+// MarshalJSON encodes r as a JSON object whose members are "sentenceType" (e.g. "GPRMC") and the
+// fields of r, named by their json struct tags. See MarshalSentenceJSON for the encoding of each
+// type of field, and raw.schema.json for its JSON Schema.
+func (r RawSentence) MarshalJSON() ([]byte, error) {
+	type fields RawSentence // Has the fields of RawSentence, but not its MarshalJSON method
+
+	return MarshalSentenceJSON(r.GetSentenceType(), fields(r))
+}
+
+// UnmarshalJSON decodes a JSON object encoded by MarshalJSON into r, which is only modified if
+// data is valid. Its "sentenceType" member may be omitted.
+func (r *RawSentence) UnmarshalJSON(data []byte) error {
+	type fields RawSentence
+
+	var decoded RawSentence
+	if err := UnmarshalSentenceJSON(data, (*fields)(&decoded), &decoded); err != nil {
+		return err
+	}
+
+	*r = decoded
+
+	return nil
+}
+
+// Ensure that RawSentence properly implements the NMEASentence, Marshaler and JSON interfaces
+var (
+	_ NMEASentence     = RawSentence{}
+	_ Marshaler        = RawSentence{}
+	_ json.Marshaler   = RawSentence{}
+	_ json.Unmarshaler = (*RawSentence)(nil)
+)
+
+// ParseRaw parses an NMEA sentence of any type, registered or not, and returns a pointer to a
+// RawSentence struct (or an error if the sentence is malformed or its checksum is invalid). The
+// sentence is parsed according to opts (see ParseOptions).
+func ParseRaw(s string, opts ...ParseOption) (*RawSentence, error) {
+	p := &SegmentParser{}
+	if err := p.ParseWithOptions(s, opts...); err != nil {
+		return nil, err
+	}
+
+	return DecodeRaw(p)
+}
+
+// DecodeRaw decodes the sentence that p has already parsed (see [SegmentParser.Parse]) as a
+// RawSentence, without interpreting its fields. It returns an error only if p.Err() is not nil.
+func DecodeRaw(p *SegmentParser) (*RawSentence, error) {
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+
+	r := &RawSentence{
+		StartDelimiter: p.delimiter,
+		Fields:         make([]string, len(p.segments)-1),
+		TagBlock:       p.tagBlock,
+		Text:           p.keep(p.sentence),
+	}
+
+	sentenceType := p.keep(p.segments[0])
+	if _, _, err := SplitProprietaryType(sentenceType); err == nil {
+		r.Manufacturer, r.Formatter = sentenceType[1:4], sentenceType[4:]
+	} else if _, _, err := SplitSentenceType(sentenceType); err == nil {
+		r.TalkerID, r.Formatter = sentenceType[:2], sentenceType[2:]
+	} else {
+		r.Formatter = sentenceType
+	}
+
+	for i, f := range p.segments[1:] {
+		r.Fields[i] = p.keep(f)
+	}
+
+	if n := len(p.sentence); n >= 3 && p.sentence[n-3] == '*' {
+		r.Checksum = p.keep(p.sentence[n-2:])
+	}
+
+	return r, nil
+}
+
+// --- Private -----------------------------------------------------------------
+
+// tagBlockText returns the tag block of r (which must not be nil) as it appears in r.Text if
+// r.TagBlock is still the tag block parsed from it, or as encoded by TagBlock.String otherwise.
+func (r RawSentence) tagBlockText() string {
+	if original, _ := cutTagBlock(r.Text); original != "" {
+		if tb, err := ParseTagBlock(original); err == nil && *tb == *r.TagBlock {
+			return original
+		}
+	}
+
+	return r.TagBlock.String()
+}
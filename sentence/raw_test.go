@@ -0,0 +1,204 @@
+package sentence
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseRaw(t *testing.T) {
+	for title, vec := range map[string]struct {
+		input    string
+		expected RawSentence
+	}{
+		"Standard": {
+			input: "$GPRMC,183729,A,3907.356,N,12102.482,W,000.0,360.0,080301,015.5,E*6F",
+			expected: RawSentence{
+				StartDelimiter: ParametricDelimiter,
+				TalkerID:       "GP",
+				Formatter:      "RMC",
+				Fields: []string{"183729", "A", "3907.356", "N", "12102.482", "W", "000.0",
+					"360.0", "080301", "015.5", "E"},
+				Checksum: "6F",
+			},
+		},
+		"Proprietary": {
+			input: "$PGRME,15.0,M,45.0,M,25.0,M*1C",
+			expected: RawSentence{
+				StartDelimiter: ParametricDelimiter,
+				Manufacturer:   "GRM",
+				Formatter:      "E",
+				Fields:         []string{"15.0", "M", "45.0", "M", "25.0", "M"},
+				Checksum:       "1C",
+			},
+		},
+		"Encapsulation": {
+			input: "!AIVDO,1,1,,,B00000000868rR2Ik`Qw3wwUoP06,0*38",
+			expected: RawSentence{
+				StartDelimiter: EncapsulationDelimiter,
+				TalkerID:       "AI",
+				Formatter:      "VDO",
+				Fields:         []string{"1", "1", "", "", "B00000000868rR2Ik`Qw3wwUoP06", "0"},
+				Checksum:       "38",
+			},
+		},
+		"Malformed Type": {
+			input: "$GPTSTX,183730*3E",
+			expected: RawSentence{
+				StartDelimiter: ParametricDelimiter,
+				Formatter:      "GPTSTX",
+				Fields:         []string{"183730"},
+				Checksum:       "3E",
+			},
+		},
+		"Tag Block": {
+			input: `\s:r3669961*0F\$GPTST,183730*66`,
+			expected: RawSentence{
+				StartDelimiter: ParametricDelimiter,
+				TalkerID:       "GP",
+				Formatter:      "TST",
+				Fields:         []string{"183730"},
+				Checksum:       "66",
+				TagBlock:       &TagBlock{Source: "r3669961"},
+			},
+		},
+	} {
+		t.Run(title, func(t *testing.T) {
+			actual, err := ParseRaw(vec.input)
+			if err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			}
+
+			expected := vec.expected
+			expected.Text = vec.input
+			if !reflect.DeepEqual(*actual, expected) {
+				t.Errorf("result should have been %+v but was %+v", expected, *actual)
+			}
+
+			if encoded, err := actual.MarshalNMEA(); err != nil || encoded != vec.input {
+				t.Errorf("expected %q to be re-encoded unchanged but was %q (error %v)", vec.input,
+					encoded, err)
+			}
+		})
+	}
+
+	t.Run("No Checksum", func(t *testing.T) {
+		actual, err := ParseRaw("$GPXYZ,1,2", WithChecksumOptional())
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		if actual.Checksum != "" || len(actual.Fields) != 2 {
+			t.Errorf("expected two fields and no checksum but was %+v", *actual)
+		}
+	})
+
+	t.Run("Invalid Checksum", func(t *testing.T) {
+		if _, err := ParseRaw("$GPXYZ,1,2*00"); !errors.Is(err, ErrChecksumMismatch) {
+			t.Errorf("expected a checksum error but was %v", err)
+		}
+	})
+}
+
+func TestRawSentence_MarshalNMEA_tagBlock(t *testing.T) {
+	for title, input := range map[string]string{
+		"Unrecognised Parameter": `\x:1,s:foo,c:01503394200,y:2*63\$GPXYZ,1,2*4F`,
+		"Lower Case Checksum":    `\s:r3669961*0f\$GPXYZ,1,2*4f`,
+	} {
+		t.Run(title, func(t *testing.T) {
+			s, err := Parse(input)
+			if err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			}
+
+			raw, ok := s.(*RawSentence)
+			if !ok {
+				t.Fatalf("expected a *RawSentence but was %T", s)
+			}
+
+			if encoded, err := raw.MarshalNMEA(); err != nil || encoded != input {
+				t.Errorf("expected %q to be re-encoded unchanged but was %q (error %v)", input,
+					encoded, err)
+			}
+		})
+	}
+
+	t.Run("Changed", func(t *testing.T) {
+		raw, err := ParseRaw(`\x:1,s:foo,c:01503394200,y:2*63\$GPXYZ,1,2*4F`)
+		if err != nil {
+			t.Fatalf("ParseRaw failed: %v", err)
+		}
+
+		raw.TagBlock.Source = "bar"
+		expected := `\x:1,s:bar,c:01503394200,y:2*74\$GPXYZ,1,2*4F`
+		if encoded, err := raw.MarshalNMEA(); err != nil || encoded != expected {
+			t.Errorf("expected %q but was %q (error %v)", expected, encoded, err)
+		}
+	})
+}
+
+func TestRawSentence_MarshalNMEA_checksum(t *testing.T) {
+	raw, err := ParseRaw("$GPXYZ,1,2*4f")
+	if err != nil {
+		t.Fatalf("ParseRaw failed: %v", err)
+	}
+
+	if encoded, err := raw.MarshalNMEA(); err != nil || encoded != raw.Text {
+		t.Errorf("expected %q to be re-encoded unchanged but was %q (error %v)", raw.Text, encoded,
+			err)
+	}
+
+	raw.Fields[1] = "3"
+	expected := "$GPXYZ,1,3*4E"
+	if encoded, err := raw.MarshalNMEA(); err != nil || encoded != expected {
+		t.Errorf("expected %q but was %q (error %v)", expected, encoded, err)
+	}
+}
+
+func TestParseBytes_unknown(t *testing.T) {
+	b := []byte("$GPXYZ,1,2*4F")
+	s, err := ParseBytes(b)
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+
+	copy(b, "$GPABC,3,4")
+	if raw, ok := s.(*RawSentence); !ok || raw.GetSentenceType() != "GPXYZ" ||
+		raw.Fields[0] != "1" || raw.Text != "$GPXYZ,1,2*4F" {
+		t.Errorf("expected a *RawSentence that does not refer to b but was %+v", s)
+	}
+}
+
+func TestRawSentence_MarshalJSON_roundTrips(t *testing.T) {
+	raw, err := ParseRaw("!AIVDO,1,1,,,B00000000868rR2Ik`Qw3wwUoP06,0*38")
+	if err != nil {
+		t.Fatalf("ParseRaw failed: %v", err)
+	}
+
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+
+	var actual RawSentence
+	if err := json.Unmarshal(encoded, &actual); err != nil {
+		t.Fatalf("error decoding %s: %v", encoded, err)
+	}
+
+	if !reflect.DeepEqual(actual, *raw) {
+		t.Errorf("round trip through %s should have produced %+v but produced %+v", encoded, *raw,
+			actual)
+	}
+}
+
+func TestRawSentence_Talker(t *testing.T) {
+	for expected, raw := range map[string]RawSentence{
+		"GP":   {TalkerID: "GP", Formatter: "RMC"},
+		"PGRM": {Manufacturer: "GRM", Formatter: "E"},
+	} {
+		if actual := raw.Talker(); actual != expected {
+			t.Errorf("expected %q but was %q", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package sentence
+
+import (
+	"strings"
+	"sync"
+)
+
+// --- Public ------------------------------------------------------------------
+
+// Decoder decodes an NMEA sentence that has already been split into segments (and had its checksum
+// verified) by a SegmentParser. Sentence packages register a Decoder for each sentence formatter
+// they support so that Parse can dispatch to it.
+type Decoder func(p *SegmentParser) (NMEASentence, error)
+
+// Register makes a Decoder available to Parse for the specified parametric sentence formatter (the
+// last three characters of element [0] of an NMEA sentence, e.g. "GGA"). The Decoder is used for
+// sentences from every talker, so a single registration handles "$GPGGA", "$GNGGA", "$GLGGA" and so
+// on. Formatters are matched case-insensitively. Register is typically called from the init
+// function of a sentence package; it panics if dec is nil or if a Decoder has already been
+// registered for the formatter.
+func Register(formatter string, dec Decoder) {
+	register("Register", formatter, ParametricDelimiter, dec)
+}
+
+// RegisterEncapsulated is like Register, but for an encapsulation sentence formatter: one whose
+// sentences begin with the "!" start delimiter (e.g. "VDM" for "!AIVDM").
+func RegisterEncapsulated(formatter string, dec Decoder) {
+	register("RegisterEncapsulated", formatter, EncapsulationDelimiter, dec)
+}
+
+// RegisterProprietary makes a Decoder available to Parse for the proprietary sentences (those
+// whose element [0] begins with "P") of the specified manufacturer, e.g. "GRM" for Garmin's
+// "$PGRME" or "UBX" for u-blox's "$PUBX", and of the specified sub-type. A proprietary sentence's
+// sub-type is the rest of element [0] after the manufacturer code (e.g. "E" for "$PGRME" or "100"
+// for "$PSRF100") or, if there is none, element [1] (e.g. "00" for "$PUBX,00" or "GGK" for
+// "$PTNL,GGK"). A Decoder registered with an empty sub-type handles every sentence of the
+// manufacturer for which no Decoder is registered for its sub-type.
+//
+// Manufacturer codes and sub-types are matched case-insensitively. Proprietary sentences use the
+// ParametricDelimiter ("$"). RegisterProprietary panics if dec is nil, if manufacturer is not a
+// three-character code, or if a Decoder has already been registered for the manufacturer and
+// sub-type.
+func RegisterProprietary(manufacturer, subType string, dec Decoder) {
+	if dec == nil {
+		panic("sentence: RegisterProprietary decoder is nil for manufacturer " + manufacturer)
+	}
+
+	if len(manufacturer) != 3 || !isAlphanumeric(manufacturer) {
+		panic("sentence: RegisterProprietary manufacturer code must be 3 characters but was " +
+			manufacturer)
+	}
+
+	key := proprietaryKey{strings.ToUpper(manufacturer), strings.ToUpper(subType)}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, dup := registry.proprietary[key]; dup {
+		panic("sentence: RegisterProprietary called twice for manufacturer " + manufacturer +
+			" and sub-type " + subType)
+	}
+
+	registry.proprietary[key] = registryEntry{dec: dec, delimiter: ParametricDelimiter}
+}
+
+// Parse verifies the checksum of the specified NMEA sentence, reads its sentence type from element
+// [0], and decodes it with the Decoder registered for that type's sentence formatter. The returned
+// value is the sentence package's own type (e.g. *gga.GGA), so callers can use a type switch on
+// it; its Talker method reports the talker that sent it.
+//
+// A sentence must use the start delimiter that its formatter was registered with (see Register and
+// RegisterEncapsulated); if it does not, Parse returns a *FieldError for element [0] that wraps a
+// *BadStartDelimiterError.
+//
+// A proprietary sentence (one whose element [0] begins with "P") is decoded with the Decoder
+// registered for its manufacturer and sub-type (see RegisterProprietary). A query sentence (e.g.
+// "$GPECQ,GGA") whose sentence type is not a registered formatter is decoded as a *Query.
+//
+// Only sentence packages that have been imported (and have therefore registered their decoders)
+// are known to Parse. If element [0] is not a standard or proprietary sentence type, or if no
+// Decoder is registered for it, Parse returns the sentence as a *RawSentence (see DecodeRaw) and a
+// nil error, so that a caller which passes sentences through need not drop it; a caller that
+// handles only known types can tell it apart with a type switch.
+func Parse(s string) (NMEASentence, error) {
+	return ParseWithOptions(s)
+}
+
+// ParseWithOptions is like Parse, but parses the sentence according to opts (see ParseOptions),
+// which are also available to the Decoder through [SegmentParser.Options]. With no options, it is
+// identical to Parse.
+func ParseWithOptions(s string, opts ...ParseOption) (NMEASentence, error) {
+	p := &SegmentParser{}
+	if err := p.ParseWithOptions(s, opts...); err != nil {
+		return nil, err
+	}
+
+	return decode(p)
+}
+
+// ParseBytes is like ParseWithOptions, but parses a sentence held in a byte slice (see
+// [SegmentParser.ParseBytes]). The returned sentence does not refer to b.
+func ParseBytes(b []byte, opts ...ParseOption) (NMEASentence, error) {
+	p := &SegmentParser{}
+	if err := p.ParseBytes(b, opts...); err != nil {
+		return nil, err
+	}
+
+	return decode(p)
+}
+
+// --- Private -----------------------------------------------------------------
+
+// decode decodes the sentence that p has parsed with the Decoder registered for its type; see
+// Parse.
+func decode(p *SegmentParser) (NMEASentence, error) {
+	sentenceType := p.AsString(0)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+
+	entry, ok := lookupDecoder(p, sentenceType)
+	if !ok {
+		return DecodeRaw(p)
+	}
+
+	if p.RequireStartDelimiter(entry.delimiter); p.Err() != nil {
+		return nil, p.Err()
+	}
+
+	return entry.dec(p)
+}
+
+// registryEntry is a registered Decoder together with the start delimiter its sentences must use.
+type registryEntry struct {
+	dec       Decoder
+	delimiter StartDelimiter
+}
+
+// proprietaryKey identifies the Decoder of a proprietary sentence (see RegisterProprietary).
+type proprietaryKey struct {
+	manufacturer, subType string
+}
+
+var registry = struct {
+	mu          sync.RWMutex
+	decoders    map[string]registryEntry
+	proprietary map[proprietaryKey]registryEntry
+}{
+	decoders:    make(map[string]registryEntry),
+	proprietary: make(map[proprietaryKey]registryEntry),
+}
+
+func register(caller, formatter string, delimiter StartDelimiter, dec Decoder) {
+	if dec == nil {
+		panic("sentence: " + caller + " decoder is nil for sentence formatter " + formatter)
+	}
+
+	key := strings.ToUpper(formatter)
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if _, dup := registry.decoders[key]; dup {
+		panic("sentence: " + caller + " called twice for sentence formatter " + formatter)
+	}
+
+	registry.decoders[key] = registryEntry{dec: dec, delimiter: delimiter}
+}
+
+// lookupDecoder returns the registered Decoder for the sentence type (element [0]) of the sentence
+// that p has parsed.
+func lookupDecoder(p *SegmentParser, sentenceType string) (registryEntry, bool) {
+	if manufacturer, rest, err := SplitProprietaryType(sentenceType); err == nil {
+		return lookupProprietaryDecoder(manufacturer, p.proprietarySubType(rest))
+	}
+
+	_, formatter, err := SplitSentenceType(sentenceType)
+	if err != nil {
+		return registryEntry{}, false
+	}
+
+	registry.mu.RLock()
+	entry, ok := registry.decoders[formatter]
+	registry.mu.RUnlock()
+
+	if !ok && isQueryType(sentenceType) {
+		return queryEntry, true
+	}
+
+	return entry, ok
+}
+
+// queryEntry is the registryEntry with which Parse decodes a query sentence (see Query).
+var queryEntry = registryEntry{
+	dec: func(p *SegmentParser) (NMEASentence, error) {
+		q, err := DecodeQuery(p)
+		if err != nil {
+			return nil, err
+		}
+
+		return q, nil
+	},
+	delimiter: ParametricDelimiter,
+}
+
+// isQueryType reports whether sentenceType is a query sentence type (see SplitQueryType).
+func isQueryType(sentenceType string) bool {
+	_, _, err := SplitQueryType(sentenceType)
+
+	return err == nil
+}
+
+func lookupProprietaryDecoder(manufacturer, subType string) (registryEntry, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	key := proprietaryKey{manufacturer, strings.ToUpper(subType)}
+	if entry, ok := registry.proprietary[key]; ok {
+		return entry, true
+	}
+
+	entry, ok := registry.proprietary[proprietaryKey{manufacturer, ""}]
+
+	return entry, ok
+}
@@ -0,0 +1,357 @@
+package sentence
+
+import (
+	"errors"
+	"testing"
+)
+
+// testSentence is a minimal NMEASentence (formatter "TST") used to exercise the registry without
+// importing any of the sentence packages (which would create an import cycle).
+type testSentence struct {
+	TalkerID string
+	FixTime  NMEATime
+}
+
+func (s testSentence) GetSentenceType() string {
+	return s.TalkerID + "TST"
+}
+
+func (s testSentence) Talker() string {
+	return s.TalkerID
+}
+
+// testEncapsulated is a minimal encapsulation NMEASentence (formatter "TSE"), whose sentences use
+// the "!" start delimiter.
+type testEncapsulated struct {
+	TalkerID string
+	Payload  string
+}
+
+func (s testEncapsulated) GetSentenceType() string {
+	return s.TalkerID + "TSE"
+}
+
+func (s testEncapsulated) Talker() string {
+	return s.TalkerID
+}
+
+// testProprietary is a minimal proprietary NMEASentence (manufacturer "TST").
+type testProprietary struct {
+	SentenceType string
+	SubType      string
+	Value        int8
+	Specific     bool // Whether it was decoded by the Decoder registered for sub-type "A"
+}
+
+func (s testProprietary) GetSentenceType() string {
+	return s.SentenceType
+}
+
+func (s testProprietary) Talker() string {
+	return "PTST"
+}
+
+func decodeTestProprietary(specific bool) Decoder {
+	return func(p *SegmentParser) (NMEASentence, error) {
+		i := int8(1) // The segment that holds the value, after any sub-type segment
+		if _, rest, _ := SplitProprietaryType(p.AsString(0)); rest == "" {
+			i = 2
+		}
+
+		s := &testProprietary{
+			SentenceType: p.AsString(0),
+			SubType:      p.RequireProprietary("TST", ""),
+			Value:        p.AsInt8(i),
+			Specific:     specific,
+		}
+		if err := p.Err(); err != nil {
+			return nil, err
+		}
+
+		return s, nil
+	}
+}
+
+func init() {
+	RegisterProprietary("TST", "", decodeTestProprietary(false))
+	RegisterProprietary("tst", "a", decodeTestProprietary(true))
+
+	Register("TST", func(p *SegmentParser) (NMEASentence, error) {
+		s := &testSentence{
+			TalkerID: p.RequireFormatter("TST"),
+			FixTime:  p.AsNMEATime(1),
+		}
+		if err := p.Err(); err != nil {
+			return nil, err
+		}
+
+		return s, nil
+	})
+
+	RegisterEncapsulated("TSE", func(p *SegmentParser) (NMEASentence, error) {
+		s := &testEncapsulated{
+			TalkerID: p.RequireFormatter("TSE"),
+			Payload:  p.AsString(1),
+		}
+		if err := p.Err(); err != nil {
+			return nil, err
+		}
+
+		return s, nil
+	})
+}
+
+func TestParse(t *testing.T) {
+	for _, vec := range []struct{ input, talker string }{
+		{input: "$GPTST,183730*66", talker: "GP"},
+		{input: "$GNTST,183730*78", talker: "GN"},
+	} {
+		t.Run("Registered Type ("+vec.talker+")", func(t *testing.T) {
+			s, err := Parse(vec.input)
+			if err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			}
+
+			ts, ok := s.(*testSentence)
+			if !ok {
+				t.Fatalf("expected a *testSentence but was %T", s)
+			}
+
+			if ts.Talker() != vec.talker {
+				t.Errorf("expected Talker %q but was %q", vec.talker, ts.Talker())
+			}
+
+			expected := NMEATime{Hour: 18, Minute: 37, Second: 30, FractionDigits: NoFractionDigits}
+			if ts.FixTime != expected {
+				t.Errorf("expected FixTime %v but was %v", expected, ts.FixTime)
+			}
+		})
+	}
+
+	t.Run("Unknown Type", func(t *testing.T) {
+		input := "$GPRMC,183729,A,3907.356,N,12102.482,W,000.0,360.0,080301,015.5,E*6F"
+		s, err := Parse(input)
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		if raw, ok := s.(*RawSentence); !ok || raw.Text != input || raw.GetSentenceType() != "GPRMC" {
+			t.Errorf("expected a *RawSentence of %q but was %v", input, s)
+		}
+	})
+
+	t.Run("Malformed Type", func(t *testing.T) {
+		s, err := Parse("$GPTSTX,183730*3E")
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		if raw, ok := s.(*RawSentence); !ok || raw.Formatter != "GPTSTX" {
+			t.Errorf("expected a *RawSentence with formatter %q but was %+v", "GPTSTX", s)
+		}
+	})
+
+	t.Run("Invalid Checksum", func(t *testing.T) {
+		_, err := Parse("$GPTST,183730*00")
+		if err == nil {
+			t.Fatal("expected an error but got nil")
+		}
+
+		if !errors.Is(err, ErrChecksumMismatch) {
+			t.Errorf("expected a checksum error but was %v", err)
+		}
+	})
+
+	t.Run("Wrong Start Delimiter", func(t *testing.T) {
+		_, err := Parse("!GPTST,183730*66")
+		expected := "sentence segment [0] must be preceded by start delimiter \"$\" but was preceded by \"!\""
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected error %q but was %v", expected, err)
+		}
+	})
+
+	t.Run("Encapsulated Type", func(t *testing.T) {
+		s, err := Parse("!AITSE,15MwkT1P37G?fl0EJbR0OwT0@MS*4D")
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		expected := testEncapsulated{TalkerID: "AI", Payload: "15MwkT1P37G?fl0EJbR0OwT0@MS"}
+		if ts, ok := s.(*testEncapsulated); !ok || *ts != expected {
+			t.Errorf("expected %+v but was %+v", expected, s)
+		}
+	})
+
+	t.Run("Wrong Encapsulated Start Delimiter", func(t *testing.T) {
+		_, err := Parse("$AITSE,15MwkT1P37G?fl0EJbR0OwT0@MS*4D")
+		expected := "sentence segment [0] must be preceded by start delimiter \"!\" but was preceded by \"$\""
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected error %q but was %v", expected, err)
+		}
+	})
+
+	t.Run("Decoder Error", func(t *testing.T) {
+		_, err := Parse("$GPTST,bad_FixTime*32")
+		expected := "sentence segment [1] must be parsable as an NMEATime but was \"bad_FixTime\""
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected error %q but was %v", expected, err)
+		}
+	})
+}
+
+func TestParse_proprietary(t *testing.T) {
+	for title, vec := range map[string]struct {
+		input    string
+		expected testProprietary
+	}{
+		"Sub-Type in Sentence Type": {
+			input:    "$PTSTA,1*5F",
+			expected: testProprietary{SentenceType: "PTSTA", SubType: "A", Value: 1, Specific: true},
+		},
+		"Sub-Type in Segment 1": {
+			input:    "$PTST,A,1*73",
+			expected: testProprietary{SentenceType: "PTST", SubType: "A", Value: 1, Specific: true},
+		},
+		"Other Sub-Type": {
+			input:    "$PTSTB,1*5C",
+			expected: testProprietary{SentenceType: "PTSTB", SubType: "B", Value: 1},
+		},
+		"Other Sub-Type in Segment 1": {
+			input:    "$PTST,00,1*32",
+			expected: testProprietary{SentenceType: "PTST", SubType: "00", Value: 1},
+		},
+	} {
+		t.Run(title, func(t *testing.T) {
+			s, err := Parse(vec.input)
+			if err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			}
+
+			if actual, ok := s.(*testProprietary); !ok || *actual != vec.expected {
+				t.Errorf("result should have been %+v but was %+v", vec.expected, s)
+			}
+		})
+	}
+
+	t.Run("Unknown Manufacturer", func(t *testing.T) {
+		s, err := Parse("$PABC,1*0D")
+		if err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+
+		if raw, ok := s.(*RawSentence); !ok || raw.Manufacturer != "ABC" {
+			t.Errorf("expected a *RawSentence from manufacturer %q but was %+v", "ABC", s)
+		}
+	})
+
+	t.Run("Wrong Start Delimiter", func(t *testing.T) {
+		if _, err := Parse("!PTSTA,1*5F"); !errors.Is(err, ErrBadStartDelimiter) {
+			t.Errorf("expected a start delimiter error but was %v", err)
+		}
+	})
+
+	t.Run("Field Error", func(t *testing.T) {
+		expected := "sentence segment [2] must be parsable as an int8 but was \"X\""
+		if _, err := Parse("$PTST,00,X*5B"); err == nil || err.Error() != expected {
+			t.Errorf("expected error %q but was %v", expected, err)
+		}
+	})
+}
+
+func TestParseWithOptions(t *testing.T) {
+	if _, err := Parse("$GPTST,183730"); err == nil {
+		t.Fatal("expected Parse to reject a sentence without a checksum")
+	}
+
+	s, err := ParseWithOptions("$GPTST,183730", WithChecksumOptional())
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+
+	expected := testSentence{TalkerID: "GP", FixTime: NMEATime{Hour: 18, Minute: 37, Second: 30, FractionDigits: NoFractionDigits}}
+	if actual, ok := s.(*testSentence); !ok || *actual != expected {
+		t.Errorf("result should have been %+v but was %+v", expected, s)
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	line := []byte("$GNTST,183730*78")
+
+	s, err := ParseBytes(line)
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+
+	copy(line, "$GPTST,000000*00")
+
+	expected := testSentence{TalkerID: "GN", FixTime: NMEATime{Hour: 18, Minute: 37, Second: 30, FractionDigits: NoFractionDigits}}
+	if actual, ok := s.(*testSentence); !ok || *actual != expected {
+		t.Errorf("result should have been %+v but was %+v", expected, s)
+	}
+
+	if _, err := ParseBytes([]byte("$GPRMC,183729*00")); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("expected a checksum mismatch but was %v", err)
+	}
+}
+
+func TestRegister_panics(t *testing.T) {
+	t.Run("Nil Decoder", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected Register to panic but it did not")
+			}
+		}()
+
+		Register("NIL", nil)
+	})
+
+	t.Run("Duplicate Formatter", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected Register to panic but it did not")
+			}
+		}()
+
+		Register("tst", func(*SegmentParser) (NMEASentence, error) { return nil, nil })
+	})
+
+	t.Run("Duplicate Encapsulated Formatter", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected RegisterEncapsulated to panic but it did not")
+			}
+		}()
+
+		RegisterEncapsulated("TST", func(*SegmentParser) (NMEASentence, error) { return nil, nil })
+	})
+
+	t.Run("Nil Proprietary Decoder", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected RegisterProprietary to panic but it did not")
+			}
+		}()
+
+		RegisterProprietary("NIL", "", nil)
+	})
+
+	t.Run("Bad Manufacturer Code", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected RegisterProprietary to panic but it did not")
+			}
+		}()
+
+		RegisterProprietary("GRMX", "", func(*SegmentParser) (NMEASentence, error) { return nil, nil })
+	})
+
+	t.Run("Duplicate Proprietary Sub-Type", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected RegisterProprietary to panic but it did not")
+			}
+		}()
+
+		RegisterProprietary("Tst", "A", func(*SegmentParser) (NMEASentence, error) { return nil, nil })
+	})
+}
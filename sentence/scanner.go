@@ -0,0 +1,186 @@
+package sentence
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// --- Public ------------------------------------------------------------------
+
+// MaxSentenceLength is the maximum length of an NMEA 0183 sentence, including its start delimiter
+// and its terminating <CR><LF>.
+const MaxSentenceLength = 82
+
+// ErrSentenceTooLong is reported (wrapped, together with the limit) by Scanner for a line that
+// exceeds MaxSentenceLength, and by ParseWithOptions for a sentence that exceeds
+// ParseOptions.MaxLength.
+var ErrSentenceTooLong = errors.New("sentence exceeds the maximum length")
+
+// Scanner reads NMEA sentences from a byte stream such as a serial port, a TCP connection or a log
+// file. Like bufio.Scanner, successive calls to Scan step through the sentences in the stream.
+//
+// Scanner is tolerant of real-world feeds: anything that precedes a start delimiter ("$" or "!") or
+// a tag block, such as a partial line at start-up or binary noise, is discarded; a start delimiter
+// always begins a new sentence (even if the previous one was not terminated); CR and LF are
+// stripped; and sentences longer than MaxSentenceLength are cut short and reported. A tag block
+// (see [TagBlock]) is kept together with the sentence that follows it and does not count towards
+// its length. Problems with an individual
+// sentence are reported by LineErr and Sentence and never stop the stream; only a failure of the
+// underlying io.Reader does, and it is reported by Err.
+//
+// Message returns each sentence together with where and when it was received, as set by the
+// Scanner's Source and Clock.
+type Scanner struct {
+	// Source identifies the stream (e.g. "/dev/ttyUSB0"), for Message.Source.
+	Source string
+
+	// Clock returns the current time, at which Scan reads a sentence (see Message.ReceivedAt). If
+	// it is nil, time.Now is used.
+	Clock func() time.Time
+
+	scanner    *bufio.Scanner
+	text       string
+	tagBlock   *TagBlock
+	receivedAt time.Time
+	lineErr    error
+}
+
+// NewScanner returns a new Scanner that reads NMEA sentences from r.
+func NewScanner(r io.Reader) *Scanner {
+	s := &Scanner{scanner: bufio.NewScanner(r)}
+	s.scanner.Split(splitSentences)
+
+	return s
+}
+
+// Scan advances the Scanner to the next sentence, which is then available through Text, LineErr
+// and Sentence. It returns false when the stream ends or a read error occurs; after Scan returns
+// false, Err returns the read error (or nil if the stream ended normally).
+func (s *Scanner) Scan() bool {
+	if !s.scanner.Scan() {
+		s.text, s.tagBlock, s.receivedAt, s.lineErr = "", nil, time.Time{}, nil
+
+		return false
+	}
+
+	s.text, s.receivedAt = s.scanner.Text(), s.now()
+
+	tb, body, err := SplitTagBlock(s.text)
+	s.tagBlock = tb
+	switch {
+	case err != nil:
+		s.lineErr = err
+	case len(body) > maxSentenceContentLength:
+		s.lineErr = fmt.Errorf("%w of %d characters", ErrSentenceTooLong, MaxSentenceLength)
+	default:
+		s.lineErr = VerifyChecksum(body)
+	}
+
+	return true
+}
+
+// Text returns the most recent sentence read by Scan, without its line terminator. It is returned
+// verbatim, even if LineErr reports a problem with it.
+func (s *Scanner) Text() string {
+	return s.text
+}
+
+// LineErr returns the framing or checksum error (if any) for the most recent sentence read by
+// Scan, or nil if it is a well-formed sentence with a valid checksum (and, if it has one, a
+// well-formed tag block with a valid checksum).
+func (s *Scanner) LineErr() error {
+	return s.lineErr
+}
+
+// Sentence decodes the most recent sentence read by Scan using Parse. If the sentence is malformed
+// (see LineErr) or cannot be decoded, it returns a nil NMEASentence and the error; if its type is
+// unknown, it returns a *RawSentence, as Parse does.
+func (s *Scanner) Sentence() (NMEASentence, error) {
+	if s.lineErr != nil {
+		return nil, s.lineErr
+	}
+
+	return Parse(s.text)
+}
+
+// Message returns the most recent sentence read by Scan as a Message: decoded as by Sentence (with
+// its error), and with the time at which Scan read it and the Scanner's Source.
+func (s *Scanner) Message() Message {
+	m := Message{
+		Raw:        s.text,
+		ReceivedAt: s.receivedAt,
+		Source:     messageSource(s.Source, s.tagBlock),
+		TagBlock:   s.tagBlock,
+	}
+	m.Sentence, m.Err = s.Sentence()
+
+	return m
+}
+
+// Err returns the first non-EOF error that was encountered by the Scanner while reading from its
+// io.Reader.
+func (s *Scanner) Err() error {
+	return s.scanner.Err()
+}
+
+// --- Private -----------------------------------------------------------------
+
+// now returns the current time according to s.Clock.
+func (s *Scanner) now() time.Time {
+	if s.Clock == nil {
+		return time.Now()
+	}
+
+	return s.Clock()
+}
+
+// maxSentenceContentLength is MaxSentenceLength without the terminating <CR><LF>.
+const maxSentenceContentLength = MaxSentenceLength - 2
+
+// splitSentences is a bufio.SplitFunc that yields one candidate sentence (including any leading
+// tag block) per token. See Scanner for the framing rules.
+func splitSentences(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	start := bytes.IndexAny(data, startDelimiters+string(tagBlockDelimiter))
+	if start < 0 {
+		return len(data), nil, nil // Discard noise while waiting for a start delimiter
+	}
+
+	// A tag block's closing delimiter doesn't end the line; skip to the sentence that follows it
+	body := start
+	if data[start] == tagBlockDelimiter {
+		if end := bytes.IndexAny(data[start+1:], "\r\n"+string(tagBlockDelimiter)); end >= 0 &&
+			data[start+1+end] == tagBlockDelimiter {
+			body = start + 1 + end + 1
+		}
+	}
+
+	// The sentence ends at a line terminator or at the start of the next sentence
+	if body < len(data) {
+		end := bytes.IndexAny(data[body+1:], "\r\n"+startDelimiters+string(tagBlockDelimiter))
+		if end >= 0 {
+			end += body + 1
+			if data[end] == '\r' || data[end] == '\n' {
+				return end + 1, data[start:end], nil
+			}
+
+			return end, data[start:end], nil
+		}
+	}
+
+	// Don't wait forever for the end of an overlong line; cut it short so that it can be reported
+	if len(data)-body > maxSentenceContentLength {
+		end := body + maxSentenceContentLength + 1
+
+		return end, data[start:end], nil
+	}
+
+	if atEOF {
+		return len(data), data[start:], nil
+	}
+
+	return start, nil, nil // Request more data
+}
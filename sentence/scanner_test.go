@@ -0,0 +1,228 @@
+package sentence
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+type scannedLine struct {
+	text    string
+	lineErr error
+}
+
+func scanAll(t *testing.T, s *Scanner) []scannedLine {
+	t.Helper()
+
+	var lines []scannedLine
+	for s.Scan() {
+		lines = append(lines, scannedLine{text: s.Text(), lineErr: s.LineErr()})
+	}
+
+	if err := s.Err(); err != nil {
+		t.Fatalf("expected no stream error but got %v", err)
+	}
+
+	return lines
+}
+
+func TestScanner_framing(t *testing.T) {
+	overlong := "$GPTST," + strings.Repeat("9", 100)
+
+	for title, vec := range map[string]struct {
+		input    string
+		expected []string
+	}{
+		"CRLF Line Endings": {
+			input:    "$GPTST,183730*66\r\n$GNTST,183730*78\r\n",
+			expected: []string{"$GPTST,183730*66", "$GNTST,183730*78"},
+		},
+		"LF Line Endings": {
+			input:    "$GPTST,183730*66\n$GNTST,183730*78\n",
+			expected: []string{"$GPTST,183730*66", "$GNTST,183730*78"},
+		},
+		"No Final Line Ending": {
+			input:    "$GPTST,183730*66\r\n$GNTST,183730*78",
+			expected: []string{"$GPTST,183730*66", "$GNTST,183730*78"},
+		},
+		"Partial Line at Start-Up": {
+			input:    "730*66\r\n$GNTST,183730*78\r\n",
+			expected: []string{"$GNTST,183730*78"},
+		},
+		"Binary Noise": {
+			input:    "\x00\xff\xfe\x13$GPTST,183730*66\r\n\x7f\x80\x81$GNTST,183730*78\r\n",
+			expected: []string{"$GPTST,183730*66", "$GNTST,183730*78"},
+		},
+		"Unterminated Sentence": {
+			input:    "$GPTST,1837$GNTST,183730*78\r\n",
+			expected: []string{"$GPTST,1837", "$GNTST,183730*78"},
+		},
+		"Encapsulation Delimiter": {
+			input:    "!AIVDM,1,1,,B,15MwkT1P37G?fl0EJbR0OwT0@MS,0*0E\r\n",
+			expected: []string{"!AIVDM,1,1,,B,15MwkT1P37G?fl0EJbR0OwT0@MS,0*0E"},
+		},
+		"Tag Blocks": {
+			input: "\\s:r3669961,c:1503394200*71\\$GPTST,183730*66\r\n" +
+				"\\g:1-2-1234,s:SAT1,c:1503394200*36\\!AIVDM,1,1,,B,15MwkT1P37G?fl0EJbR0OwT0@MS,0*0E\r\n",
+			expected: []string{
+				"\\s:r3669961,c:1503394200*71\\$GPTST,183730*66",
+				"\\g:1-2-1234,s:SAT1,c:1503394200*36\\!AIVDM,1,1,,B,15MwkT1P37G?fl0EJbR0OwT0@MS,0*0E",
+			},
+		},
+		"Tag Block on Overlong Sentence": {
+			input: "\\s:r3669961,c:1503394200*71\\" + overlong + "\r\n$GNTST,183730*78\r\n",
+			expected: []string{
+				"\\s:r3669961,c:1503394200*71\\" + overlong[:maxSentenceContentLength+1],
+				"$GNTST,183730*78",
+			},
+		},
+		"Overlong Garbage": {
+			input:    overlong + "\r\n$GNTST,183730*78\r\n",
+			expected: []string{overlong[:maxSentenceContentLength+1], "$GNTST,183730*78"},
+		},
+		"Empty Stream": {
+			input: "",
+		},
+	} {
+		t.Run(title, func(t *testing.T) {
+			lines := scanAll(t, NewScanner(iotest.OneByteReader(strings.NewReader(vec.input))))
+			if len(lines) != len(vec.expected) {
+				t.Fatalf("expected %d sentences but got %d: %+v", len(vec.expected), len(lines), lines)
+			}
+
+			for i, line := range lines {
+				if line.text != vec.expected[i] {
+					t.Errorf("sentence %d should have been %q but was %q", i, vec.expected[i], line.text)
+				}
+			}
+		})
+	}
+}
+
+func TestScanner_LineErr(t *testing.T) {
+	input := "$GPTST,183730*66\r\n" +
+		"$GPTST,183730*00\r\n" +
+		"$GPTST," + strings.Repeat("9", 100) + "\r\n" +
+		"$GNTST,183730*78\r\n"
+
+	lines := scanAll(t, NewScanner(strings.NewReader(input)))
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 sentences but got %d: %+v", len(lines), lines)
+	}
+
+	if lines[0].lineErr != nil {
+		t.Errorf("expected no error for a valid sentence but got %v", lines[0].lineErr)
+	}
+
+	expected := "calculated checksum value \"66\" does not match sentence-specified value of \"00\""
+	if lines[1].lineErr == nil || lines[1].lineErr.Error() != expected {
+		t.Errorf("expected error %q but was %v", expected, lines[1].lineErr)
+	}
+
+	if !errors.Is(lines[2].lineErr, ErrSentenceTooLong) {
+		t.Errorf("expected ErrSentenceTooLong but was %v", lines[2].lineErr)
+	}
+
+	if lines[3].lineErr != nil {
+		t.Errorf("expected the stream to recover after an error but got %v", lines[3].lineErr)
+	}
+}
+
+func TestScanner_tagBlocks(t *testing.T) {
+	input := "\\s:r3669961,c:1503394200*71\\$GPTST,183730*66\r\n" +
+		"\\s:r3669961,c:1503394200*4A\\$GPTST,183730*66\r\n"
+
+	s := NewScanner(strings.NewReader(input))
+	lines := scanAll(t, s)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 sentences but got %d: %+v", len(lines), lines)
+	}
+
+	if lines[0].lineErr != nil {
+		t.Errorf("expected no error for a valid tag block but got %v", lines[0].lineErr)
+	}
+
+	if lines[1].lineErr == nil {
+		t.Error("expected an error for a tag block with an invalid checksum")
+	}
+}
+
+func TestScanner_Sentence(t *testing.T) {
+	s := NewScanner(strings.NewReader("$GPTST,183730*66\r\n$GPTST,183730*00\r\n$GNTST,183730*78\r\n"))
+
+	var talkers []string
+	var errs int
+	for s.Scan() {
+		decoded, err := s.Sentence()
+		if err != nil {
+			errs++
+
+			continue
+		}
+
+		talkers = append(talkers, decoded.Talker())
+	}
+
+	if errs != 1 {
+		t.Errorf("expected 1 per-line error but got %d", errs)
+	}
+
+	if strings.Join(talkers, ",") != "GP,GN" {
+		t.Errorf("expected sentences from talkers GP and GN but got %q", talkers)
+	}
+}
+
+func TestScanner_Err(t *testing.T) {
+	readErr := errors.New("serial port unplugged")
+	s := NewScanner(iotest.ErrReader(readErr))
+
+	if s.Scan() {
+		t.Error("expected Scan to return false on a read error")
+	}
+
+	if !errors.Is(s.Err(), readErr) {
+		t.Errorf("expected Err to return %v but was %v", readErr, s.Err())
+	}
+}
+
+func TestScanner_Message(t *testing.T) {
+	receivedAt := time.Date(2026, 3, 1, 18, 37, 30, 0, time.UTC)
+	s := NewScanner(strings.NewReader("$GPTST,183730*66\r\n\\s:r3669961*0F\\$GPXYZ,1,2*4F\r\n" +
+		"$GPTST,183730*00\r\n"))
+	s.Source = "/dev/ttyUSB0"
+	s.Clock = func() time.Time { return receivedAt }
+
+	var messages []Message
+	for s.Scan() {
+		messages = append(messages, s.Message())
+	}
+
+	if len(messages) != 3 {
+		t.Fatalf("expected 3 messages but got %d", len(messages))
+	}
+
+	for i, m := range messages {
+		if !m.ReceivedAt.Equal(receivedAt) || m.Source != "/dev/ttyUSB0" {
+			t.Errorf("message %d should have been received at %v from %q but was %+v", i,
+				receivedAt, s.Source, m)
+		}
+	}
+
+	if m := messages[0]; m.Err != nil || m.Sentence.Talker() != "GP" || m.Raw != "$GPTST,183730*66" {
+		t.Errorf("expected a decoded GPTST sentence but was %+v", m)
+	}
+
+	m := messages[1]
+	if _, ok := m.Sentence.(*RawSentence); !ok || m.Err != nil {
+		t.Errorf("expected a *RawSentence and no error but was %+v", m)
+	}
+	if m.TagBlock == nil || m.TagBlock.Source != "r3669961" {
+		t.Errorf("expected the tag block to be kept but was %v", m.TagBlock)
+	}
+
+	if m := messages[2]; m.Sentence != nil || !errors.Is(m.Err, ErrChecksumMismatch) {
+		t.Errorf("expected a checksum error but was %+v", m)
+	}
+}
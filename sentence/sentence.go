@@ -9,4 +9,10 @@ type NMEASentence interface {
 	// that implements this interface. It always represents element [0] of any
 	// NMEA sentence.
 	GetSentenceType() string
+
+	// Talker returns the talker identifier of the device that produced the sentence (e.g. "GP"
+	// for GPS or "GN" for a multi-constellation GNSS receiver). It is the first two characters of
+	// element [0] of a standard NMEA sentence. For a proprietary sentence (see RegisterProprietary),
+	// it is "P" followed by the manufacturer code, e.g. "PGRM".
+	Talker() string
 }
@@ -0,0 +1,331 @@
+package sentence
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// --- Public ------------------------------------------------------------------
+
+// TagBlock represents an IEC 61162-1 (NMEA 0183 v4) tag block: a set of parameters, enclosed in
+// backslashes and protected by its own checksum, that may precede a sentence on a network feed or
+// in a log, e.g. "\s:r3669961,c:1503394200*71\$GPGGA,...".
+//
+// Parameters that are absent from a tag block are left as their zero value. Parameters that have
+// no field (see Unrecognized) are kept, so that a parsed tag block whose fields are unchanged is
+// encoded by String exactly as it was received. Otherwise, zero-valued fields are omitted when the
+// tag block is encoded (as a tag block or as JSON), and unrecognised parameters are not included
+// in JSON.
+type TagBlock struct {
+	// Source identifies the device or station that sent the sentence. It is parameter "s".
+	Source string `json:"source,omitzero"`
+
+	// UnixTime is the time at which the sentence was received or sent, as a Unix timestamp (in
+	// seconds, although some feeds use milliseconds). It is parameter "c".
+	UnixTime int64 `json:"unixTime,omitzero"`
+
+	// Destination identifies the device or station to which the sentence is addressed. It is
+	// parameter "d".
+	Destination string `json:"destination,omitzero"`
+
+	// LineCount is a running count of the lines (sentences) sent by the source. It is parameter
+	// "n".
+	LineCount int64 `json:"lineCount,omitzero"`
+
+	// RelativeTime is a time relative to some source-defined reference, in milliseconds. It is
+	// parameter "r".
+	RelativeTime int64 `json:"relativeTime,omitzero"`
+
+	// Group identifies the sentence's position in a group of sentences that belong together (for
+	// example, the parts of a multi-sentence AIS message). It is parameter "g".
+	Group TagBlockGroup `json:"group,omitzero"`
+
+	// Text is free-form text. It is parameter "t".
+	Text string `json:"text,omitzero"`
+
+	// raw is the tag block, including its enclosing backslashes, from which ParseTagBlock parsed
+	// this one if String would not otherwise reproduce it (for example, because its parameters
+	// were in a different order, a zero value was present or a parameter was unrecognised), or ""
+	// otherwise. Leaving it empty whenever possible keeps such tag blocks equal to literals.
+	raw string
+}
+
+// TagBlockGroup represents the grouping parameter ("g") of a tag block, whose wire format is
+// "<sentence>-<total>-<id>", e.g. "1-2-1234".
+type TagBlockGroup struct {
+	// Sentence is the (1-based) number of this sentence within the group.
+	Sentence int `json:"sentence"`
+
+	// Total is the total number of sentences in the group.
+	Total int `json:"total"`
+
+	// ID identifies the group; it is shared by every sentence in the group.
+	ID int `json:"id"`
+}
+
+// ParseTagBlock parses a tag block, including its enclosing backslashes, e.g.
+// "\s:r3669961,c:1503394200*71\". It returns an error if the tag block is malformed or if its
+// checksum is invalid (a *MissingChecksumError, *MalformedChecksumError or *ChecksumMismatchError
+// whose TagBlock field is true).
+func ParseTagBlock(s string) (*TagBlock, error) {
+	if len(s) < 2 || s[0] != tagBlockDelimiter || s[len(s)-1] != tagBlockDelimiter {
+		return nil, fmt.Errorf("tag block must be enclosed in \"\\\" but was \"%s\"", s)
+	}
+
+	body := s[1 : len(s)-1]
+
+	star := strings.LastIndexByte(body, '*')
+	if star < 0 {
+		return nil, &MissingChecksumError{TagBlock: true}
+	}
+
+	if len(body)-star-1 != 2 {
+		return nil, &MalformedChecksumError{Remaining: len(body) - star - 1, TagBlock: true}
+	}
+
+	expectedHex := strings.ToUpper(body[star+1:])
+	calculatedHex := fmt.Sprintf("%02X", Checksum(body[:star]))
+	if calculatedHex != expectedHex {
+		return nil, &ChecksumMismatchError{
+			Expected:   expectedHex,
+			Calculated: calculatedHex,
+			TagBlock:   true,
+		}
+	}
+
+	tb := &TagBlock{}
+	for _, param := range strings.Split(body[:star], ",") {
+		if err := tb.parseParam(param); err != nil {
+			return nil, err
+		}
+	}
+
+	if tb.String() != s {
+		tb.raw = s
+	}
+
+	return tb, nil
+}
+
+// String encodes the tag block, including its enclosing backslashes and checksum, e.g.
+// "\s:r3669961,c:1503394200*71\".
+//
+// A tag block returned by ParseTagBlock is encoded exactly as it was parsed, unless its fields
+// have since been changed. Then its parameters are written in their original order, including
+// unrecognised and zero-valued ones, with the values of changed fields replaced and those of fields
+// that have been cleared (set to their zero value) omitted; fields that were absent but are now
+// set follow them. Any other tag block's parameters are written in the order
+// s, c, d, n, r, g, t; those whose field holds its zero value are omitted.
+func (tb TagBlock) String() string {
+	if tb.raw == "" {
+		var params []string
+		for _, code := range tagBlockCodes {
+			if value, set := tb.paramValue(code); set {
+				params = append(params, code+":"+value)
+			}
+		}
+
+		return encodeTagBlock(params)
+	}
+
+	parsed := TagBlock{}
+	for _, param := range tagBlockParams(tb.raw) {
+		_ = parsed.parseParam(param) // tb.raw has already been parsed successfully
+	}
+
+	current := tb
+	current.raw = ""
+	if current == parsed {
+		return tb.raw
+	}
+
+	var params []string
+	present := make(map[string]bool)
+	for _, param := range tagBlockParams(tb.raw) {
+		code, _, _ := strings.Cut(param, ":")
+		if !slices.Contains(tagBlockCodes, code) {
+			params = append(params, param)
+			continue
+		}
+
+		present[code] = true
+		value, set := tb.paramValue(code)
+		if original, _ := parsed.paramValue(code); value != original {
+			if !set {
+				continue // The field has been cleared
+			}
+
+			param = code + ":" + value
+		}
+
+		params = append(params, param)
+	}
+
+	for _, code := range tagBlockCodes {
+		if value, set := tb.paramValue(code); set && !present[code] {
+			params = append(params, code+":"+value)
+		}
+	}
+
+	return encodeTagBlock(params)
+}
+
+// Unrecognized returns the parameters of a tag block returned by ParseTagBlock that have no field
+// in TagBlock, in the form "<code>:<value>" and in the order in which they appeared, e.g.
+// ["x:99"]. It returns nil if there were none.
+func (tb TagBlock) Unrecognized() []string {
+	var unrecognized []string
+	for _, param := range tagBlockParams(tb.raw) {
+		if code, _, _ := strings.Cut(param, ":"); !slices.Contains(tagBlockCodes, code) {
+			unrecognized = append(unrecognized, param)
+		}
+	}
+
+	return unrecognized
+}
+
+// SplitTagBlock splits a line into its leading tag block (if any) and the sentence that follows
+// it. If the line does not start with a tag block, it returns a nil TagBlock and the line
+// unchanged; otherwise it returns the parsed TagBlock, or an error if the tag block is malformed.
+func SplitTagBlock(line string) (*TagBlock, string, error) {
+	raw, rest := cutTagBlock(line)
+	if raw == "" {
+		return nil, line, nil
+	}
+
+	tb, err := ParseTagBlock(raw)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return tb, rest, nil
+}
+
+// --- Private -----------------------------------------------------------------
+
+// tagBlockDelimiter encloses a tag block.
+const tagBlockDelimiter = '\\'
+
+// tagBlockCodes are the codes of the parameters that have a field in TagBlock, in the order in
+// which String writes them.
+var tagBlockCodes = []string{"s", "c", "d", "n", "r", "g", "t"}
+
+// tagBlockParams returns the parameters of raw, a tag block (including its enclosing backslashes)
+// that has already been parsed successfully, or nil if raw is "".
+func tagBlockParams(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+
+	body := raw[1 : len(raw)-1]
+
+	return strings.Split(body[:strings.LastIndexByte(body, '*')], ",")
+}
+
+// encodeTagBlock joins params into a tag block, including its enclosing backslashes and checksum.
+func encodeTagBlock(params []string) string {
+	body := strings.Join(params, ",")
+
+	return fmt.Sprintf("\\%s*%02X\\", body, Checksum(body))
+}
+
+// paramValue returns the value of the parameter with the specified code (one of tagBlockCodes) as
+// it is written in a tag block, and whether its field is set to other than its zero value.
+func (tb TagBlock) paramValue(code string) (value string, set bool) {
+	switch code {
+	case "s":
+		return tb.Source, tb.Source != ""
+	case "c":
+		return strconv.FormatInt(tb.UnixTime, 10), tb.UnixTime != 0
+	case "d":
+		return tb.Destination, tb.Destination != ""
+	case "n":
+		return strconv.FormatInt(tb.LineCount, 10), tb.LineCount != 0
+	case "r":
+		return strconv.FormatInt(tb.RelativeTime, 10), tb.RelativeTime != 0
+	case "g":
+		g := tb.Group
+		return fmt.Sprintf("%d-%d-%d", g.Sentence, g.Total, g.ID), g != (TagBlockGroup{})
+	case "t":
+		return tb.Text, tb.Text != ""
+	}
+
+	return "", false
+}
+
+// cutTagBlock splits a line into its raw leading tag block (including its enclosing backslashes)
+// and the remainder of the line. If the line does not start with a tag block, it returns "" and
+// the line unchanged.
+func cutTagBlock(line string) (raw, rest string) {
+	if line == "" || line[0] != tagBlockDelimiter {
+		return "", line
+	}
+
+	end := strings.IndexByte(line[1:], tagBlockDelimiter)
+	if end < 0 {
+		return line, ""
+	}
+
+	return line[:end+2], line[end+2:]
+}
+
+func (tb *TagBlock) parseParam(param string) error {
+	code, value, ok := strings.Cut(param, ":")
+	if !ok {
+		return fmt.Errorf("tag block parameter must be of the form \"<code>:<value>\" but was \"%s\"",
+			param)
+	}
+
+	var err error
+	switch code {
+	case "s":
+		tb.Source = value
+	case "c":
+		tb.UnixTime, err = parseTagBlockInt(code, value)
+	case "d":
+		tb.Destination = value
+	case "n":
+		tb.LineCount, err = parseTagBlockInt(code, value)
+	case "r":
+		tb.RelativeTime, err = parseTagBlockInt(code, value)
+	case "g":
+		tb.Group, err = parseTagBlockGroup(value)
+	case "t":
+		tb.Text = value
+	}
+
+	return err
+}
+
+func parseTagBlockInt(code, value string) (int64, error) {
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("tag block parameter \"%s\" must be parsable as an integer but was \"%s\"",
+			code, value)
+	}
+
+	return v, nil
+}
+
+func parseTagBlockGroup(value string) (TagBlockGroup, error) {
+	parts := strings.Split(value, "-")
+	if len(parts) == 3 {
+		var g TagBlockGroup
+		var errs [3]error
+		g.Sentence, errs[0] = strconv.Atoi(parts[0])
+		g.Total, errs[1] = strconv.Atoi(parts[1])
+		g.ID, errs[2] = strconv.Atoi(parts[2])
+
+		if errors.Join(errs[:]...) == nil {
+			return g, nil
+		}
+	}
+
+	return TagBlockGroup{}, fmt.Errorf(
+		"tag block parameter \"g\" must be of the form \"<sentence>-<total>-<id>\" but was \"%s\"",
+		value,
+	)
+}
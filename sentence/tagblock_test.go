@@ -0,0 +1,210 @@
+package sentence
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestParseTagBlock(t *testing.T) {
+	for title, vec := range map[string]struct {
+		input    string
+		expected TagBlock
+	}{
+		"Source and UNIX Time": {
+			input:    "\\s:r3669961,c:1503394200*71\\",
+			expected: TagBlock{Source: "r3669961", UnixTime: 1503394200},
+		},
+		"Grouping": {
+			input: "\\g:1-2-1234,s:SAT1,c:1503394200*36\\",
+			expected: TagBlock{
+				Source:   "SAT1",
+				UnixTime: 1503394200,
+				Group:    TagBlockGroup{Sentence: 1, Total: 2, ID: 1234},
+				raw:      "\\g:1-2-1234,s:SAT1,c:1503394200*36\\",
+			},
+		},
+		"All Parameters": {
+			input: "\\s:2573345,c:1503394200,d:RX,n:42,r:35,g:2-2-7,t:hello*24\\",
+			expected: TagBlock{
+				Source:       "2573345",
+				UnixTime:     1503394200,
+				Destination:  "RX",
+				LineCount:    42,
+				RelativeTime: 35,
+				Group:        TagBlockGroup{Sentence: 2, Total: 2, ID: 7},
+				Text:         "hello",
+			},
+		},
+		"Unrecognised Parameter": {
+			input:    "\\s:foo,x:99*41\\",
+			expected: TagBlock{Source: "foo", raw: "\\s:foo,x:99*41\\"},
+		},
+	} {
+		t.Run(title, func(t *testing.T) {
+			actual, err := ParseTagBlock(vec.input) // Unit under test
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if *actual != vec.expected {
+				t.Errorf("result should have been %+v but was %+v", vec.expected, *actual)
+			}
+		})
+	}
+}
+
+func TestParseTagBlock_errors(t *testing.T) {
+	for title, vec := range map[string]struct {
+		input  string
+		errMsg string
+	}{
+		"Missing Delimiters": {
+			input:  "s:r3669961,c:1503394200*71",
+			errMsg: "tag block must be enclosed in \"\\\" but was \"s:r3669961,c:1503394200*71\"",
+		},
+		"Missing Checksum": {
+			input:  "\\s:r3669961,c:1503394200\\",
+			errMsg: "tag block does not contain a checksum",
+		},
+		"Short Checksum": {
+			input:  "\\s:r3669961,c:1503394200*7\\",
+			errMsg: "there must be exactly 2 characters remaining after \"*\" in a tag block but there was/were 1",
+		},
+		"Invalid Checksum": {
+			input: "\\s:r3669961,c:1503394200*4A\\",
+			errMsg: "calculated tag block checksum value \"71\" does not match tag block-specified value " +
+				"of \"4A\"",
+		},
+		"Malformed Parameter": {
+			input:  "\\s*73\\",
+			errMsg: "tag block parameter must be of the form \"<code>:<value>\" but was \"s\"",
+		},
+		"Non-Integer UNIX Time": {
+			input:  "\\c:bad*3E\\",
+			errMsg: "tag block parameter \"c\" must be parsable as an integer but was \"bad\"",
+		},
+		"Malformed Grouping": {
+			input:  "\\g:1-2*73\\",
+			errMsg: "tag block parameter \"g\" must be of the form \"<sentence>-<total>-<id>\" but was \"1-2\"",
+		},
+	} {
+		t.Run(title, func(t *testing.T) {
+			_, err := ParseTagBlock(vec.input) // Unit under test
+			if err == nil {
+				t.Fatal("tag block parsing succeeded (but should not have)")
+			}
+
+			if err.Error() != vec.errMsg {
+				t.Errorf("error message should have been '%v' but was '%v'", vec.errMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestTagBlock_String(t *testing.T) {
+	for _, input := range []string{
+		"\\s:r3669961,c:1503394200*71\\",
+		"\\s:2573345,c:1503394200,d:RX,n:42,r:35,g:2-2-7,t:hello*24\\",
+		"\\g:1-2-1234,s:SAT1,c:1503394200*36\\",
+		"\\s:foo,x:99*41\\",
+		"\\s:foo,n:0*67\\",
+		"\\x:1,s:foo,c:01503394200,y:2*63\\",
+		"\\x:1,s:foo,y:2*2d\\",
+	} {
+		t.Run(input, func(t *testing.T) {
+			tb, err := ParseTagBlock(input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if actual := tb.String(); actual != input { // Unit under test
+				t.Errorf("result should have been %q but was %q", input, actual)
+			}
+		})
+	}
+}
+
+func TestTagBlock_String_changed(t *testing.T) {
+	tb, err := ParseTagBlock("\\x:1,s:foo,n:0,c:01503394200*76\\")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tb.Source = "bar"
+	tb.Text = "hello"
+
+	expected := "\\x:1,s:bar,n:0,c:01503394200,t:hello*61\\"
+	if actual := tb.String(); actual != expected { // Unit under test
+		t.Errorf("result should have been %q but was %q", expected, actual)
+	}
+}
+
+func TestTagBlock_String_cleared(t *testing.T) {
+	tb, err := ParseTagBlock("\\x:1,s:foo,n:0,c:01503394200*76\\")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tb.Source = ""
+	tb.UnixTime = 0
+
+	expected := "\\x:1,n:0*3B\\"
+	if actual := tb.String(); actual != expected { // Unit under test
+		t.Errorf("result should have been %q but was %q", expected, actual)
+	}
+}
+
+func TestTagBlock_Unrecognized(t *testing.T) {
+	tb, err := ParseTagBlock("\\x:1,s:foo,y:2*2d\\")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"x:1", "y:2"}
+	if actual := tb.Unrecognized(); !slices.Equal(actual, expected) { // Unit under test
+		t.Errorf("result should have been %q but was %q", expected, actual)
+	}
+
+	if actual := (TagBlock{Source: "foo"}).Unrecognized(); actual != nil {
+		t.Errorf("result should have been nil but was %q", actual)
+	}
+}
+
+func TestSplitTagBlock(t *testing.T) {
+	tb, rest, err := SplitTagBlock("\\s:r3669961,c:1503394200*71\\" + referenceSentence)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := (TagBlock{Source: "r3669961", UnixTime: 1503394200}); tb == nil || *tb != expected {
+		t.Errorf("tag block should have been %+v but was %+v", expected, tb)
+	}
+
+	if rest != referenceSentence {
+		t.Errorf("sentence should have been %q but was %q", referenceSentence, rest)
+	}
+
+	tb, rest, err = SplitTagBlock(referenceSentence)
+	if err != nil || tb != nil || rest != referenceSentence {
+		t.Errorf("a sentence without a tag block should have been returned unchanged but got %v, %q, %v",
+			tb, rest, err)
+	}
+
+	if _, _, err = SplitTagBlock("\\s:r3669961,c:1503394200*4A\\" + referenceSentence); err == nil {
+		t.Error("a tag block with an invalid checksum should have been rejected")
+	}
+}
+
+func ExampleParseTagBlock() {
+	tb, err := ParseTagBlock("\\g:1-2-1234,s:SAT1,c:1503394200*36\\")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%s %d %+v\n", tb.Source, tb.UnixTime, tb.Group)
+	fmt.Println(tb)
+	// Output:
+	// SAT1 1503394200 {Sentence:1 Total:2 ID:1234}
+	// \g:1-2-1234,s:SAT1,c:1503394200*36\
+}
@@ -0,0 +1,102 @@
+package sentence
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Talker identifiers used by GNSS receivers. A standard sentence's type (element [0]) is made up of
+// one of these (or any other two-letter talker identifier) followed by a three-letter sentence
+// formatter, e.g. "GN" + "GGA".
+const (
+	// TalkerGPS identifies the Global Positioning System (GPS).
+	TalkerGPS = "GP"
+
+	// TalkerGLONASS identifies GLONASS.
+	TalkerGLONASS = "GL"
+
+	// TalkerGalileo identifies Galileo.
+	TalkerGalileo = "GA"
+
+	// TalkerBeiDou identifies the BeiDou Navigation Satellite System (NMEA 4.11 and later).
+	TalkerBeiDou = "GB"
+
+	// TalkerBeiDouLegacy identifies the BeiDou Navigation Satellite System (before NMEA 4.11).
+	TalkerBeiDouLegacy = "BD"
+
+	// TalkerQZSS identifies the Quasi-Zenith Satellite System (QZSS).
+	TalkerQZSS = "GQ"
+
+	// TalkerNavIC identifies the Indian Regional Navigation Satellite System (NavIC).
+	TalkerNavIC = "GI"
+
+	// TalkerGNSS identifies a combined, multi-constellation GNSS solution.
+	TalkerGNSS = "GN"
+)
+
+// SplitSentenceType splits the type of a standard NMEA sentence (element [0], e.g. "GNGGA") into
+// its two-letter talker identifier and its three-letter sentence formatter. Both are returned in
+// upper case. An error is returned if sentenceType is not a valid standard sentence type.
+func SplitSentenceType(sentenceType string) (talker, formatter string, err error) {
+	if len(sentenceType) != 5 || !isAlpha(sentenceType[:2]) || !isAlphanumeric(sentenceType[2:]) {
+		return "", "", fmt.Errorf("\"%s\" is not a valid sentence type", sentenceType)
+	}
+
+	talker = strings.ToUpper(sentenceType[:2])
+	if talker[0] == 'P' {
+		return "", "", fmt.Errorf("\"%s\" is a proprietary sentence type", sentenceType)
+	}
+
+	return talker, strings.ToUpper(sentenceType[2:]), nil
+}
+
+// SplitProprietaryType splits the type of a proprietary NMEA sentence (element [0], which begins
+// with "P", e.g. "PGRME" or "PUBX") into its three-character manufacturer code and the rest of the
+// sentence type, which may be empty; e.g. "GRM" and "E", or "UBX" and "". Both are returned in
+// upper case. An error is returned if sentenceType is not a valid proprietary sentence type.
+func SplitProprietaryType(sentenceType string) (manufacturer, rest string, err error) {
+	if len(sentenceType) < 4 || (sentenceType[0] != 'P' && sentenceType[0] != 'p') ||
+		!isAlphanumeric(sentenceType[1:]) {
+		return "", "", fmt.Errorf("\"%s\" is not a valid proprietary sentence type", sentenceType)
+	}
+
+	return strings.ToUpper(sentenceType[1:4]), strings.ToUpper(sentenceType[4:]), nil
+}
+
+// SplitQueryType splits the type of a query sentence (element [0], e.g. "GPECQ") into the talker
+// identifier of the requester (e.g. "GP") and that of the destination, the device that is asked for
+// data (e.g. "EC"). Both are returned in upper case. An error is returned if sentenceType is not a
+// valid query sentence type: two talker identifiers followed by "Q".
+func SplitQueryType(sentenceType string) (requester, destination string, err error) {
+	if len(sentenceType) != 5 || !isAlpha(sentenceType[:4]) ||
+		(sentenceType[4] != 'Q' && sentenceType[4] != 'q') {
+		return "", "", fmt.Errorf("\"%s\" is not a valid query sentence type", sentenceType)
+	}
+
+	requester = strings.ToUpper(sentenceType[:2])
+	if requester[0] == 'P' {
+		return "", "", fmt.Errorf("\"%s\" is a proprietary sentence type", sentenceType)
+	}
+
+	return requester, strings.ToUpper(sentenceType[2:4]), nil
+}
+
+func isAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i] | 0x20; c < 'a' || c > 'z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isAlphanumeric(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; (c < '0' || c > '9') && !isAlpha(s[i:i+1]) {
+			return false
+		}
+	}
+
+	return true
+}
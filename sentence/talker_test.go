@@ -0,0 +1,83 @@
+package sentence
+
+import "testing"
+
+func TestSplitSentenceType(t *testing.T) {
+	for _, vec := range []struct{ input, talker, formatter string }{
+		{input: "GPGGA", talker: "GP", formatter: "GGA"},
+		{input: "GNGLL", talker: "GN", formatter: "GLL"},
+		{input: "BDGSA", talker: "BD", formatter: "GSA"},
+		{input: "gngga", talker: "GN", formatter: "GGA"},
+	} {
+		t.Run(vec.input, func(t *testing.T) {
+			talker, formatter, err := SplitSentenceType(vec.input)
+			if err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			}
+			if talker != vec.talker || formatter != vec.formatter {
+				t.Errorf("expected (%q, %q) but was (%q, %q)", vec.talker, vec.formatter, talker, formatter)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "GPGG", "GPGGAA", "G1GGA", "GPG-A", "PGRME"} {
+		t.Run("Invalid "+input, func(t *testing.T) {
+			if _, _, err := SplitSentenceType(input); err == nil {
+				t.Errorf("expected an error for %q but got nil", input)
+			}
+		})
+	}
+}
+
+func TestSplitProprietaryType(t *testing.T) {
+	for _, vec := range []struct{ input, manufacturer, rest string }{
+		{input: "PGRME", manufacturer: "GRM", rest: "E"},
+		{input: "PUBX", manufacturer: "UBX", rest: ""},
+		{input: "PSRF100", manufacturer: "SRF", rest: "100"},
+		{input: "ptnl", manufacturer: "TNL", rest: ""},
+	} {
+		t.Run(vec.input, func(t *testing.T) {
+			manufacturer, rest, err := SplitProprietaryType(vec.input)
+			if err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			}
+			if manufacturer != vec.manufacturer || rest != vec.rest {
+				t.Errorf("expected (%q, %q) but was (%q, %q)", vec.manufacturer, vec.rest, manufacturer, rest)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "PGR", "GPGGA", "PGR-E", "XGRME"} {
+		t.Run("Invalid "+input, func(t *testing.T) {
+			if _, _, err := SplitProprietaryType(input); err == nil {
+				t.Errorf("expected an error for %q but got nil", input)
+			}
+		})
+	}
+}
+
+func TestSplitQueryType(t *testing.T) {
+	for _, vec := range []struct{ input, requester, destination string }{
+		{input: "GPECQ", requester: "GP", destination: "EC"},
+		{input: "iigpq", requester: "II", destination: "GP"},
+	} {
+		t.Run(vec.input, func(t *testing.T) {
+			requester, destination, err := SplitQueryType(vec.input)
+			if err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			}
+			if requester != vec.requester || destination != vec.destination {
+				t.Errorf("expected (%q, %q) but was (%q, %q)", vec.requester, vec.destination,
+					requester, destination)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "GPEC", "GPECQQ", "GPGGA", "G1ECQ", "GPE1Q", "PGECQ"} {
+		t.Run("Invalid "+input, func(t *testing.T) {
+			if _, _, err := SplitQueryType(input); err == nil {
+				t.Errorf("expected an error for %q but got nil", input)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package sentence
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- Public ------------------------------------------------------------------
+
+// UnescapeText decodes the IEC 61162-1 escapes in s, the raw value of a text field (e.g. the
+// message of a TXT or ALR sentence): "^" followed by two hexadecimal digits represents the
+// ISO 8859-1 character with that code, so "^2C" is a comma (",") and "^5E" a caret ("^"). It
+// returns an error if s holds an invalid escape or a character outside the IEC 61162-1 character
+// set: a control character, a character above "}" (0x7D), or one of the reserved characters "$",
+// "*", ",", "!" and "\".
+func UnescapeText(s string) (string, error) {
+	if strings.IndexFunc(s, isReservedTextRune) < 0 {
+		return s, nil // Nothing to decode
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '^' {
+			if !isTextChar(c) {
+				return "", fmt.Errorf("invalid character %q at offset %d", s[i:i+1], i)
+			}
+
+			b.WriteByte(c)
+
+			continue
+		}
+
+		if i+2 >= len(s) || !isHexDigit(s[i+1]) || !isHexDigit(s[i+2]) {
+			return "", fmt.Errorf("invalid escape %q at offset %d", s[i:min(i+3, len(s))], i)
+		}
+
+		b.WriteRune(rune(hexValue(s[i+1])<<4 | hexValue(s[i+2]))) // ISO 8859-1 is the first 256
+		i += 2
+	}
+
+	return b.String(), nil
+}
+
+// EscapeText encodes s as the raw value of a text field; it is the inverse of UnescapeText. Each
+// character outside the IEC 61162-1 character set (including the reserved characters "$", "*",
+// ",", "!", "\", "^" and "~") is written as "^" followed by its ISO 8859-1 code in two upper case
+// hexadecimal digits, e.g. "^2C" for a comma. It returns an error if s holds a character that is
+// not in ISO 8859-1 (that is, one above U+00FF).
+func EscapeText(s string) (string, error) {
+	if strings.IndexFunc(s, isReservedTextRune) < 0 {
+		return s, nil // Nothing to encode
+	}
+
+	var b strings.Builder
+	b.Grow(len(s) + 8)
+	for i, r := range s {
+		switch {
+		case r < 0x80 && isTextChar(byte(r)):
+			b.WriteRune(r)
+		case r <= 0xFF:
+			fmt.Fprintf(&b, "^%02X", r)
+		default:
+			return "", fmt.Errorf("%q at offset %d is not an ISO 8859-1 character", r, i)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// AsText parses the sentence segment at the specified index as a text field, such as the message
+// of a TXT sentence, and returns it with its escapes decoded (see UnescapeText); e.g. "A^2CB"
+// becomes "A,B". Unlike AsString, it rejects characters outside the IEC 61162-1 character set. If
+// p.Err() is not nil, this function returns an empty string and leaves the error unchanged.
+func (p *SegmentParser) AsText(i int8) string {
+	v, ok := p.Segment(i)
+	if !ok {
+		return ""
+	}
+
+	text, err := UnescapeText(v)
+	if err != nil {
+		p.fail(&FieldError{
+			Segment:  i,
+			Expected: "text",
+			Message:  fmt.Sprintf("must be valid text (%v) but was \"%s\"", err, v),
+		})
+
+		return ""
+	}
+
+	if text == v {
+		return p.keep(v)
+	}
+
+	return text
+}
+
+// WriteText appends s as the next segment, escaping the characters that may not appear in it
+// verbatim (see EscapeText); e.g. "A,B" is written as "A^2CB". If s holds a character that is not
+// in ISO 8859-1, the error is recorded.
+func (w *SegmentWriter) WriteText(s string) {
+	if w.err != nil {
+		return
+	}
+
+	text, err := EscapeText(s)
+	if err != nil {
+		w.setErr(fmt.Sprintf("must be ISO 8859-1 text but was \"%s\" (%v)", s, err))
+
+		return
+	}
+
+	w.append(text)
+}
+
+// --- Private -----------------------------------------------------------------
+
+// isTextChar reports whether c may appear verbatim in a text field: whether it is a printable
+// ASCII character other than the reserved characters "$", "*", ",", "!", "\", "^" and "~".
+func isTextChar(c byte) bool {
+	return c >= 0x20 && c < 0x7E && !strings.ContainsRune("$*,!\\^", rune(c))
+}
+
+// isReservedTextRune reports whether r may not appear verbatim in a text field (see isTextChar).
+func isReservedTextRune(r rune) bool {
+	return r >= 0x80 || !isTextChar(byte(r))
+}
+
+func isHexDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'A' && c <= 'F' || c >= 'a' && c <= 'f'
+}
+
+func hexValue(c byte) byte {
+	switch {
+	case c <= '9':
+		return c - '0'
+	case c >= 'a':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 10
+	}
+}
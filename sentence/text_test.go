@@ -0,0 +1,119 @@
+package sentence
+
+import "testing"
+
+func TestUnescapeText(t *testing.T) {
+	for _, vec := range []struct{ title, input, expected string }{
+		{title: "No Escapes", input: "ANTENNA OK", expected: "ANTENNA OK"},
+		{title: "Comma", input: "A^2CB", expected: "A,B"},
+		{title: "Caret", input: "^5E", expected: "^"},
+		{title: "Lower Case Digits", input: "^2c^7e", expected: ",~"},
+		{title: "ISO 8859-1", input: "^B0C", expected: "°C"},
+		{title: "Empty", input: "", expected: ""},
+	} {
+		t.Run(vec.title, func(t *testing.T) {
+			actual, err := UnescapeText(vec.input)
+			if err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			}
+
+			if actual != vec.expected {
+				t.Errorf("expected %q but was %q", vec.expected, actual)
+			}
+		})
+	}
+
+	for _, vec := range []struct{ title, input, errMsg string }{
+		{title: "Bad Hex Digit", input: "A^2GB", errMsg: "invalid escape \"^2G\" at offset 1"},
+		{title: "Truncated Escape", input: "AB^2", errMsg: "invalid escape \"^2\" at offset 2"},
+		{title: "Tilde", input: "A~B", errMsg: "invalid character \"~\" at offset 1"},
+		{title: "Start Delimiter", input: "A$B", errMsg: "invalid character \"$\" at offset 1"},
+		{title: "Control Character", input: "A\tB", errMsg: "invalid character \"\\t\" at offset 1"},
+		{title: "Non-ASCII", input: "°C", errMsg: "invalid character \"\\xc2\" at offset 0"},
+	} {
+		t.Run(vec.title, func(t *testing.T) {
+			if _, err := UnescapeText(vec.input); err == nil || err.Error() != vec.errMsg {
+				t.Errorf("expected error %q but was %v", vec.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	for _, vec := range []struct{ title, input, expected string }{
+		{title: "No Reserved Characters", input: "ANTENNA OK", expected: "ANTENNA OK"},
+		{title: "Reserved Characters", input: "$*,!\\^~", expected: "^24^2A^2C^21^5C^5E^7E"},
+		{title: "Control Characters", input: "A\r\n", expected: "A^0D^0A"},
+		{title: "ISO 8859-1", input: "20°C", expected: "20^B0C"},
+	} {
+		t.Run(vec.title, func(t *testing.T) {
+			actual, err := EscapeText(vec.input)
+			if err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			}
+
+			if actual != vec.expected {
+				t.Errorf("expected %q but was %q", vec.expected, actual)
+			}
+
+			if unescaped, err := UnescapeText(actual); err != nil || unescaped != vec.input {
+				t.Errorf("expected %q to round-trip but was %q (error %v)", vec.input, unescaped, err)
+			}
+		})
+	}
+
+	expected := "'€' at offset 2 is not an ISO 8859-1 character"
+	if _, err := EscapeText("10€"); err == nil || err.Error() != expected {
+		t.Errorf("expected error %q but was %v", expected, err)
+	}
+}
+
+func TestSegmentParser_AsText(t *testing.T) {
+	p := &SegmentParser{}
+	if err := p.Parse("$GPTXT,01,01,02,A^2cB*41"); err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	if actual := p.AsText(4); actual != "A,B" || p.Err() != nil {
+		t.Errorf("expected %q but was %q (error %v)", "A,B", actual, p.Err())
+	}
+
+	for input, errMsg := range map[string]string{
+		"$GPTXT,01,01,02,A^2GB*65": "sentence segment [4] must be valid text (invalid escape \"^2G\" at " +
+			"offset 1) but was \"A^2GB\"",
+		"$GPTXT,01,01,02,A~B*30": "sentence segment [4] must be valid text (invalid character \"~\" " +
+			"at offset 1) but was \"A~B\"",
+	} {
+		p := &SegmentParser{}
+		if err := p.Parse(input); err != nil {
+			t.Fatalf("failed to parse %s: %v", input, err)
+		}
+
+		if actual := p.AsText(4); actual != "" || p.Err() == nil || p.Err().Error() != errMsg {
+			t.Errorf("expected error %q but was %v (and %q)", errMsg, p.Err(), actual)
+		}
+	}
+}
+
+func TestSegmentWriter_WriteText(t *testing.T) {
+	t.Run("Escaped", func(t *testing.T) {
+		w := &SegmentWriter{}
+		w.WriteSentenceType("GP", "TXT")
+		w.WriteText("A,B")
+		if actual, _ := w.Sentence(); actual != "$GPTXT,A^2CB*4F" {
+			t.Errorf("expected %q but was %q", "$GPTXT,A^2CB*4F", actual)
+		}
+	})
+
+	t.Run("Not ISO 8859-1", func(t *testing.T) {
+		w := &SegmentWriter{}
+		w.WriteSentenceType("GP", "TXT")
+		w.WriteText("€")
+
+		expected := "sentence segment [1] must be ISO 8859-1 text but was \"€\" ('€' at offset 0 is " +
+			"not an ISO 8859-1 character)"
+		if _, err := w.Sentence(); err == nil || err.Error() != expected {
+			t.Errorf("expected error %q but was %v", expected, err)
+		}
+	})
+}
@@ -0,0 +1,152 @@
+package sentence
+
+import (
+	"errors"
+	"time"
+)
+
+// --- Public ------------------------------------------------------------------
+
+// ErrNoReferenceTime is returned by TimeResolver.Resolve if the TimeResolver has not been seeded.
+var ErrNoReferenceTime = errors.New("the TimeResolver has no reference time")
+
+// GPSWeekRolloverPeriod is the period (1024 weeks, about 19.6 years) after which the 10-bit GPS
+// week number rolls over. A receiver that mishandles the rollover reports dates that are a whole
+// number of periods in the past.
+const GPSWeekRolloverPeriod = 1024 * 7 * 24 * time.Hour
+
+// DefaultMaxJitter is the default TimeResolver.MaxJitter.
+const DefaultMaxJitter = 2 * time.Second
+
+// TimeAdjustment describes how TimeResolver resolved a time or a date.
+type TimeAdjustment int
+
+const (
+	// NoAdjustment means that the time was at or after the previous one on the same day.
+	NoAdjustment TimeAdjustment = iota
+
+	// MidnightRollover means that the time was earlier in the day than the previous one, so it
+	// was resolved to the following day.
+	MidnightRollover
+
+	// BackwardJitter means that the time was at most MaxJitter before the previous one. It was
+	// resolved as is, but the TimeResolver keeps the previous time as its reference.
+	BackwardJitter
+
+	// BackwardStep means that the time was more than MaxJitter (but less than 12 hours) before the
+	// previous one. It was resolved as is and became the TimeResolver's reference.
+	BackwardStep
+
+	// WeekRollover means that the date was a whole number of GPSWeekRolloverPeriods too early, and
+	// was moved forward by that many periods.
+	WeekRollover
+)
+
+//go:generate go run github.com/dmarkham/enumer@v1.6.3 -type=TimeAdjustment -values -output=timeadjustment_gen.go
+
+// TimeResolver converts the NMEATime of sentences without a date (such as GGA and GLL) into
+// absolute times in UTC. It is seeded with a reference time, from a dated sentence (SeedDate),
+// the system clock or a log header (Seed), and resolves each subsequent NMEATime to the time of
+// day nearest to the previous one (within 12 hours). This tracks the date across midnight UTC.
+//
+// A TimeResolver is not safe for concurrent use. Its zero value is ready to use once seeded.
+type TimeResolver struct {
+	// MaxJitter is how far a time may go backwards (e.g. from sentences of a burst that are
+	// reordered) without moving the reference time back. If it is 0, DefaultMaxJitter is used.
+	MaxJitter time.Duration
+
+	// NotBefore, if not zero, is the earliest plausible date (e.g. the date the software was
+	// built). SeedDate moves a date before it forward by whole GPSWeekRolloverPeriods.
+	NotBefore time.Time
+
+	last   time.Time
+	seeded bool
+}
+
+// NewTimeResolver returns a TimeResolver seeded with ref.
+func NewTimeResolver(ref time.Time) *TimeResolver {
+	r := &TimeResolver{}
+	r.Seed(ref)
+
+	return r
+}
+
+// Seed sets the reference time of r to ref (e.g. from the system clock or a log header),
+// replacing any previous reference.
+func (r *TimeResolver) Seed(ref time.Time) {
+	r.last = ref.UTC()
+	r.seeded = true
+}
+
+// SeedDate sets the reference time of r to the date and time of a dated sentence (such as RMC or
+// ZDA) and returns it. A date that is more than half a GPSWeekRolloverPeriod before the previous
+// reference time, or before NotBefore, is taken to be the result of a GPS week number rollover
+// and moved forward by whole periods, in which case the adjustment is WeekRollover. It returns an
+// error, and leaves r unchanged, if DateTime does.
+func (r *TimeResolver) SeedDate(d NMEADate, t NMEATime) (time.Time, TimeAdjustment, error) {
+	tm, err := DateTime(d, t)
+	if err != nil {
+		return time.Time{}, NoAdjustment, err
+	}
+
+	adj := NoAdjustment
+	earliest := r.NotBefore
+	if r.seeded && r.last.Add(-GPSWeekRolloverPeriod/2).After(earliest) {
+		earliest = r.last.Add(-GPSWeekRolloverPeriod / 2)
+	}
+
+	for !earliest.IsZero() && tm.Before(earliest) {
+		tm = tm.Add(GPSWeekRolloverPeriod)
+		adj = WeekRollover
+	}
+
+	r.Seed(tm)
+
+	return tm, adj, nil
+}
+
+// Resolve returns the absolute time in UTC of t, the time of day of a sentence received after the
+// reference time of r: the time at t on the reference day, or on the day before or after it if
+// that is nearer. It updates the reference time unless the adjustment is BackwardJitter.
+//
+// As for DateTime, a leap second (23:59:60.sss) resolves to 00:00:00.sss of the following day.
+func (r *TimeResolver) Resolve(t NMEATime) (time.Time, TimeAdjustment, error) {
+	if !r.seeded {
+		return time.Time{}, NoAdjustment, ErrNoReferenceTime
+	}
+
+	day := time.Date(r.last.Year(), r.last.Month(), r.last.Day(), 0, 0, 0, 0, time.UTC)
+	tm := day.Add(time.Duration(t.Hour)*time.Hour + time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second + time.Duration(t.Millisecond)*time.Millisecond)
+
+	adj := NoAdjustment
+	switch delta := tm.Sub(r.last); {
+	case delta < -12*time.Hour:
+		tm = tm.AddDate(0, 0, 1)
+		adj = MidnightRollover
+	case delta > 12*time.Hour:
+		tm = tm.AddDate(0, 0, -1)
+	}
+
+	if back := r.last.Sub(tm); back > 0 {
+		maxJitter := r.MaxJitter
+		if maxJitter == 0 {
+			maxJitter = DefaultMaxJitter
+		}
+
+		if back <= maxJitter {
+			return tm, BackwardJitter, nil
+		}
+
+		adj = BackwardStep
+	}
+
+	r.last = tm
+
+	return tm, adj, nil
+}
+
+// Reference returns the reference time of r, and false if r has not been seeded.
+func (r *TimeResolver) Reference() (time.Time, bool) {
+	return r.last, r.seeded
+}
@@ -0,0 +1,127 @@
+package sentence
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimeResolver_Resolve(t *testing.T) {
+	r := NewTimeResolver(time.Date(2024, time.February, 28, 23, 59, 58, 0, time.UTC))
+
+	for _, vec := range []struct {
+		input       NMEATime
+		expected    time.Time
+		adjustment  TimeAdjustment
+		description string
+	}{
+		{
+			input:       NMEATime{Hour: 23, Minute: 59, Second: 59, Millisecond: 500},
+			expected:    time.Date(2024, time.February, 28, 23, 59, 59, 5e8, time.UTC),
+			adjustment:  NoAdjustment,
+			description: "same day",
+		},
+		{
+			input:       NMEATime{Hour: 0, Minute: 0, Second: 0, Millisecond: 500},
+			expected:    time.Date(2024, time.February, 29, 0, 0, 0, 5e8, time.UTC),
+			adjustment:  MidnightRollover,
+			description: "midnight",
+		},
+		{
+			input:       NMEATime{Hour: 23, Minute: 59, Second: 59, Millisecond: 900},
+			expected:    time.Date(2024, time.February, 28, 23, 59, 59, 9e8, time.UTC),
+			adjustment:  BackwardJitter,
+			description: "jitter back across midnight",
+		},
+		{
+			input:       NMEATime{Hour: 0, Minute: 0, Second: 1},
+			expected:    time.Date(2024, time.February, 29, 0, 0, 1, 0, time.UTC),
+			adjustment:  NoAdjustment,
+			description: "after jitter",
+		},
+		{
+			input:       NMEATime{Hour: 0, Minute: 0, Second: 0},
+			expected:    time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+			adjustment:  BackwardJitter,
+			description: "jitter",
+		},
+		{
+			input:       NMEATime{Hour: 1, Minute: 0, Second: 0},
+			expected:    time.Date(2024, time.February, 29, 1, 0, 0, 0, time.UTC),
+			adjustment:  NoAdjustment,
+			description: "gap",
+		},
+		{
+			input:       NMEATime{Hour: 0, Minute: 30, Second: 0},
+			expected:    time.Date(2024, time.February, 29, 0, 30, 0, 0, time.UTC),
+			adjustment:  BackwardStep,
+			description: "step",
+		},
+	} {
+		actual, adj, err := r.Resolve(vec.input)
+		if err != nil {
+			t.Fatalf("%s: Resolve failed: %v", vec.description, err)
+		}
+
+		if !actual.Equal(vec.expected) || adj != vec.adjustment {
+			t.Errorf("%s: expected %v (%d) but was %v (%d)", vec.description, vec.expected,
+				vec.adjustment, actual, adj)
+		}
+	}
+}
+
+func TestTimeResolver_Resolve_leapSecond(t *testing.T) {
+	r := NewTimeResolver(time.Date(2016, time.December, 31, 23, 59, 59, 0, time.UTC))
+
+	actual, adj, err := r.Resolve(NMEATime{Hour: 23, Minute: 59, Second: 60})
+	expected := time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if err != nil || !actual.Equal(expected) || adj != NoAdjustment {
+		t.Errorf("expected %v but was %v (%d, %v)", expected, actual, adj, err)
+	}
+
+	actual, adj, _ = r.Resolve(NMEATime{Hour: 0, Minute: 0, Second: 0, Millisecond: 200})
+	expected = time.Date(2017, time.January, 1, 0, 0, 0, 2e8, time.UTC)
+	if !actual.Equal(expected) || adj != NoAdjustment {
+		t.Errorf("expected %v but was %v (%d)", expected, actual, adj)
+	}
+}
+
+func TestTimeResolver_Resolve_unseeded(t *testing.T) {
+	var r TimeResolver
+	if _, _, err := r.Resolve(NMEATime{}); !errors.Is(err, ErrNoReferenceTime) {
+		t.Errorf("expected ErrNoReferenceTime but was %v", err)
+	}
+}
+
+func TestTimeResolver_SeedDate(t *testing.T) {
+	var r TimeResolver
+	tm, adj, err := r.SeedDate(NMEADate{Year: 2024, Month: 3, Day: 1}, NMEATime{Hour: 12})
+	if err != nil || adj != NoAdjustment {
+		t.Fatalf("SeedDate failed: %d, %v", adj, err)
+	}
+
+	if ref, ok := r.Reference(); !ok || !ref.Equal(tm) {
+		t.Errorf("expected the reference time to be %v but was %v", tm, ref)
+	}
+
+	// A receiver affected by the week number rollover reports 1024 weeks earlier
+	tm, adj, err = r.SeedDate(NMEADate{Year: 2004, Month: 7, Day: 17}, NMEATime{Hour: 12, Minute: 1})
+	expected := time.Date(2024, time.March, 2, 12, 1, 0, 0, time.UTC)
+	if err != nil || !tm.Equal(expected) || adj != WeekRollover {
+		t.Errorf("expected %v (WeekRollover) but was %v (%d, %v)", expected, tm, adj, err)
+	}
+
+	r = TimeResolver{NotBefore: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	tm, adj, _ = r.SeedDate(NMEADate{Year: 2004, Month: 7, Day: 17}, NMEATime{Hour: 12, Minute: 1})
+	if !tm.Equal(expected) || adj != WeekRollover {
+		t.Errorf("expected %v (WeekRollover) but was %v (%d)", expected, tm, adj)
+	}
+
+	if _, _, err := r.SeedDate(NMEADate{}, NMEATime{}); err == nil {
+		t.Error("expected an error for an empty date")
+	}
+
+	if ref, _ := r.Reference(); !ref.Equal(expected) {
+		t.Errorf("expected a failed SeedDate to leave the reference time %v but was %v", expected, ref)
+	}
+}
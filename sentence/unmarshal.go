@@ -0,0 +1,542 @@
+package sentence
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// --- Public ------------------------------------------------------------------
+
+// Unmarshal parses the NMEA sentence s and stores its segments in the struct that v points to. It
+// makes it possible to define a sentence type (e.g. a proprietary one) by declaring a struct,
+// rather than by writing a parser. Each struct field that holds a segment is tagged with the
+// segment's index, optionally followed by comma-separated options:
+//
+//	// RME is Garmin's proprietary estimated error sentence, e.g. "$PGRME,15.0,M,45.0,M,25.0,M*1C".
+//	type RME struct {
+//		SentenceType string  `nmea:"0"`
+//		HPE          Float32 `nmea:"1"` // Horizontal position error
+//		HPEUnit      string  `nmea:"2"`
+//		VPE          Float32 `nmea:"3"` // Vertical position error
+//		VPEUnit      string  `nmea:"4"`
+//	}
+//
+// A field may be a string, float32, float64, int, int8, int16, int32 or int64; an NMEATime; an
+// NMEADate (in the ddmmyy format); a Float32, Float64, Int or Time (which are invalid if the
+// segment is empty); a Latitude or Longitude, which holds both the tagged segment and the
+// hemisphere segment that follows it; or any type whose pointer implements
+// encoding.TextUnmarshaler and which (or whose pointer) implements encoding.TextMarshaler, such as
+// the enums of the sentence packages.
+// Other than for enums, an empty segment leaves the field's zero value. The options are:
+//
+//   - time: the field is an NMEATime or a Time. (Such fields are recognized without it.)
+//   - text: the (string) field is a text field, whose escapes (e.g. "^2C" for a comma) are decoded
+//     by Unmarshal and written by Marshal (see SegmentParser.AsText and SegmentWriter.WriteText).
+//   - enum: the field is an enum, which must implement encoding.TextUnmarshaler and fmt.Stringer.
+//     Its segment must not be empty, and with ParseOptions.CaseSensitive it must match the enum's
+//     String exactly.
+//   - pad=N: Marshal writes the (integer) field left-padded with zeros to N digits.
+//   - formatter=XXX (segment 0 only): the sentence type must be a standard one (see
+//     SplitSentenceType) with the sentence formatter XXX, and the (string) field holds its talker
+//     identifier. Without it, a segment 0 field holds the whole sentence type.
+//   - encapsulated (segment 0 only): the sentence must use the EncapsulationDelimiter ("!"), not
+//     the ParametricDelimiter ("$").
+//
+// A field of type *TagBlock tagged `nmea:"tagblock"` holds the sentence's tag block, if any.
+// Untagged fields, and fields tagged `nmea:"-"`, are ignored.
+//
+// Errors in the segments are reported as for the sentence packages: a *FieldError (whose Field is
+// the name of the struct field) for the first failing segment, or, with ParseOptions.CollectErrors,
+// for all of them. The struct is only modified if the sentence is valid. An error is also returned
+// if v is not a non-nil pointer to a struct, or if its tags are invalid.
+func Unmarshal(s string, v any) error {
+	return UnmarshalWithOptions(s, v)
+}
+
+// UnmarshalWithOptions is like Unmarshal, but parses the sentence according to opts (see
+// ParseOptions). With no options, it is identical to Unmarshal.
+func UnmarshalWithOptions(s string, v any, opts ...ParseOption) error {
+	p := &SegmentParser{}
+	if err := p.ParseWithOptions(s, opts...); err != nil {
+		return err
+	}
+
+	return UnmarshalSegments(p, v)
+}
+
+// UnmarshalSegments is like Unmarshal, but reads the sentence that p has already parsed (see
+// [SegmentParser.Parse]). It can be used to implement the Decoder of a struct-tagged sentence type
+// (see Register).
+func UnmarshalSegments(p *SegmentParser, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("sentence: Unmarshal requires a non-nil pointer to a struct but got %T", v)
+	}
+
+	fields, err := structFields(rv.Elem().Type())
+	if err != nil {
+		return err
+	}
+
+	segments := *p
+	segments.NameFields(fields.names()...)
+	segments.RequireStartDelimiter(fields.delimiter())
+
+	// Decode into a copy, so that v is only modified if the sentence is valid
+	out := reflect.New(rv.Elem().Type()).Elem()
+	out.Set(rv.Elem())
+	for _, f := range fields {
+		f.decode(&segments, out.Field(f.index))
+	}
+
+	if err := segments.Err(); err != nil {
+		return err
+	}
+
+	rv.Elem().Set(out)
+
+	return nil
+}
+
+// Marshal encodes v, a struct (or a pointer to one) whose fields are tagged as described for
+// Unmarshal, as an NMEA sentence, including its start delimiter, checksum and (if it has one) tag
+// block. Segments that no field is tagged with are written empty. Invalid enum values and
+// sentence types are reported as an *EncodingError.
+func Marshal(v any) (string, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return "", fmt.Errorf("sentence: Marshal requires a struct or a pointer to one but got %T", v)
+	}
+
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return "", err
+	}
+
+	w := &SegmentWriter{}
+	w.WriteStartDelimiter(fields.delimiter())
+
+	next := int8(0)
+	for _, f := range fields {
+		if f.tagBlock {
+			w.WriteTagBlock(rv.Field(f.index).Interface().(*TagBlock))
+
+			continue
+		}
+
+		for ; next < f.segment; next++ {
+			w.WriteString("") // A segment that no field is tagged with
+		}
+
+		f.encode(w, rv.Field(f.index))
+		next += f.width()
+	}
+
+	return w.Sentence()
+}
+
+// --- Private -----------------------------------------------------------------
+
+var (
+	nmeaTimeType        = reflect.TypeFor[NMEATime]()
+	nmeaDateType        = reflect.TypeFor[NMEADate]()
+	float32Type         = reflect.TypeFor[Float32]()
+	float64Type         = reflect.TypeFor[Float64]()
+	intType             = reflect.TypeFor[Int]()
+	timeType            = reflect.TypeFor[Time]()
+	latitudeType        = reflect.TypeFor[Latitude]()
+	longitudeType       = reflect.TypeFor[Longitude]()
+	tagBlockType        = reflect.TypeFor[*TagBlock]()
+	startDelimiterType  = reflect.TypeFor[StartDelimiter]()
+	stringerType        = reflect.TypeFor[fmt.Stringer]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// structField describes a struct field tagged with `nmea:"..."`.
+type structField struct {
+	name         string // The name of the struct field
+	index        int    // The index of the struct field
+	segment      int8   // The index of the (first) sentence segment
+	tagBlock     bool   // Whether the field holds the tag block, rather than a segment
+	enum         bool
+	text         bool
+	pad          int
+	formatter    string
+	encapsulated bool
+	coordinate   bool // Whether the field is a Latitude or Longitude, which has two segments
+}
+
+// structFieldList is the list of the tagged fields of a struct, in segment order (with any tag
+// block field first).
+type structFieldList []structField
+
+// structFields returns the tagged fields of the struct type t, or an error if their tags (or
+// their types) are invalid.
+func structFields(t reflect.Type) (structFieldList, error) {
+	var fields structFieldList
+	seen := make(map[int8]string)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("nmea")
+		if !ok || tag == "-" {
+			continue
+		}
+
+		f, err := parseStructTag(sf, tag)
+		if err != nil {
+			return nil, fmt.Errorf("sentence: invalid nmea tag %q on %s.%s: %w", tag, t, sf.Name, err)
+		}
+
+		f.index = i
+		for j := int8(0); !f.tagBlock && j < f.width(); j++ {
+			segment := f.segment + j
+			if other, dup := seen[segment]; dup {
+				return nil, fmt.Errorf("sentence: %s.%s and %s.%s are both tagged with segment %d",
+					t, other, t, sf.Name, segment)
+			}
+
+			seen[segment] = sf.Name
+		}
+
+		fields = append(fields, f)
+	}
+
+	if _, ok := seen[0]; !ok {
+		return nil, fmt.Errorf("sentence: %s has no field tagged with segment 0 (the sentence type)", t)
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		if fields[i].tagBlock || fields[j].tagBlock {
+			return fields[i].tagBlock && !fields[j].tagBlock
+		}
+
+		return fields[i].segment < fields[j].segment
+	})
+
+	return fields, nil
+}
+
+func parseStructTag(sf reflect.StructField, tag string) (structField, error) {
+	f := structField{name: sf.Name}
+	if !sf.IsExported() {
+		return f, fmt.Errorf("the field must be exported")
+	}
+
+	if tag == "tagblock" {
+		if sf.Type != tagBlockType {
+			return f, fmt.Errorf("a tag block field must be a *TagBlock but was %s", sf.Type)
+		}
+
+		f.tagBlock = true
+
+		return f, nil
+	}
+
+	index, options, _ := strings.Cut(tag, ",")
+	segment, err := strconv.ParseInt(index, 10, 8)
+	if err != nil || segment < 0 {
+		return f, fmt.Errorf("the segment index must be an integer in [0, 127] but was %q", index)
+	}
+
+	f.segment = int8(segment)
+	f.coordinate = sf.Type == latitudeType || sf.Type == longitudeType
+	if f.coordinate && segment == 127 {
+		return f, fmt.Errorf("a %s field cannot be tagged with segment 127", sf.Type.Name())
+	}
+
+	for _, opt := range strings.Split(options, ",") {
+		if err := f.setOption(sf.Type, opt); err != nil {
+			return f, err
+		}
+	}
+
+	if !f.enum && !isSupportedFieldType(sf.Type) {
+		return f, fmt.Errorf("fields of type %s are not supported", sf.Type)
+	}
+
+	if !f.enum && isTextField(sf.Type) && !reflect.PointerTo(sf.Type).Implements(textMarshalerType) {
+		return f, fmt.Errorf("a field whose pointer implements encoding.TextUnmarshaler must also "+
+			"implement encoding.TextMarshaler (on %s or on *%s)", sf.Type, sf.Type)
+	}
+
+	if f.segment == 0 && sf.Type.Kind() != reflect.String {
+		return f, fmt.Errorf("the segment 0 (sentence type) field must be a string but was %s", sf.Type)
+	}
+
+	return f, nil
+}
+
+func (f *structField) setOption(t reflect.Type, opt string) error {
+	key, value, _ := strings.Cut(opt, "=")
+	switch key {
+	case "":
+		// No options
+	case "time":
+		if t != nmeaTimeType && t != timeType {
+			return fmt.Errorf("a time field must be an NMEATime or a Time but was %s", t)
+		}
+	case "text":
+		if t.Kind() != reflect.String {
+			return fmt.Errorf("a text field must be a string but was %s", t)
+		}
+
+		f.text = true
+	case "enum":
+		if !reflect.PointerTo(t).Implements(textUnmarshalerType) || !t.Implements(stringerType) {
+			return fmt.Errorf("an enum field must implement encoding.TextUnmarshaler and " +
+				"fmt.Stringer")
+		}
+
+		f.enum = true
+	case "pad":
+		pad, err := strconv.Atoi(value)
+		if err != nil || pad < 1 {
+			return fmt.Errorf("the pad width must be a positive integer but was %q", value)
+		}
+
+		if t != intType && (t.Kind() < reflect.Int || t.Kind() > reflect.Int64) {
+			return fmt.Errorf("a padded field must be an integer but was %s", t)
+		}
+
+		f.pad = pad
+	case "formatter":
+		if f.segment != 0 || value == "" {
+			return fmt.Errorf("the formatter option requires segment 0 and a sentence formatter")
+		}
+
+		f.formatter = value
+	case "encapsulated":
+		if f.segment != 0 {
+			return fmt.Errorf("the encapsulated option requires segment 0")
+		}
+
+		f.encapsulated = true
+	default:
+		return fmt.Errorf("unknown option %q", opt)
+	}
+
+	return nil
+}
+
+func isSupportedFieldType(t reflect.Type) bool {
+	switch t {
+	case nmeaTimeType, nmeaDateType, float32Type, float64Type, intType, timeType,
+		latitudeType, longitudeType:
+		return true
+	}
+
+	if isTextField(t) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+
+	return false
+}
+
+// isTextField reports whether a field of type t, which is not an enum, is decoded with its
+// UnmarshalText method (and so must be encoded with its MarshalText method).
+func isTextField(t reflect.Type) bool {
+	switch t {
+	case nmeaTimeType, nmeaDateType, float32Type, float64Type, intType, timeType,
+		latitudeType, longitudeType:
+		return false
+	}
+
+	return reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// names returns the names of the fields, indexed by segment (see SegmentParser.NameFields).
+func (fields structFieldList) names() []string {
+	var names []string
+	for _, f := range fields {
+		if f.tagBlock {
+			continue
+		}
+
+		for len(names) < int(f.segment+f.width()) {
+			names = append(names, "")
+		}
+
+		for j := int8(0); j < f.width(); j++ {
+			names[f.segment+j] = f.name
+		}
+	}
+
+	return names
+}
+
+// width returns the number of sentence segments that the field f holds.
+func (f structField) width() int8 {
+	if f.coordinate {
+		return 2
+	}
+
+	return 1
+}
+
+// delimiter returns the start delimiter that the sentence must use.
+func (fields structFieldList) delimiter() StartDelimiter {
+	for _, f := range fields {
+		if f.encapsulated {
+			return EncapsulationDelimiter
+		}
+	}
+
+	return ParametricDelimiter
+}
+
+// decode sets v, the value of the field f, from the sentence that p has parsed.
+func (f structField) decode(p *SegmentParser, v reflect.Value) {
+	i := f.segment
+	switch {
+	case f.tagBlock:
+		v.Set(reflect.ValueOf(p.TagBlock()))
+	case i == 0 && f.formatter != "":
+		v.SetString(p.RequireFormatter(f.formatter))
+	case v.Type() == nmeaTimeType:
+		v.Set(reflect.ValueOf(p.AsNMEATime(i)))
+	case v.Type() == nmeaDateType:
+		v.Set(reflect.ValueOf(p.AsNMEADate(i)))
+	case v.Type() == float32Type:
+		v.Set(reflect.ValueOf(p.AsOptionalFloat32(i)))
+	case v.Type() == float64Type:
+		v.Set(reflect.ValueOf(p.AsOptionalFloat64(i)))
+	case v.Type() == intType:
+		v.Set(reflect.ValueOf(p.AsOptionalInt(i, 64)))
+	case v.Type() == timeType:
+		v.Set(reflect.ValueOf(p.AsOptionalNMEATime(i)))
+	case v.Type() == latitudeType:
+		v.Set(reflect.ValueOf(p.AsLatitude(i)))
+	case v.Type() == longitudeType:
+		v.Set(reflect.ValueOf(p.AsLongitude(i)))
+	case f.enum || v.Addr().Type().Implements(textUnmarshalerType):
+		f.decodeText(p, v)
+	case f.text:
+		v.SetString(p.AsText(i))
+	case v.Kind() == reflect.String:
+		v.SetString(p.AsString(i))
+	case v.Kind() == reflect.Float32:
+		v.SetFloat(float64(p.AsFloat32(i)))
+	case v.Kind() == reflect.Float64:
+		v.SetFloat(p.AsFloat64(i))
+	default: // An integer kind
+		v.SetInt(p.AsOptionalInt(i, v.Type().Bits()).Int)
+	}
+}
+
+// decodeText sets v, which implements encoding.TextUnmarshaler, from the sentence segment of the
+// field f.
+func (f structField) decodeText(p *SegmentParser, v reflect.Value) {
+	s, ok := p.Segment(f.segment)
+	if !ok || (s == "" && !f.enum) {
+		return
+	}
+
+	err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	if err != nil || (f.enum && !p.AcceptsCase(s, v.Interface().(fmt.Stringer).String())) {
+		v.SetZero()
+
+		name := v.Type().Name()
+		p.Fail(f.segment, name, fmt.Sprintf("must be parsable as %s %s but was \"%s\"",
+			indefiniteArticle(name), name, s))
+	}
+}
+
+// encode writes v, the value of the field f, to w.
+func (f structField) encode(w *SegmentWriter, v reflect.Value) {
+	switch {
+	case f.segment == 0 && f.formatter != "":
+		w.WriteSentenceType(v.String(), f.formatter)
+	case v.Type() == nmeaTimeType:
+		w.WriteNMEATime(v.Interface().(NMEATime))
+	case v.Type() == nmeaDateType:
+		w.WriteNMEADate(v.Interface().(NMEADate))
+	case v.Type() == float32Type:
+		w.WriteOptionalFloat32(v.Interface().(Float32))
+	case v.Type() == float64Type:
+		w.WriteOptionalFloat64(v.Interface().(Float64))
+	case v.Type() == latitudeType:
+		w.WriteLatitude(v.Interface().(Latitude))
+	case v.Type() == longitudeType:
+		w.WriteLongitude(v.Interface().(Longitude))
+	case v.Type() == intType && f.pad > 0:
+		w.WriteOptionalZeroPaddedInt(v.Interface().(Int), f.pad)
+	case v.Type() == intType:
+		w.WriteOptionalInt(v.Interface().(Int))
+	case v.Type() == timeType:
+		w.WriteOptionalNMEATime(v.Interface().(Time))
+	case f.enum:
+		w.WriteEnum(v.Interface().(fmt.Stringer), isValidEnum(v))
+	case reflect.PointerTo(v.Type()).Implements(textMarshalerType):
+		text, err := textMarshaler(v).MarshalText()
+		if err != nil {
+			w.setErr(fmt.Sprintf("must be a valid %s but was %v (%v)", v.Type(), v, err))
+
+			return
+		}
+
+		w.WriteString(string(text))
+	case f.text:
+		w.WriteText(v.String())
+	case v.Kind() == reflect.String:
+		w.WriteString(v.String())
+	case v.Kind() == reflect.Float32:
+		w.WriteFloat32(float32(v.Float()))
+	case v.Kind() == reflect.Float64:
+		w.WriteFloat64(v.Float())
+	case f.pad > 0: // An integer kind
+		w.WriteZeroPaddedInt(v.Int(), f.pad)
+	default: // An integer kind
+		w.WriteInt(v.Int())
+	}
+}
+
+// textMarshaler returns v, whose type or pointer type implements encoding.TextMarshaler, as an
+// encoding.TextMarshaler. If only its pointer type does, it returns a pointer to a copy of v, as v
+// need not be addressable.
+func textMarshaler(v reflect.Value) encoding.TextMarshaler {
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		return m
+	}
+
+	c := reflect.New(v.Type())
+	c.Elem().Set(v)
+
+	return c.Interface().(encoding.TextMarshaler)
+}
+
+// isValidEnum reports whether v, an enum value, is one of the enum's defined values: that is,
+// whether its String can be parsed back into the same value.
+func isValidEnum(v reflect.Value) bool {
+	parsed := reflect.New(v.Type())
+	text := v.Interface().(fmt.Stringer).String()
+	if err := parsed.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+		return false
+	}
+
+	return parsed.Elem().Equal(v)
+}
+
+// indefiniteArticle returns "an" if the type name s begins with a vowel and "a" otherwise, for
+// error messages such as "must be parsable as an EastWest".
+func indefiniteArticle(s string) string {
+	if s != "" && strings.ContainsRune("AEIOUaeiou", rune(s[0])) {
+		return "an"
+	}
+
+	return "a"
+}
@@ -0,0 +1,462 @@
+package sentence_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mab-go/nmea/sentence"
+	"github.com/mab-go/nmea/sentence/gll"
+)
+
+// rme is Garmin's proprietary estimated error sentence.
+type rme struct {
+	SentenceType string           `nmea:"0"`
+	HPE          sentence.Float32 `nmea:"1"`
+	HPEUnit      string           `nmea:"2"`
+	VPE          sentence.Float32 `nmea:"3"`
+	VPEUnit      string           `nmea:"4"`
+	EPE          sentence.Float32 `nmea:"5"`
+	EPEUnit      string           `nmea:"6"`
+	Note         string
+}
+
+// taggedGLL is a struct-tagged equivalent of gll.GLL.
+type taggedGLL struct {
+	TalkerID   string             `nmea:"0,formatter=GLL"`
+	Latitude   sentence.Latitude  `nmea:"1"`
+	Longitude  sentence.Longitude `nmea:"3"`
+	FixTime    sentence.Time      `nmea:"5,time"`
+	DataStatus gll.DataStatus     `nmea:"6,enum"`
+	Mode       gll.Mode           `nmea:"7,enum"`
+	Version    sentence.Version   // Not a segment, so untagged
+	TagBlock   *sentence.TagBlock `nmea:"tagblock"`
+}
+
+// taggedVDM is a struct-tagged encapsulation sentence that leaves segments [3] to [5] untagged.
+type taggedVDM struct {
+	TalkerID      string `nmea:"0,formatter=VDM,encapsulated"`
+	FragmentCount int8   `nmea:"1"`
+	FragmentIndex int16  `nmea:"2,pad=2"`
+	FillBits      int    `nmea:"6"`
+}
+
+// taggedTXT is a struct-tagged TXT sentence, whose message is a text field.
+type taggedTXT struct {
+	TalkerID string `nmea:"0,formatter=TXT"`
+	Count    int8   `nmea:"1,pad=2"`
+	Number   int8   `nmea:"2,pad=2"`
+	Severity int8   `nmea:"3,pad=2"`
+	Message  string `nmea:"4,text"`
+}
+
+// span is a struct-typed text field ("<min>-<max>") whose MarshalText has a pointer receiver.
+type span struct {
+	Min, Max int
+}
+
+func (s *span) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "%d-%d", &s.Min, &s.Max)
+
+	return err
+}
+
+func (s *span) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "%d-%d", s.Min, s.Max), nil
+}
+
+// unmarshalOnlySpan is a struct-typed field that implements encoding.TextUnmarshaler but not
+// encoding.TextMarshaler.
+type unmarshalOnlySpan struct {
+	Min, Max int
+}
+
+func (s *unmarshalOnlySpan) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "%d-%d", &s.Min, &s.Max)
+
+	return err
+}
+
+// taggedSpan is a struct-tagged proprietary sentence with a struct-typed text field.
+type taggedSpan struct {
+	SentenceType string `nmea:"0"`
+	Span         span   `nmea:"1"`
+}
+
+// taggedUnmarshalOnlySpan is like taggedSpan, but its text field cannot be marshalled.
+type taggedUnmarshalOnlySpan struct {
+	SentenceType string            `nmea:"0"`
+	Span         unmarshalOnlySpan `nmea:"1"`
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Run("Proprietary", func(t *testing.T) {
+		actual := rme{Note: "untouched"}
+		if err := sentence.Unmarshal("$PGRME,15.0,M,45.0,M,25.0,M*1C", &actual); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+
+		expected := rme{
+			SentenceType: "PGRME",
+			HPE:          sentence.NewFloat32(15),
+			HPEUnit:      "M",
+			VPE:          sentence.NewFloat32(45),
+			VPEUnit:      "M",
+			EPE:          sentence.NewFloat32(25),
+			EPEUnit:      "M",
+			Note:         "untouched",
+		}
+		if actual != expected {
+			t.Errorf("result should have been %+v but was %+v", expected, actual)
+		}
+	})
+
+	t.Run("Standard", func(t *testing.T) {
+		input := "\\s:r3669961,c:1503394200*71\\$GPGLL,3723.2475,N,12158.3416,W,161229.487,A,A*41"
+		var actual taggedGLL
+		if err := sentence.Unmarshal(input, &actual); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+
+		expected, err := gll.Parse(input)
+		if err != nil {
+			t.Fatalf("gll.Parse failed: %v", err)
+		}
+
+		if actual.TagBlock == nil || *actual.TagBlock != *expected.TagBlock {
+			t.Errorf("tag block should have been %+v but was %+v", expected.TagBlock, actual.TagBlock)
+		}
+
+		actual.TagBlock, expected.TagBlock = nil, nil
+		actual.Version = expected.Version
+		if gll.GLL(actual) != *expected {
+			t.Errorf("result should have been %+v but was %+v", *expected, actual)
+		}
+	})
+
+	t.Run("Encapsulated", func(t *testing.T) {
+		var actual taggedVDM
+		if err := sentence.Unmarshal("!AIVDM,2,2,3,B,1@0000000000000,2*55", &actual); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+
+		expected := taggedVDM{TalkerID: "AI", FragmentCount: 2, FragmentIndex: 2, FillBits: 2}
+		if actual != expected {
+			t.Errorf("result should have been %+v but was %+v", expected, actual)
+		}
+
+		err := sentence.Unmarshal("$AIVDM,2,2,3,B,1@0000000000000,2*55", &actual)
+		if !errors.Is(err, sentence.ErrBadStartDelimiter) {
+			t.Errorf("expected a start delimiter error but was %v", err)
+		}
+	})
+
+	t.Run("Date", func(t *testing.T) {
+		var actual struct {
+			TalkerID string            `nmea:"0,formatter=RMC"`
+			FixTime  sentence.NMEATime `nmea:"1"`
+			FixDate  sentence.NMEADate `nmea:"9"`
+		}
+		input := "$GPRMC,123519,A,4807.038,N,01131.000,E,022.4,084.4,230394,003.1,W*6A"
+		if err := sentence.Unmarshal(input, &actual); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+
+		expected := sentence.NMEADate{Year: 1994, Month: 3, Day: 23}
+		if actual.FixDate != expected {
+			t.Errorf("FixDate should have been %+v but was %+v", expected, actual.FixDate)
+		}
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		var actual taggedTXT
+		input := "$GPTXT,01,01,02,u-blox ag ^2C www.u-blox.com ^5E_^5E*2D"
+		if err := sentence.Unmarshal(input, &actual); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+
+		if expected := "u-blox ag , www.u-blox.com ^_^"; actual.Message != expected {
+			t.Errorf("Message should have been %q but was %q", expected, actual.Message)
+		}
+	})
+}
+
+func TestUnmarshal_fieldErrors(t *testing.T) {
+	original := taggedGLL{TalkerID: "original"}
+
+	t.Run("First Error", func(t *testing.T) {
+		actual := original
+		err := sentence.Unmarshal("$GPGLL,3723.2475,N,12158.3416,W,161229.487,A,bad_Mode*1B", &actual)
+
+		expected := "sentence segment [7] must be parsable as a Mode but was \"bad_Mode\""
+		if err == nil || err.Error() != expected {
+			t.Fatalf("expected error %q but was %v", expected, err)
+		}
+
+		var fieldErr *sentence.FieldError
+		if !errors.As(err, &fieldErr) || fieldErr.Field != "Mode" || fieldErr.Expected != "Mode" {
+			t.Errorf("expected a *FieldError for field Mode but was %+v", fieldErr)
+		}
+
+		if actual != original {
+			t.Errorf("Unmarshal should not have modified its destination but it was %+v", actual)
+		}
+	})
+
+	t.Run("Collected Errors", func(t *testing.T) {
+		actual := original
+		err := sentence.UnmarshalWithOptions("$GPGLL,3723.2475,X,12158.3416,W,161229.487,A,*16", &actual,
+			sentence.WithCollectErrors())
+
+		expected := []string{
+			"sentence segment [2] must be parsable as a NorthSouth but was \"X\"",
+			"sentence segment [7] must be parsable as a Mode but was \"\"",
+		}
+		if err == nil || err.Error() != strings.Join(expected, "\n") {
+			t.Errorf("expected errors %q but was %v", expected, err)
+		}
+	})
+
+	t.Run("Case Sensitive", func(t *testing.T) {
+		actual := original
+		err := sentence.UnmarshalWithOptions("$GPGLL,3723.2475,n,12158.3416,W,161229.487,A,A*61", &actual,
+			sentence.WithCaseSensitive())
+
+		expected := "sentence segment [2] must be parsable as a NorthSouth but was \"n\""
+		if err == nil || err.Error() != expected {
+			t.Errorf("expected error %q but was %v", expected, err)
+		}
+	})
+}
+
+func TestUnmarshal_invalidTargets(t *testing.T) {
+	const input = "$PGRME,15.0,M,45.0,M,25.0,M*1C"
+
+	for title, vec := range map[string]struct {
+		v      any
+		errMsg string
+	}{
+		"Nil": {
+			v:      nil,
+			errMsg: "sentence: Unmarshal requires a non-nil pointer to a struct but got <nil>",
+		},
+		"Non-Pointer": {
+			v:      rme{},
+			errMsg: "sentence: Unmarshal requires a non-nil pointer to a struct but got sentence_test.rme",
+		},
+		"No Segment 0": {
+			v: &struct {
+				HPE float32 `nmea:"1"`
+			}{},
+			errMsg: "has no field tagged with segment 0",
+		},
+		"Duplicate Segment": {
+			v: &struct {
+				Type string  `nmea:"0"`
+				A    float32 `nmea:"1"`
+				B    float32 `nmea:"1"`
+			}{},
+			errMsg: ".A and struct",
+		},
+		"Hemisphere Segment": {
+			v: &struct {
+				Type     string            `nmea:"0"`
+				Latitude sentence.Latitude `nmea:"1"`
+				Status   string            `nmea:"2"`
+			}{},
+			errMsg: "are both tagged with segment 2",
+		},
+		"Bad Index": {
+			v: &struct {
+				Type string `nmea:"zero"`
+			}{},
+			errMsg: "the segment index must be an integer in [0, 127] but was \"zero\"",
+		},
+		"Unknown Option": {
+			v: &struct {
+				Type string `nmea:"0,upper"`
+			}{},
+			errMsg: "unknown option \"upper\"",
+		},
+		"Text Option on Non-String": {
+			v: &struct {
+				Type string  `nmea:"0"`
+				Text float32 `nmea:"1,text"`
+			}{},
+			errMsg: "a text field must be a string but was float32",
+		},
+		"Unsupported Type": {
+			v: &struct {
+				Type string `nmea:"0"`
+				Flag bool   `nmea:"1"`
+			}{},
+			errMsg: "fields of type bool are not supported",
+		},
+		"Enum Without Stringer": {
+			v: &struct {
+				Type string  `nmea:"0"`
+				HPE  float32 `nmea:"1,enum"`
+			}{},
+			errMsg: "an enum field must implement encoding.TextUnmarshaler and fmt.Stringer",
+		},
+		"Non-String Sentence Type": {
+			v: &struct {
+				Type int `nmea:"0"`
+			}{},
+			errMsg: "the segment 0 (sentence type) field must be a string but was int",
+		},
+		"Formatter Not on Segment 0": {
+			v: &struct {
+				Type string `nmea:"0"`
+				HPE  string `nmea:"1,formatter=RME"`
+			}{},
+			errMsg: "the formatter option requires segment 0",
+		},
+		"Padded String": {
+			v: &struct {
+				Type string `nmea:"0"`
+				HPE  string `nmea:"1,pad=2"`
+			}{},
+			errMsg: "a padded field must be an integer but was string",
+		},
+		"Text Field Without TextMarshaler": {
+			v: &struct {
+				Type string            `nmea:"0"`
+				Span unmarshalOnlySpan `nmea:"1"`
+			}{},
+			errMsg: "a field whose pointer implements encoding.TextUnmarshaler must also implement " +
+				"encoding.TextMarshaler (on sentence_test.unmarshalOnlySpan or on " +
+				"*sentence_test.unmarshalOnlySpan)",
+		},
+		"Bad Tag Block": {
+			v: &struct {
+				Type     string            `nmea:"0"`
+				TagBlock sentence.TagBlock `nmea:"tagblock"`
+			}{},
+			errMsg: "a tag block field must be a *TagBlock but was sentence.TagBlock",
+		},
+	} {
+		t.Run(title, func(t *testing.T) {
+			err := sentence.Unmarshal(input, vec.v)
+			if err == nil || !strings.Contains(err.Error(), vec.errMsg) {
+				t.Errorf("expected an error containing %q but was %v", vec.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	for title, vec := range map[string]struct {
+		input string
+		v     any
+	}{
+		"Proprietary":  {input: "$PGRME,15.0,M,45.0,M,25.0,M*1C", v: &rme{}},
+		"Standard":     {input: "$GPGLL,3723.2475,N,12158.3416,W,161229.487,A,A*41", v: &taggedGLL{}},
+		"Tag Block":    {input: "\\s:r3669961,c:1503394200*71\\$GPGLL,3723.2475,N,12158.3416,W,161229.487,A,A*41", v: &taggedGLL{}},
+		"Encapsulated": {input: "!AIVDM,2,02,,,,2*55", v: &taggedVDM{}},
+		"Text":         {input: "$GPTXT,01,01,02,u-blox ag ^2C www.u-blox.com ^5E_^5E*2D", v: &taggedTXT{}},
+	} {
+		t.Run(title, func(t *testing.T) {
+			if err := sentence.Unmarshal(vec.input, vec.v); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+
+			actual, err := sentence.Marshal(vec.v)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+
+			if actual != vec.input {
+				t.Errorf("Marshal should have produced %q but produced %q", vec.input, actual)
+			}
+		})
+	}
+}
+
+func TestMarshal_pointerTextMarshaler(t *testing.T) {
+	v := taggedSpan{SentenceType: "PTSTS", Span: span{Min: 3, Max: 7}}
+
+	// v is passed by value, so its Span field is not addressable
+	actual, err := sentence.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	if expected := "$PTSTS,3-7*55"; actual != expected {
+		t.Errorf("Marshal should have produced %q but produced %q", expected, actual)
+	}
+
+	var decoded taggedSpan
+	if err := sentence.Unmarshal(actual, &decoded); err != nil || decoded != v {
+		t.Errorf("Unmarshal should have produced %+v but produced %+v (error %v)", v, decoded, err)
+	}
+}
+
+func TestMarshal_errors(t *testing.T) {
+	valid := taggedGLL{TalkerID: "GP", Latitude: sentence.MustParseLatitude("3723.2475,N"),
+		Longitude: sentence.MustParseLongitude("12158.3416,W"), DataStatus: gll.ValidDataStatus, Mode: gll.AutonomousMode}
+
+	invalidMode := valid
+	invalidMode.Mode = 0
+
+	missingTalker := valid
+	missingTalker.TalkerID = ""
+
+	for title, vec := range map[string]struct {
+		v      any
+		errMsg string
+	}{
+		"Invalid Enum":   {v: invalidMode, errMsg: "sentence segment [7] must be a valid gll.Mode but was \"Mode(0)\""},
+		"Missing Talker": {v: &missingTalker, errMsg: "sentence segment [0] must be a valid sentence type but was \"GLL\""},
+		"Not a Struct":   {v: "GPGLL", errMsg: "sentence: Marshal requires a struct or a pointer to one but got string"},
+		"Text Field Without TextMarshaler": {
+			v: taggedUnmarshalOnlySpan{SentenceType: "PTSTS"},
+			errMsg: "sentence: invalid nmea tag \"1\" on sentence_test.taggedUnmarshalOnlySpan.Span: a " +
+				"field whose pointer implements encoding.TextUnmarshaler must also implement " +
+				"encoding.TextMarshaler (on sentence_test.unmarshalOnlySpan or on " +
+				"*sentence_test.unmarshalOnlySpan)",
+		},
+		"Nil Pointer": {v: (*rme)(nil), errMsg: "sentence: Marshal requires a struct or a pointer to one but got *sentence_test.rme"},
+	} {
+		t.Run(title, func(t *testing.T) {
+			if _, err := sentence.Marshal(vec.v); err == nil || err.Error() != vec.errMsg {
+				t.Errorf("expected error %q but was %v", vec.errMsg, err)
+			}
+		})
+	}
+}
+
+func ExampleUnmarshal() {
+	// RME is Garmin's proprietary estimated error sentence.
+	type RME struct {
+		SentenceType string           `nmea:"0"`
+		HPE          sentence.Float32 `nmea:"1"` // Horizontal position error
+		HPEUnit      string           `nmea:"2"`
+		VPE          sentence.Float32 `nmea:"3"` // Vertical position error
+		VPEUnit      string           `nmea:"4"`
+	}
+
+	var r RME
+	err := sentence.Unmarshal("$PGRME,15.0,M,45.0,M,25.0,M*1C", &r)
+	_ = err
+
+	fmt.Printf("%+v", r)
+	// Output:
+	// {SentenceType:PGRME HPE:{Float32:15 Valid:true} HPEUnit:M VPE:{Float32:45 Valid:true} VPEUnit:M}
+}
+
+func ExampleMarshal() {
+	type RME struct {
+		SentenceType string           `nmea:"0"`
+		HPE          sentence.Float32 `nmea:"1"`
+		HPEUnit      string           `nmea:"2"`
+	}
+
+	s, err := sentence.Marshal(RME{SentenceType: "PGRME", HPE: sentence.NewFloat32(15), HPEUnit: "M"})
+	_ = err
+
+	fmt.Println(s)
+	// Output:
+	// $PGRME,15.0,M*1A
+}
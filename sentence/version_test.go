@@ -0,0 +1,84 @@
+package sentence
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestVersion_String(t *testing.T) {
+	for v, expected := range map[Version]string{
+		Version20:  "2.0",
+		Version23:  "2.3",
+		Version410: "4.10",
+		Version411: "4.11",
+		0:          "Version(0)",
+		9:          "Version(9)",
+	} {
+		if actual := v.String(); actual != expected {
+			t.Errorf("expected %q but was %q", expected, actual)
+		}
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	for _, v := range []Version{Version20, Version23, Version30, Version410, Version411} {
+		if actual, err := ParseVersion(v.String()); err != nil || actual != v {
+			t.Errorf("expected %s but was %s (error %v)", v, actual, err)
+		}
+	}
+
+	expected := "\"4.1\" is not a supported NMEA 0183 version"
+	if _, err := ParseVersion("4.1"); err == nil || err.Error() != expected {
+		t.Errorf("expected error %q but was %v", expected, err)
+	}
+}
+
+func TestVersion_Encodes(t *testing.T) {
+	for _, vec := range []struct {
+		title    string
+		version  Version
+		set      bool
+		expected bool
+	}{
+		{"Same Version", Version23, false, true},
+		{"Later Version", Version410, false, true},
+		{"Earlier Version", Version20, true, false},
+		{"No Version, Set", 0, true, true},
+		{"No Version, Not Set", 0, false, false},
+	} {
+		t.Run(vec.title, func(t *testing.T) {
+			if actual := vec.version.Encodes(Version23, vec.set); actual != vec.expected {
+				t.Errorf("expected %v but was %v", vec.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFieldVersions_Detect(t *testing.T) {
+	versions := FieldVersions{{Version: Version20, Len: 18}, {Version: Version410, Len: 19}}
+	for n, expected := range map[int]Version{10: Version20, 18: Version20, 19: Version410, 25: Version410} {
+		if actual := versions.Detect(n); actual != expected {
+			t.Errorf("expected %s for %d segments but was %s", expected, n, actual)
+		}
+	}
+
+	if actual := (FieldVersions{}).Detect(7); actual != 0 {
+		t.Errorf("expected no version but was %s", actual)
+	}
+}
+
+func TestVersion_JSON(t *testing.T) {
+	encoded, err := json.Marshal(Version410)
+	if err != nil || string(encoded) != `"4.10"` {
+		t.Fatalf("expected \"4.10\" but was %s (error %v)", encoded, err)
+	}
+
+	var v Version
+	if err := json.Unmarshal(encoded, &v); err != nil || v != Version410 {
+		t.Errorf("expected %s but was %s (error %v)", Version410, v, err)
+	}
+
+	if _, err := json.Marshal(Version(0)); err == nil {
+		t.Error("expected an error encoding the zero Version")
+	}
+}
@@ -0,0 +1,247 @@
+package sentence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// --- Public ------------------------------------------------------------------
+
+// Marshaler describes a struct that can encode itself as a valid NMEA sentence (including its
+// start delimiter and checksum).
+type Marshaler interface {
+	// MarshalNMEA encodes the struct as an NMEA sentence string, e.g.
+	// "$GPGLL,3723.2475,N,12158.3416,W,161229.487,A,A*41".
+	MarshalNMEA() (string, error)
+}
+
+// SegmentWriter provides functionality for building an NMEA sentence from individual segments. It
+// is the counterpart of SegmentParser: each Write method appends one segment, and Sentence
+// joins them and appends a freshly calculated checksum. Once a Write method fails, subsequent
+// Write methods do nothing and Sentence returns the error.
+type SegmentWriter struct {
+	segments  []string
+	delimiter StartDelimiter
+	tagBlock  *TagBlock
+	err       error
+}
+
+// Err returns a SegmentWriter's error value.
+func (w *SegmentWriter) Err() error {
+	return w.err
+}
+
+// Sentence returns the NMEA sentence made up of the segments written so far, including the start
+// delimiter ("$" unless WriteStartDelimiter says otherwise) and the "*hh" checksum. If w.Err() is
+// not nil, it returns "" and the error.
+func (w *SegmentWriter) Sentence() (string, error) {
+	if w.err != nil {
+		return "", w.err
+	}
+
+	body := strings.Join(w.segments, ",")
+	delimiter := w.delimiter
+	if delimiter == 0 {
+		delimiter = ParametricDelimiter
+	}
+
+	s := fmt.Sprintf("%s%s*%02X", delimiter, body, Checksum(body))
+
+	if w.tagBlock != nil {
+		s = w.tagBlock.String() + s
+	}
+
+	return s, nil
+}
+
+// WriteStartDelimiter sets the start delimiter that Sentence writes: ParametricDelimiter ("$", the
+// default) or EncapsulationDelimiter ("!"). It does not append a segment.
+func (w *SegmentWriter) WriteStartDelimiter(d StartDelimiter) {
+	if w.err != nil {
+		return
+	}
+
+	if !isStartDelimiter(byte(d)) {
+		w.err = fmt.Errorf("start delimiter must be \"$\" or \"!\" but was \"%s\"", d)
+
+		return
+	}
+
+	w.delimiter = d
+}
+
+// WriteTagBlock sets the tag block (see [TagBlock]) that Sentence writes in front of the sentence.
+// It does not append a segment. A nil tag block writes none.
+func (w *SegmentWriter) WriteTagBlock(tb *TagBlock) {
+	w.tagBlock = tb
+}
+
+// WriteSentenceType appends a standard sentence type (element [0]) made up of the specified talker
+// identifier and sentence formatter, e.g. "GN" and "GGA". If they do not form a valid sentence
+// type (see [SplitSentenceType]), the error is recorded.
+func (w *SegmentWriter) WriteSentenceType(talker, formatter string) {
+	if w.err != nil {
+		return
+	}
+
+	if _, _, err := SplitSentenceType(talker + formatter); err != nil {
+		w.setErr(fmt.Sprintf("must be a valid sentence type but was \"%s\"", talker+formatter))
+
+		return
+	}
+
+	w.append(talker + formatter)
+}
+
+// WriteQueryType appends a query sentence type (element [0]) made up of the talker identifiers of
+// the requester and of the destination followed by "Q", e.g. "GPECQ". If they do not form a valid
+// query sentence type (see [SplitQueryType]), the error is recorded.
+func (w *SegmentWriter) WriteQueryType(requester, destination string) {
+	if w.err != nil {
+		return
+	}
+
+	if _, _, err := SplitQueryType(requester + destination + "Q"); err != nil {
+		w.setErr(fmt.Sprintf("must be a valid query sentence type but was \"%sQ\"",
+			requester+destination))
+
+		return
+	}
+
+	w.append(requester + destination + "Q")
+}
+
+// WriteString appends s as the next segment. s is written verbatim.
+func (w *SegmentWriter) WriteString(s string) {
+	w.append(s)
+}
+
+// WriteFloat32 appends v as the next segment, using the fewest digits necessary to represent it
+// exactly as a float32 but always at least one fractional digit (e.g. "1.6", "-24.1" or "48.0").
+func (w *SegmentWriter) WriteFloat32(v float32) {
+	w.append(formatDecimal(float64(v), 32))
+}
+
+// WriteFloat64 appends v as the next segment, using the fewest digits necessary to represent it
+// exactly as a float64 but always at least one fractional digit.
+func (w *SegmentWriter) WriteFloat64(v float64) {
+	w.append(formatDecimal(v, 64))
+}
+
+// WriteOptionalFloat32 appends v as the next segment like WriteFloat32, or an empty segment if v
+// is not valid.
+func (w *SegmentWriter) WriteOptionalFloat32(v Float32) {
+	if !v.Valid {
+		w.append("")
+
+		return
+	}
+
+	w.WriteFloat32(v.Float32)
+}
+
+// WriteOptionalFloat64 appends v as the next segment like WriteFloat64, or an empty segment if v
+// is not valid.
+func (w *SegmentWriter) WriteOptionalFloat64(v Float64) {
+	if !v.Valid {
+		w.append("")
+
+		return
+	}
+
+	w.WriteFloat64(v.Float64)
+}
+
+// WriteInt appends v as the next segment in base 10.
+func (w *SegmentWriter) WriteInt(v int64) {
+	w.append(strconv.FormatInt(v, 10))
+}
+
+// WriteZeroPaddedInt appends v as the next segment in base 10, left-padded with zeros to at least
+// width digits (e.g. a satellite count of 5 with a width of 2 is written as "05").
+func (w *SegmentWriter) WriteZeroPaddedInt(v int64, width int) {
+	w.append(fmt.Sprintf("%0*d", width, v))
+}
+
+// WriteOptionalInt appends v as the next segment like WriteInt, or an empty segment if v is not
+// valid.
+func (w *SegmentWriter) WriteOptionalInt(v Int) {
+	if !v.Valid {
+		w.append("")
+
+		return
+	}
+
+	w.WriteInt(v.Int)
+}
+
+// WriteOptionalZeroPaddedInt appends v as the next segment like WriteZeroPaddedInt, or an empty
+// segment if v is not valid.
+func (w *SegmentWriter) WriteOptionalZeroPaddedInt(v Int, width int) {
+	if !v.Valid {
+		w.append("")
+
+		return
+	}
+
+	w.WriteZeroPaddedInt(v.Int, width)
+}
+
+// WriteNMEATime appends t as the next segment using its canonical wire encoding (see
+// [NMEATime.String]).
+func (w *SegmentWriter) WriteNMEATime(t NMEATime) {
+	w.append(t.String())
+}
+
+// WriteOptionalNMEATime appends v as the next segment like WriteNMEATime, or an empty segment if v
+// is not valid.
+func (w *SegmentWriter) WriteOptionalNMEATime(v Time) {
+	if !v.Valid {
+		w.append("")
+
+		return
+	}
+
+	w.WriteNMEATime(v.NMEATime)
+}
+
+// WriteEnum appends the wire value of v (as returned by its enumer-generated String method) as the
+// next segment. The caller reports whether v is one of the enum's defined values (typically via
+// the enumer-generated IsA<Type> method); if it is not, the error is recorded.
+func (w *SegmentWriter) WriteEnum(v fmt.Stringer, valid bool) {
+	if w.err != nil {
+		return
+	}
+
+	if !valid {
+		w.setErr(fmt.Sprintf("must be a valid %T but was \"%s\"", v, v))
+
+		return
+	}
+
+	w.append(v.String())
+}
+
+// --- Private -----------------------------------------------------------------
+
+func (w *SegmentWriter) append(s string) {
+	if w.err != nil {
+		return // There's already an error; exit early.
+	}
+
+	w.segments = append(w.segments, s)
+}
+
+func (w *SegmentWriter) setErr(message string) {
+	w.err = &EncodingError{Segment: int8(len(w.segments)), Message: message}
+}
+
+func formatDecimal(v float64, bitSize int) string {
+	s := strconv.FormatFloat(v, 'f', -1, bitSize)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+
+	return s
+}
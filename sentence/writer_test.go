@@ -0,0 +1,194 @@
+package sentence
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSegmentWriter_Sentence(t *testing.T) {
+	w := &SegmentWriter{}
+	w.WriteSentenceType("GP", "GLL")
+	w.WriteLatitude(MustParseLatitude("3723.2475,N"))
+	w.WriteLongitude(MustParseLongitude("12158.3416,W"))
+	w.WriteNMEATime(NMEATime{Hour: 16, Minute: 12, Second: 29, Millisecond: 487})
+	w.WriteString("A")
+	w.WriteString("A")
+
+	actual, err := w.Sentence()
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+
+	expected := "$GPGLL,3723.2475,N,12158.3416,W,161229.487,A,A*41"
+	if actual != expected {
+		t.Errorf("expected %q but was %q", expected, actual)
+	}
+
+	if err := VerifyChecksum(actual); err != nil {
+		t.Errorf("expected a valid checksum but got %v", err)
+	}
+}
+
+func TestSegmentWriter_WriteStartDelimiter(t *testing.T) {
+	w := &SegmentWriter{}
+	w.WriteStartDelimiter(EncapsulationDelimiter)
+	w.WriteSentenceType("AI", "VDM")
+	w.WriteString("1")
+
+	actual, err := w.Sentence()
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+
+	if expected := "!AIVDM,1*4A"; actual != expected {
+		t.Errorf("expected %q but was %q", expected, actual)
+	}
+
+	w = &SegmentWriter{}
+	w.WriteStartDelimiter('#')
+
+	expectedMsg := "start delimiter must be \"$\" or \"!\" but was \"#\""
+	if _, err := w.Sentence(); err == nil || err.Error() != expectedMsg {
+		t.Errorf("expected error %q but was %v", expectedMsg, err)
+	}
+}
+
+func TestSegmentWriter_WriteTagBlock(t *testing.T) {
+	w := &SegmentWriter{}
+	w.WriteTagBlock(&TagBlock{Source: "r3669961", UnixTime: 1503394200})
+	w.WriteSentenceType("GP", "GLL")
+	w.WriteLatitude(MustParseLatitude("3723.2475,N"))
+	w.WriteLongitude(MustParseLongitude("12158.3416,W"))
+	w.WriteNMEATime(NMEATime{Hour: 16, Minute: 12, Second: 29, Millisecond: 487})
+	w.WriteString("A")
+	w.WriteString("A")
+
+	actual, err := w.Sentence()
+	if err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+
+	expected := "\\s:r3669961,c:1503394200*71\\$GPGLL,3723.2475,N,12158.3416,W,161229.487,A,A*41"
+	if actual != expected {
+		t.Errorf("expected %q but was %q", expected, actual)
+	}
+}
+
+func TestSegmentWriter_WriteSentenceType(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		w := &SegmentWriter{}
+		w.WriteSentenceType("GN", "GGA")
+		if actual, _ := w.Sentence(); actual != "$GNGGA*48" {
+			t.Errorf("expected %q but was %q", "$GNGGA*48", actual)
+		}
+	})
+
+	t.Run("Missing Talker", func(t *testing.T) {
+		w := &SegmentWriter{}
+		w.WriteSentenceType("", "GGA")
+
+		expected := "sentence segment [0] must be a valid sentence type but was \"GGA\""
+		if _, err := w.Sentence(); err == nil || err.Error() != expected {
+			t.Errorf("expected error %q but was %v", expected, err)
+		}
+	})
+}
+
+func TestSegmentWriter_WriteQueryType(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		w := &SegmentWriter{}
+		w.WriteQueryType("GP", "EC")
+		if actual, _ := w.Sentence(); actual != "$GPECQ*40" {
+			t.Errorf("expected %q but was %q", "$GPECQ*40", actual)
+		}
+	})
+
+	t.Run("Invalid Destination", func(t *testing.T) {
+		w := &SegmentWriter{}
+		w.WriteQueryType("GP", "E")
+
+		expected := "sentence segment [0] must be a valid query sentence type but was \"GPEQ\""
+		if _, err := w.Sentence(); err == nil || err.Error() != expected {
+			t.Errorf("expected error %q but was %v", expected, err)
+		}
+	})
+}
+
+func TestSegmentWriter_numbers(t *testing.T) {
+	for _, vec := range []struct {
+		title    string
+		write    func(w *SegmentWriter)
+		expected string
+	}{
+		{title: "Float32", write: func(w *SegmentWriter) { w.WriteFloat32(1.6) }, expected: "1.6"},
+		{title: "Float32 Negative", write: func(w *SegmentWriter) { w.WriteFloat32(-24.1) }, expected: "-24.1"},
+		{title: "Float32 Integral", write: func(w *SegmentWriter) { w.WriteFloat32(48) }, expected: "48.0"},
+		{title: "Float64", write: func(w *SegmentWriter) { w.WriteFloat64(0.14) }, expected: "0.14"},
+		{title: "Int", write: func(w *SegmentWriter) { w.WriteInt(-12) }, expected: "-12"},
+		{title: "Zero-Padded Int", write: func(w *SegmentWriter) { w.WriteZeroPaddedInt(5, 2) }, expected: "05"},
+		{title: "Zero-Padded Int (Wide)", write: func(w *SegmentWriter) { w.WriteZeroPaddedInt(201, 2) }, expected: "201"},
+		{title: "Optional Float32", write: func(w *SegmentWriter) { w.WriteOptionalFloat32(NewFloat32(0)) }, expected: "0.0"},
+		{title: "Optional Float32 (Empty)", write: func(w *SegmentWriter) { w.WriteOptionalFloat32(Float32{}) }, expected: ""},
+		{title: "Optional Float64", write: func(w *SegmentWriter) { w.WriteOptionalFloat64(NewFloat64(0.14)) }, expected: "0.14"},
+		{title: "Optional Float64 (Empty)", write: func(w *SegmentWriter) { w.WriteOptionalFloat64(Float64{}) }, expected: ""},
+		{title: "Optional Int", write: func(w *SegmentWriter) { w.WriteOptionalInt(NewInt(0)) }, expected: "0"},
+		{title: "Optional Int (Empty)", write: func(w *SegmentWriter) { w.WriteOptionalInt(Int{}) }, expected: ""},
+		{title: "Optional Zero-Padded Int", write: func(w *SegmentWriter) { w.WriteOptionalZeroPaddedInt(NewInt(0), 4) }, expected: "0000"},
+		{title: "Optional Zero-Padded Int (Empty)", write: func(w *SegmentWriter) { w.WriteOptionalZeroPaddedInt(Int{}, 4) }, expected: ""},
+		{title: "Optional NMEATime", write: func(w *SegmentWriter) { w.WriteOptionalNMEATime(NewTime(NMEATime{})) }, expected: "000000.000"},
+		{title: "Optional NMEATime (Empty)", write: func(w *SegmentWriter) { w.WriteOptionalNMEATime(Time{}) }, expected: ""},
+	} {
+		t.Run(vec.title, func(t *testing.T) {
+			w := &SegmentWriter{}
+			vec.write(w)
+			if len(w.segments) != 1 || w.segments[0] != vec.expected {
+				t.Errorf("expected segments [%q] but were %q", vec.expected, w.segments)
+			}
+		})
+	}
+}
+
+type testEnum int
+
+func (e testEnum) String() string {
+	if e == 1 {
+		return "A"
+	}
+
+	return "testEnum(0)"
+}
+
+func TestSegmentWriter_WriteEnum(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		w := &SegmentWriter{}
+		w.WriteEnum(testEnum(1), true)
+		if w.Err() != nil || len(w.segments) != 1 || w.segments[0] != "A" {
+			t.Errorf("expected segments [\"A\"] and no error but were %q and %v", w.segments, w.Err())
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		w := &SegmentWriter{}
+		w.WriteString("GPTST")
+		w.WriteEnum(testEnum(0), false)
+
+		expected := "sentence segment [1] must be a valid sentence.testEnum but was \"testEnum(0)\""
+		if w.Err() == nil || w.Err().Error() != expected {
+			t.Errorf("expected error %q but was %v", expected, w.Err())
+		}
+	})
+
+	t.Run("Pre-existing Error", func(t *testing.T) {
+		w := &SegmentWriter{}
+		w.WriteEnum(testEnum(0), false)
+		firstErr := w.Err()
+		w.WriteEnum(testEnum(2), false)
+		w.WriteString("A")
+		if !errors.Is(w.Err(), firstErr) {
+			t.Errorf("expected error to remain unchanged but it changed to %v", w.Err())
+		}
+		if len(w.segments) != 0 {
+			t.Errorf("expected no segments to be written after an error but were %q", w.segments)
+		}
+	})
+}
@@ -0,0 +1,681 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/mab-go/nmea/sentence"
+)
+
+// header is the first line of every generated file (see https://go.dev/s/generatedcode).
+const header = "// Code generated by nmeagen. DO NOT EDIT."
+
+// maxLineLength is the length to which generated comments are wrapped, counting a tab as 4.
+const maxLineLength = 100
+
+// File is a generated source file.
+type File struct {
+	Name    string
+	Content []byte
+}
+
+// Generate generates the source files of the sentence package described by spec. specFile is the
+// name of the spec's file (relative to the package directory), for the go:generate directive.
+func Generate(spec *Spec, specFile string) ([]File, error) {
+	data := &templateData{Spec: spec, SpecFile: specFile}
+
+	files := []File{}
+	for _, t := range []struct{ name, tmpl string }{
+		{spec.Package + ".go", sentenceTemplate},
+		{"parser.go", parserTemplate},
+		{"enum.go", enumTemplate},
+		{spec.Package + "_gen_test.go", testTemplate},
+	} {
+		if t.name == "enum.go" && len(spec.Enums) == 0 {
+			continue
+		}
+
+		content, err := execute(t.name, t.tmpl, data)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, File{Name: t.name, Content: content})
+	}
+
+	return files, nil
+}
+
+// GenerateSchemaFile generates the source file that registers the struct of the sentence package
+// described by spec with tools/nmeaschema, so that it publishes the struct's JSON Schema. The
+// file belongs in the directory of tools/nmeaschema, not in that of the package.
+func GenerateSchemaFile(spec *Spec) (File, error) {
+	content, err := execute("schema", schemaTemplate, &templateData{Spec: spec})
+	if err != nil {
+		return File{}, err
+	}
+
+	return File{Name: spec.Package + "_gen.go", Content: content}, nil
+}
+
+// templateData is the data passed to the templates.
+type templateData struct {
+	*Spec
+	SpecFile string
+}
+
+// Var returns the name of the variable that holds the decoded sentence, e.g. "vtg".
+func (d *templateData) Var() string {
+	return d.Package
+}
+
+// Receiver returns the name of the struct's method receiver, e.g. "v".
+func (d *templateData) Receiver() string {
+	return strings.ToLower(d.Type[:1])
+}
+
+// Name returns the name of the sentence type, e.g. "VTG" or "PGRME".
+func (d *templateData) Name() string {
+	if d.Proprietary != nil {
+		return "P" + d.Proprietary.Manufacturer + d.Proprietary.SubType
+	}
+
+	return d.Formatter
+}
+
+// A returns the indefinite article for the struct's name.
+func (d *templateData) A() string {
+	return article(d.Type)
+}
+
+// Delimiter returns the sentence's start delimiter.
+func (d *templateData) Delimiter() string {
+	if d.Encapsulated {
+		return "!"
+	}
+
+	return "$"
+}
+
+// Register returns the call that registers the sentence's Decoder, without its last argument.
+func (d *templateData) Register() string {
+	switch {
+	case d.Proprietary != nil:
+		return fmt.Sprintf("sentence.RegisterProprietary(%q, %q, ", d.Proprietary.Manufacturer,
+			d.Proprietary.SubType)
+	case d.Encapsulated:
+		return fmt.Sprintf("sentence.RegisterEncapsulated(%q, ", d.Formatter)
+	default:
+		return fmt.Sprintf("sentence.Register(%q, ", d.Formatter)
+	}
+}
+
+// PackageDoc returns the package documentation comment.
+func (d *templateData) PackageDoc() string {
+	doc := fmt.Sprintf("Package %s contains data structures and functions related to NMEA "+
+		"sentences of type %q", d.Package, d.Name())
+	if d.Description != "" {
+		doc += " (" + d.Description + ")"
+	}
+
+	switch {
+	case d.Proprietary != nil:
+		doc += fmt.Sprintf(", a proprietary sentence of the manufacturer %q",
+			d.Proprietary.Manufacturer)
+	case len(d.Talkers) > 0:
+		doc += ", as sent by the talkers " + strings.Join(d.Talkers, ", ")
+	default:
+		doc += fmt.Sprintf(", as sent by any talker (e.g. \"%sGP%s\" or \"%sGN%s\")", d.Delimiter(),
+			d.Formatter, d.Delimiter(), d.Formatter)
+	}
+
+	doc += "."
+	if d.Doc != "" {
+		doc += "\n\n" + d.Doc
+	}
+
+	doc += fmt.Sprintf("\n\nThis package is generated by nmeagen (see tools/nmeagen) from %s.",
+		d.SpecFile)
+
+	return comment("", doc)
+}
+
+// FieldNames returns the names of the fields held by each sentence segment, as Go source.
+func (d *templateData) FieldNames() string {
+	names := []string{strconv.Quote("TalkerID")}
+	if d.Proprietary != nil {
+		names[0] = strconv.Quote("SentenceType")
+	}
+
+	for _, f := range d.Fields {
+		for range f.width() {
+			names = append(names, strconv.Quote(f.Name))
+		}
+	}
+
+	return wrap("\t", strings.Join(names, ", ")+",")
+}
+
+// Since returns the fields that have a version, which are the last ones.
+func (d *templateData) Since() []*Field {
+	for i, f := range d.Fields {
+		if f.Since != "" {
+			return d.Fields[i:]
+		}
+	}
+
+	return nil
+}
+
+// SinceCondition returns the condition under which the field f, which has a version, is encoded:
+// the struct's Version has it or, if the Version is unknown, it or one of the fields that follow
+// it is set (see sentence.Version.Encodes).
+func (d *templateData) SinceCondition(f *Field) string {
+	conditions := []string{}
+	for _, g := range d.Fields {
+		if g.index >= f.index {
+			conditions = append(conditions, g.isSet(d.Receiver()+"."+g.Name))
+		}
+	}
+
+	return fmt.Sprintf("%s.Version.Encodes(%s, %s)", d.Receiver(), versionConst(f.version),
+		strings.Join(conditions, " || "))
+}
+
+// Versions returns the elements of the package's sentence.FieldVersions, as Go source.
+func (d *templateData) Versions() string {
+	lines := []string{}
+	for _, v := range d.fieldVersions() {
+		lines = append(lines, fmt.Sprintf("\t{Version: %s, Len: %d},", versionConst(v.Version), v.Len))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// VersionDoc returns the documentation comment of the struct's Version field.
+func (d *templateData) VersionDoc() string {
+	since := []string{}
+	for _, v := range d.fieldVersions()[1:] {
+		since = append(since, v.Version.String())
+	}
+
+	return comment("\t", fmt.Sprintf("Version is the NMEA 0183 version of the %s input, as far "+
+		"as its fields tell: the latest of 2.0 and the versions that added fields (%s) whose "+
+		"fields it has. MarshalNMEA writes the fields of this version; if it is the zero Version, "+
+		"it writes a field that was added later only if it, or a field that follows it, is set. "+
+		"It is not part of the %s input itself.", d.Type, strings.Join(since, ", "), d.Type))
+}
+
+// VersionConst returns the name of the sentence.Version constant of the field f's version.
+func (f *Field) VersionConst() string {
+	return versionConst(f.version)
+}
+
+// fieldVersions returns the package's sentence.FieldVersions: 2.0 has the fields without a
+// version, and each later version those that it added.
+func (d *templateData) fieldVersions() sentence.FieldVersions {
+	since := d.Since()
+	if len(since) == 0 {
+		return nil
+	}
+
+	versions := sentence.FieldVersions{{Version: sentence.Version20, Len: since[0].index}}
+	for _, f := range since {
+		last := &versions[len(versions)-1]
+		if last.Version != f.version {
+			versions = append(versions, sentence.FieldVersion{Version: f.version})
+			last = &versions[len(versions)-1]
+		}
+
+		last.Len = f.index + f.width()
+	}
+
+	return versions
+}
+
+// FieldDoc returns the documentation comment of the struct field f.
+func (d *templateData) FieldDoc(f *Field) string {
+	doc := strings.TrimSpace(f.Doc)
+	if doc != "" && !strings.HasSuffix(doc, ".") {
+		doc += "."
+	}
+
+	if f.width() == 2 {
+		doc += fmt.Sprintf(" It is elements [%d] (the value) and [%d] (the hemisphere) of %s %s "+
+			"input.", f.index, f.index+1, article(d.Type), d.Type)
+	} else {
+		doc += fmt.Sprintf(" It is element [%d] of %s %s input.", f.index, article(d.Type), d.Type)
+	}
+	if f.Since != "" {
+		doc += fmt.Sprintf(" It was added in NMEA 0183 version %s; if the input predates it, it "+
+			"is the zero value.", f.Since)
+	}
+
+	return comment("\t", strings.TrimSpace(doc))
+}
+
+// GoType returns the Go type of the struct field f.
+func (f *Field) GoType() string {
+	switch {
+	case f.enum != nil:
+		return f.enum.Name
+	case f.Optional && f.isInt():
+		return "sentence.Int"
+	case f.Optional:
+		return "sentence.F" + strings.TrimPrefix(f.Type, "f")
+	case f.Type == "time":
+		return "sentence.NMEATime"
+	case f.Type == "latitude", f.Type == "longitude":
+		return "sentence." + strings.ToUpper(f.Type[:1]) + f.Type[1:]
+	default:
+		return f.Type
+	}
+}
+
+// JSONTag returns the json struct tag of the field f: its name in lower camel case and, for an
+// enum (whose zero value has no JSON encoding), the omitzero option.
+func (f *Field) JSONTag() string {
+	opts := ""
+	if f.enum != nil {
+		opts = ",omitzero"
+	}
+
+	return fmt.Sprintf("`json:\"%s%s\"`", jsonName(f.Name), opts)
+}
+
+// Index returns the field's (first) segment index.
+func (f *Field) Index() int {
+	return f.index
+}
+
+// width returns the number of segments that the field f occupies: two for a latitude or
+// longitude (its value and its hemisphere), and one for any other field.
+func (f *Field) width() int {
+	if f.Type == "latitude" || f.Type == "longitude" {
+		return 2
+	}
+
+	return 1
+}
+
+// Decode returns the expression that decodes the field from segments.
+func (f *Field) Decode() string {
+	var call string
+	switch {
+	case f.Literal != "":
+		return fmt.Sprintf("segments.RequireString(%d, %q)", f.index, f.Literal)
+	case f.enum != nil:
+		call = "As" + f.enum.Name
+	case f.Optional && f.isInt():
+		return fmt.Sprintf("segments.AsOptionalInt(%d, %s)", f.index,
+			strings.TrimPrefix(f.Type, "int"))
+	case f.Optional:
+		call = "AsOptionalF" + strings.TrimPrefix(f.Type, "f")
+	case f.Type == "time":
+		call = "AsNMEATime"
+	default:
+		call = "As" + strings.ToUpper(f.Type[:1]) + f.Type[1:]
+	}
+
+	return fmt.Sprintf("segments.%s(%d)", call, f.index)
+}
+
+// Encode returns the statement that writes the field of the struct r to w.
+func (f *Field) Encode(r string) string {
+	v := r + "." + f.Name
+	switch {
+	case f.Literal != "":
+		return fmt.Sprintf("w.WriteString(%q)", f.Literal)
+	case f.enum != nil:
+		return fmt.Sprintf("w.WriteEnum(%s, %s.IsA%s())", v, v, f.enum.Name)
+	case f.Optional && f.isInt() && f.Pad > 0:
+		return fmt.Sprintf("w.WriteOptionalZeroPaddedInt(%s, %d)", v, f.Pad)
+	case f.Optional && f.isInt():
+		return fmt.Sprintf("w.WriteOptionalInt(%s)", v)
+	case f.Optional:
+		return fmt.Sprintf("w.WriteOptionalF%s(%s)", strings.TrimPrefix(f.Type, "f"), v)
+	case f.isInt() && f.Pad > 0:
+		return fmt.Sprintf("w.WriteZeroPaddedInt(int64(%s), %d)", v, f.Pad)
+	case f.isInt():
+		return fmt.Sprintf("w.WriteInt(int64(%s))", v)
+	case f.Type == "time":
+		return fmt.Sprintf("w.WriteNMEATime(%s)", v)
+	default: // WriteFloat32, WriteLatitude, WriteString, etc.
+		return fmt.Sprintf("w.Write%s(%s)", strings.ToUpper(f.Type[:1])+f.Type[1:], v)
+	}
+}
+
+// isSet returns the condition under which the value v of the field f, which has a version, is
+// set.
+func (f *Field) isSet(v string) string {
+	switch {
+	case f.enum != nil:
+		return v + " != 0"
+	case f.Optional, f.width() == 2:
+		return v + ".Valid"
+	default:
+		return v + " != \"\""
+	}
+}
+
+// EnumDoc returns the documentation comment of the enum e.
+func (e *Enum) EnumDoc() string {
+	wires := make([]string, len(e.Values))
+	for i, v := range e.Values {
+		wires[i] = strconv.Quote(v.Wire)
+	}
+
+	doc := strings.TrimSpace(e.Doc)
+	if doc == "" {
+		doc = e.Name + " is an enum."
+	} else if !strings.HasSuffix(doc, ".") {
+		doc += "."
+	}
+
+	switch len(wires) {
+	case 1:
+		doc += " It can only be " + wires[0] + "."
+	case 2:
+		doc += fmt.Sprintf(" It can be either %s or %s.", wires[0], wires[1])
+	default:
+		doc += fmt.Sprintf(" It can be one of %s, or %s.", strings.Join(wires[:len(wires)-1], ", "),
+			wires[len(wires)-1])
+	}
+
+	return comment("", doc)
+}
+
+// ValueDoc returns the documentation comment of the enum value v.
+func (v *EnumValue) ValueDoc() string {
+	doc := strings.TrimSpace(v.Doc)
+	if doc == "" {
+		doc = fmt.Sprintf("%s represents the wire value %q.", v.Name, v.Wire)
+	} else if !strings.HasSuffix(doc, ".") {
+		doc += "."
+	}
+
+	return comment("\t", doc)
+}
+
+// Article returns the indefinite article for the enum's name.
+func (e *Enum) Article() string {
+	return article(e.Name)
+}
+
+// Expected returns the fields of the struct that the example g decodes to, as Go source, in the
+// order of the struct's fields.
+func (d *templateData) Expected(g *GoodExample) ([]string, error) {
+	names := []string{d.talkerField()}
+	for _, f := range d.Fields {
+		names = append(names, f.Name)
+	}
+
+	fields := []string{}
+	for _, name := range names {
+		wire, ok := g.Fields[name]
+		if !ok {
+			continue
+		}
+
+		v, err := d.exampleValue(name, wire)
+		if err != nil {
+			return nil, fmt.Errorf("good example %q: %w", g.Title, err)
+		}
+
+		fields = append(fields, name+": "+v)
+	}
+
+	if versions := d.fieldVersions(); versions != nil {
+		p := &sentence.SegmentParser{}
+		if err := p.Parse(g.Input); err != nil {
+			return nil, fmt.Errorf("good example %q: %w", g.Title, err)
+		}
+
+		fields = append(fields, "Version: "+versionConst(versions.Detect(p.Len())))
+	}
+
+	return fields, nil
+}
+
+// exampleValue returns the Go expression for the value of the field name given in its wire form
+// by an example.
+func (d *templateData) exampleValue(name, wire string) (string, error) {
+	if name == d.talkerField() {
+		return strconv.Quote(wire), nil
+	}
+
+	var f *Field
+	for _, g := range d.Fields {
+		if g.Name == name {
+			f = g
+		}
+	}
+
+	switch {
+	case f.enum != nil:
+		for _, v := range f.enum.Values {
+			if strings.EqualFold(v.Wire, wire) {
+				return v.Name, nil
+			}
+		}
+
+		return "", fmt.Errorf("%q is not a %s", wire, f.enum.Name)
+	case f.Type == "string":
+		return strconv.Quote(wire), nil
+	case f.Type == "time":
+		return timeValue(wire)
+	case f.width() == 2:
+		return coordinateValue(f, wire)
+	}
+
+	if f.isInt() {
+		if _, err := strconv.ParseInt(wire, 10, 64); err != nil {
+			return "", fmt.Errorf("%q is not a valid %s for field %s", wire, f.Type, name)
+		}
+	} else if _, err := strconv.ParseFloat(wire, 64); err != nil {
+		return "", fmt.Errorf("%q is not a valid %s for field %s", wire, f.Type, name)
+	}
+
+	switch {
+	case f.Optional && f.isInt():
+		return "sentence.NewInt(" + wire + ")", nil
+	case f.Optional:
+		return "sentence.NewF" + strings.TrimPrefix(f.Type, "f") + "(" + wire + ")", nil
+	default:
+		return wire, nil
+	}
+}
+
+// coordinateValue returns the Go expression for the sentence.Latitude or sentence.Longitude of the
+// field f with the wire form s (the value and hemisphere segments, e.g. "3723.2475,N").
+func coordinateValue(f *Field, s string) (string, error) {
+	var err error
+	if f.Type == "latitude" {
+		_, err = sentence.ParseLatitude(s)
+	} else {
+		_, err = sentence.ParseLongitude(s)
+	}
+
+	if err != nil || s == "," {
+		return "", fmt.Errorf("%q is not a valid %s (value,hemisphere) for field %s", s, f.Type,
+			f.Name)
+	}
+
+	return fmt.Sprintf("sentence.MustParse%s(%q)", strings.ToUpper(f.Type[:1])+f.Type[1:], s), nil
+}
+
+// timeValue returns the Go expression for the sentence.NMEATime with the wire form s
+// (hhmmss[.sss]).
+func timeValue(s string) (string, error) {
+	intPart, fracPart, hasFrac := strings.Cut(s, ".")
+	n, err := strconv.Atoi(intPart)
+	if err != nil || len(intPart) != 6 || len(fracPart) > 3 || (hasFrac && fracPart == "") {
+		return "", fmt.Errorf("%q is not a valid time (hhmmss.sss)", s)
+	}
+
+	ms := 0
+	if fracPart != "" {
+		if ms, err = strconv.Atoi((fracPart + "00")[:3]); err != nil {
+			return "", fmt.Errorf("%q is not a valid time (hhmmss.sss)", s)
+		}
+	}
+
+	digits := ""
+	switch {
+	case !hasFrac:
+		digits = ", FractionDigits: sentence.NoFractionDigits"
+	case len(fracPart) < 3:
+		digits = fmt.Sprintf(", FractionDigits: %d", len(fracPart))
+	}
+
+	return fmt.Sprintf("sentence.NMEATime{Hour: %d, Minute: %d, Second: %d, Millisecond: %d%s}",
+		n/10000, n/100%100, n%100, ms, digits), nil
+}
+
+// execute executes the template tmpl with data and formats the result as Go source.
+func execute(name, tmpl string, data *templateData) ([]byte, error) {
+	t, err := template.New(name).Funcs(template.FuncMap{
+		"quote":     strconv.Quote,
+		"backquote": backquote,
+	}).Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	var b bytes.Buffer
+	if err := t.Execute(&b, data); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%s: formatting generated source: %w\n%s", name, err, b.String())
+	}
+
+	return reflowComments(src), nil
+}
+
+// backquote returns s as a raw (backquoted) Go string literal if it can be one, and as a quoted
+// Go string literal otherwise.
+func backquote(s string) string {
+	if strconv.CanBackquote(s) {
+		return "`" + s + "`"
+	}
+
+	return strconv.Quote(s)
+}
+
+// reflowComments rewraps each block of consecutive full-line comments in src that has a line
+// longer than maxLineLength.
+func reflowComments(src []byte) []byte {
+	lines := strings.Split(string(src), "\n")
+	out := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); {
+		indent, _, ok := strings.Cut(lines[i], "// ")
+		if !ok || strings.TrimLeft(indent, "\t") != "" {
+			out = append(out, lines[i])
+			i++
+
+			continue
+		}
+
+		j, long := i, false
+		for ; j < len(lines) && (strings.HasPrefix(lines[j], indent+"// ") ||
+			lines[j] == indent+"//"); j++ {
+			long = long || len(lines[j])+3*len(indent) > maxLineLength
+		}
+
+		block := lines[i:j]
+		if long {
+			text := ""
+			for _, l := range block {
+				text += strings.TrimPrefix(strings.TrimPrefix(l, indent+"//"), " ") + "\n"
+			}
+			block = strings.Split(comment(indent, text), "\n")
+		}
+
+		out = append(out, block...)
+		i = j
+	}
+
+	return []byte(strings.Join(out, "\n"))
+}
+
+// article returns "an" if s begins with a vowel and "a" otherwise.
+func article(s string) string {
+	if s != "" && strings.ContainsRune("AEIOUaeiou", rune(s[0])) {
+		return "an"
+	}
+
+	return "a"
+}
+
+// jsonName returns the Go identifier name in lower camel case, treating a run of capitals (with
+// an optional plural "s") as one word, e.g. "talkerId" for "TalkerID", "dgpsUpdateAge" for
+// "DGPSUpdateAge" and "prns" for "PRNs".
+func jsonName(name string) string {
+	isUpper := func(i int) bool { return i < len(name) && name[i] >= 'A' && name[i] <= 'Z' }
+
+	var b strings.Builder
+	word := 0 // The index of the current word
+	for i := range len(name) {
+		if i > 0 && isUpper(i) && (!isUpper(i-1) ||
+			i+1 < len(name) && !isUpper(i+1) && name[i+1:] != "s") {
+			word++
+		} else if i > 0 {
+			b.WriteString(strings.ToLower(name[i : i+1]))
+
+			continue
+		}
+
+		if word == 0 {
+			b.WriteString(strings.ToLower(name[i : i+1]))
+		} else {
+			b.WriteString(strings.ToUpper(name[i : i+1]))
+		}
+	}
+
+	return b.String()
+}
+
+// comment returns text as a line comment indented by indent, with its paragraphs (separated by a
+// blank line) wrapped to maxLineLength.
+func comment(indent, text string) string {
+	paragraphs := strings.Split(strings.TrimSpace(text), "\n\n")
+	for i, p := range paragraphs {
+		paragraphs[i] = wrap(indent+"// ", strings.Join(strings.Fields(p), " "))
+	}
+
+	return strings.Join(paragraphs, "\n"+indent+"//\n")
+}
+
+// wrap wraps the words of text to maxLineLength, prefixing each line with prefix.
+func wrap(prefix, text string) string {
+	width := maxLineLength - len(prefix) - 3*strings.Count(prefix, "\t")
+
+	lines := []string{}
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, prefix+line)
+			line = ""
+		}
+
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+
+	return strings.Join(append(lines, prefix+line), "\n")
+}
+
+// versionConst returns the name of the sentence.Version constant of v, e.g.
+// "sentence.Version23".
+func versionConst(v sentence.Version) string {
+	return "sentence.Version" + strings.ReplaceAll(v.String(), ".", "")
+}
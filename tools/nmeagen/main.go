@@ -0,0 +1,129 @@
+// Command nmeagen generates a sentence package (like those in github.com/mab-go/nmea/sentence)
+// from a declarative YAML (or JSON) definition of a sentence type. It is meant to be run by
+// go:generate from the package's directory:
+//
+//	//go:generate go run github.com/mab-go/nmea/tools/nmeagen vtg.yaml
+//
+// The generated package's main file carries this directive itself, so once a package has been
+// generated, "go generate ./..." keeps it up to date with its definition. It also carries a
+// directive that runs tools/nmeaschema, which publishes the JSON Schema of the sentence struct.
+// For a definition file named vtg.yaml, nmeagen writes:
+//
+//   - vtg.go, with the sentence struct (and the json struct tags of its fields), its
+//     GetSentenceType, Talker, MarshalNMEA, MarshalJSON and UnmarshalJSON methods, Parse,
+//     ParseWithOptions, Decode and DecodeInto, and the registration of its decoder with
+//     sentence.Parse;
+//   - parser.go, with a SegmentParser that has an As<Enum> method for each enum;
+//   - enum.go, with the enum types and the same methods (String, <Enum>String, IsA<Enum>, Values,
+//     JSON and text marshalling, etc.) that enumer generates for the hand-written packages;
+//   - vtg_gen_test.go, with table tests for the definition's example sentences; and
+//   - vtg_gen.go in the directory of tools/nmeaschema, which registers the sentence struct there.
+//
+// A definition looks like this (see sentence/vtg/vtg.yaml for a complete one):
+//
+//	package: vtg
+//	formatter: VTG              # or proprietary: {manufacturer: GRM, subtype: E}
+//	talkers: [GP, GN]           # optional; any talker is accepted by default
+//	encapsulated: false         # true for a "!" sentence
+//	description: course over ground and ground speed
+//	fields:                     # elements [1], [2], ... in order
+//	  - name: TrueTrack
+//	    type: float32           # float32, float64, int8, int16, int32, string, time,
+//	    optional: true          # latitude, longitude, or one of the enums
+//	    doc: TrueTrack is the track made good, in degrees true.
+//	  - name: TrueTrackReference
+//	    literal: T              # a fixed segment, verified but not stored
+//	  - name: Mode
+//	    type: Mode
+//	    since: "2.3"            # the version that added it; such fields come last
+//	enums:
+//	  - name: Mode
+//	    values:
+//	      - {name: AutonomousMode, wire: A}
+//	examples:
+//	  good:
+//	    - title: GPS (GP)
+//	      input: $GPVTG,054.7,T,034.4,M,005.5,N,010.2,K,A*25
+//	      fields: {TalkerID: GP, TrueTrack: "54.7", Mode: A}
+//	      json: '{"sentenceType":"GPVTG","talkerId":"GP","trueTrack":54.7,...}'
+//	  bad:
+//	    - title: Bad Mode
+//	      input: ...
+//	      error: sentence segment [9] must be parsable as a Mode but was "X"
+//
+// Usage:
+//
+//	nmeagen [-o dir] [-schemas dir] spec.yaml
+//
+// The output directory defaults to the directory of spec.yaml, and the directory of
+// tools/nmeaschema defaults to ../../tools/nmeaschema relative to it (the package is
+// github.com/mab-go/nmea/sentence/<package>).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	dir := flag.String("o", "", "the output directory (default: the directory of the spec)")
+	schemaDir := flag.String("schemas", "",
+		"the directory of tools/nmeaschema (default: ../../tools/nmeaschema from the output directory)")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: nmeagen [-o dir] [-schemas dir] spec.yaml")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Arg(0), *dir, *schemaDir); err != nil {
+		fmt.Fprintln(os.Stderr, "nmeagen:", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the package defined by the spec at specPath into dir, and its registration with
+// tools/nmeaschema into schemaDir.
+func run(specPath, dir, schemaDir string) error {
+	spec, err := LoadSpec(specPath)
+	if err != nil {
+		return err
+	}
+
+	if dir == "" {
+		dir = filepath.Dir(specPath)
+	}
+
+	specFile, err := filepath.Rel(dir, specPath)
+	if err != nil {
+		specFile = specPath
+	}
+
+	files, err := Generate(spec, filepath.ToSlash(specFile))
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(dir, f.Name), f.Content, 0o644); err != nil {
+			return err
+		}
+	}
+
+	if schemaDir == "" {
+		schemaDir = filepath.Join(dir, "..", "..", "tools", "nmeaschema")
+	}
+
+	f, err := GenerateSchemaFile(spec)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(schemaDir, f.Name), f.Content, 0o644)
+}
@@ -0,0 +1,414 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// minimalSpec is a valid spec to which the tests append or substitute lines.
+const minimalSpec = `package: tst
+formatter: TST
+fields:
+  - {name: Value, type: float32}
+examples:
+  good:
+    - {title: Good, input: "$GPTST,1.5*42", fields: {TalkerID: GP, Value: "1.5"},
+       json: '{"sentenceType":"GPTST","talkerId":"GP","value":1.5}'}
+`
+
+func writeSpec(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing spec: %v", err)
+	}
+
+	return path
+}
+
+func generate(t *testing.T, content string) map[string]string {
+	t.Helper()
+
+	spec, err := LoadSpec(writeSpec(t, content))
+	if err != nil {
+		t.Fatalf("LoadSpec failed: %v", err)
+	}
+
+	files, err := Generate(spec, "spec.yaml")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	generated := map[string]string{}
+	for _, f := range files {
+		generated[f.Name] = string(f.Content)
+	}
+
+	return generated
+}
+
+func TestGenerate_upToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "sentence", "vtg")
+	spec, err := LoadSpec(filepath.Join(dir, "vtg.yaml"))
+	if err != nil {
+		t.Fatalf("LoadSpec failed: %v", err)
+	}
+
+	files, err := Generate(spec, "vtg.yaml")
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	schemaFile, err := GenerateSchemaFile(spec)
+	if err != nil {
+		t.Fatalf("GenerateSchemaFile failed: %v", err)
+	}
+
+	paths := map[string][]byte{}
+	for _, f := range files {
+		paths[filepath.Join(dir, f.Name)] = f.Content
+	}
+	paths[filepath.Join("..", "nmeaschema", schemaFile.Name)] = schemaFile.Content
+
+	for path, content := range paths {
+		actual, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("reading %s: %v", path, err)
+		}
+
+		if string(actual) != string(content) {
+			t.Errorf("%s is out of date; run \"go generate ./sentence/vtg\"", path)
+		}
+	}
+}
+
+// TestGenerate_directives verifies that go generate, run over the whole module, finds only real
+// directives (and not, e.g., those in the templates of this package).
+func TestGenerate_directives(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping go generate in short mode")
+	}
+
+	cmd := exec.Command("go", "generate", "-n", "./...")
+	cmd.Dir = filepath.Join("..", "..")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("go generate failed: %v\n%s", err, out)
+	}
+
+	nmeagen := 0
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if strings.Contains(line, "{{") {
+			t.Errorf("go generate would run %q", line)
+		}
+
+		if strings.Contains(line, "tools/nmeagen ") {
+			nmeagen++
+		}
+	}
+
+	if nmeagen == 0 {
+		t.Errorf("expected go generate to run nmeagen but it ran:\n%s", out)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("Standard", func(t *testing.T) {
+		files := generate(t, minimalSpec)
+		if _, ok := files["enum.go"]; ok {
+			t.Error("expected no enum.go for a spec without enums")
+		}
+
+		for _, expected := range []string{
+			`sentence.Register("TST", `,
+			`TalkerID: segments.RequireFormatter("TST"), // Verify sentence type`,
+			"tst.Value = segments.AsFloat32(1)",
+			"w.WriteFloat32(t.Value)",
+			`"TalkerID", "Value",`,
+			"Value float32 `json:\"value\"`",
+			"//go:generate go run github.com/mab-go/nmea/tools/nmeaschema tst",
+			"return sentence.MarshalSentenceJSON(t.GetSentenceType(), fields(t))",
+			"//go:generate go run github.com/mab-go/nmea/tools/nmeagen spec.yaml",
+		} {
+			if !strings.Contains(files["tst.go"], expected) {
+				t.Errorf("expected tst.go to contain %q but it did not:\n%s", expected, files["tst.go"])
+			}
+		}
+
+		if !strings.Contains(files["tst_gen_test.go"], `Value:    1.5,`) {
+			t.Errorf("expected tst_gen_test.go to expect Value 1.5:\n%s", files["tst_gen_test.go"])
+		}
+	})
+
+	t.Run("Proprietary", func(t *testing.T) {
+		spec := strings.Replace(minimalSpec, "formatter: TST",
+			"proprietary: {manufacturer: GRM, subtype: E}", 1)
+		files := generate(t, strings.Replace(spec, "TalkerID: GP, ", "", 1))
+
+		for _, expected := range []string{
+			`sentence.RegisterProprietary("GRM", "E", `,
+			`segments.RequireProprietary("GRM", "E") // Verify sentence type`,
+			`w.WriteString("PGRME")`,
+			`return "PGRM"`,
+			`"SentenceType", "Value",`,
+		} {
+			if !strings.Contains(files["tst.go"], expected) {
+				t.Errorf("expected tst.go to contain %q but it did not:\n%s", expected, files["tst.go"])
+			}
+		}
+
+		if strings.Contains(files["tst.go"], "TalkerID") {
+			t.Errorf("expected a proprietary sentence to have no TalkerID:\n%s", files["tst.go"])
+		}
+	})
+
+	t.Run("Talkers and Enums", func(t *testing.T) {
+		spec := strings.Replace(minimalSpec, "  - {name: Value, type: float32}",
+			"  - {name: Value, type: float32}\n  - {name: Status, type: Status}", 1)
+		files := generate(t, strings.Replace(spec, "formatter: TST",
+			"formatter: TST\ntalkers: [GP, GN]\nencapsulated: true", 1)+`
+  bad:
+    - {title: Bad, input: "!GPTST,X*00", error: some error}
+enums:
+  - name: Status
+    values:
+      - {name: Active, wire: A, doc: Active means active.}
+      - {name: Void, wire: V}
+`)
+
+		for name, expected := range map[string]string{
+			"tst.go":          "Status Status `json:\"status,omitzero\"`",
+			"parser.go":       `var talkers = []string{"GP", "GN"}`,
+			"enum.go":         "func (Status) Values() []string {",
+			"tst_gen_test.go": `errMsg: "some error",`,
+		} {
+			if !strings.Contains(files[name], expected) {
+				t.Errorf("expected %s to contain %q but it did not:\n%s", name, expected, files[name])
+			}
+		}
+
+		if !strings.Contains(files["tst.go"], "sentence.RegisterEncapsulated") ||
+			!strings.Contains(files["tst.go"], "w.WriteStartDelimiter(sentence.EncapsulationDelimiter)") {
+			t.Errorf("expected an encapsulation sentence:\n%s", files["tst.go"])
+		}
+
+		if !strings.Contains(files["parser.go"], "func (p *SegmentParser) AsStatus(i int8) Status") {
+			t.Errorf("expected an AsStatus method:\n%s", files["parser.go"])
+		}
+
+		if !strings.Contains(files["tst.go"], "segments.requireTalker(tst.TalkerID)") ||
+			!strings.Contains(files["enum.go"], "Active Status = iota + 1 // A") {
+			t.Errorf("expected the talkers to be required and the enum to start at 1:\n%s\n%s",
+				files["tst.go"], files["enum.go"])
+		}
+	})
+
+	t.Run("Coordinates", func(t *testing.T) {
+		spec := strings.Replace(minimalSpec, "  - {name: Value, type: float32}",
+			"  - {name: Latitude, type: latitude}\n  - {name: Longitude, type: longitude}\n"+
+				"  - {name: Value, type: float32}", 1)
+		files := generate(t, strings.Replace(spec, `input: "$GPTST,1.5*42", fields: {`,
+			`input: "$GPTST,3723.2475,N,12158.3416,W,1.5*65", fields: {Latitude: "3723.2475,N", `, 1))
+
+		for _, expected := range []string{
+			"Latitude sentence.Latitude",
+			"tst.Longitude = segments.AsLongitude(3)",
+			"tst.Value = segments.AsFloat32(5)",
+			"w.WriteLatitude(t.Latitude)",
+			`"TalkerID", "Latitude", "Latitude", "Longitude", "Longitude", "Value",`,
+			"It is elements [1] (the value) and [2] (the hemisphere) of a TST input.",
+		} {
+			if !strings.Contains(files["tst.go"], expected) {
+				t.Errorf("expected tst.go to contain %q but it did not:\n%s", expected, files["tst.go"])
+			}
+		}
+
+		expected := `Latitude: sentence.MustParseLatitude("3723.2475,N"),`
+		if !strings.Contains(files["tst_gen_test.go"], expected) {
+			t.Errorf("expected tst_gen_test.go to contain %q:\n%s", expected, files["tst_gen_test.go"])
+		}
+	})
+
+	t.Run("Since", func(t *testing.T) {
+		files := generate(t, strings.Replace(minimalSpec, "  - {name: Value, type: float32}",
+			"  - {name: Value, type: float32}\n  - {name: Extra, type: string, since: \"4.10\"}", 1))
+
+		for _, expected := range []string{
+			"if tst.Version >= sentence.Version410 { // Added in NMEA 0183 version 4.10",
+			"if t.Version.Encodes(sentence.Version410, t.Extra != \"\") { // Added in NMEA 0183",
+			"It was added in NMEA 0183 version 4.10",
+			"Version sentence.Version `json:\"version,omitzero\"`",
+			"{Version: sentence.Version20, Len: 2},\n\t{Version: sentence.Version410, Len: 3},",
+		} {
+			if !strings.Contains(files["tst.go"], expected) {
+				t.Errorf("expected tst.go to contain %q but it did not:\n%s", expected, files["tst.go"])
+			}
+		}
+
+		if expected := "sentence.Version20,"; !strings.Contains(files["tst_gen_test.go"],
+			expected) {
+			t.Errorf("expected tst_gen_test.go to contain %q but it did not", expected)
+		}
+	})
+}
+
+func TestGenerateSchemaFile(t *testing.T) {
+	spec, err := LoadSpec(writeSpec(t, minimalSpec))
+	if err != nil {
+		t.Fatalf("LoadSpec failed: %v", err)
+	}
+
+	f, err := GenerateSchemaFile(spec)
+	if err != nil {
+		t.Fatalf("GenerateSchemaFile failed: %v", err)
+	}
+
+	if f.Name != "tst_gen.go" {
+		t.Errorf("expected tst_gen.go but was %s", f.Name)
+	}
+
+	for _, expected := range []string{
+		"package main",
+		`import "github.com/mab-go/nmea/sentence/tst"`,
+		`schemas["tst"] = tst.TST{}`,
+	} {
+		if !strings.Contains(string(f.Content), expected) {
+			t.Errorf("expected tst_gen.go to contain %q but it did not:\n%s", expected, f.Content)
+		}
+	}
+}
+
+func TestLoadSpec_errors(t *testing.T) {
+	for title, vec := range map[string]struct{ old, new, errMsg string }{
+		"Bad Package": {
+			old: "package: tst", new: "package: Tst",
+			errMsg: `package must be a lower case Go package name but was "Tst"`,
+		},
+		"Bad Formatter": {
+			old: "formatter: TST", new: "formatter: TSTX",
+			errMsg: `formatter must be three upper case letters but was "TSTX"`,
+		},
+		"Unknown Key": {
+			old: "formatter: TST", new: "formatter: TST\ntalker: GP",
+			errMsg: "field talker not found",
+		},
+		"Unknown Type": {
+			old: "type: float32", new: "type: float",
+			errMsg: `field [1]: type "float" is neither a built-in type nor one of the enums`,
+		},
+		"Optional String": {
+			old: "type: float32", new: "type: string, optional: true",
+			errMsg: "field [1]: only a float or integer field can be optional",
+		},
+		"Padded Float": {
+			old: "type: float32", new: "type: float32, pad: 2",
+			errMsg: "field [1]: only an integer field can be padded, to a positive width",
+		},
+		"Since Not Last": {
+			old:    "  - {name: Value, type: float32}",
+			new:    "  - {name: Mode, type: string, since: \"2.3\"}\n  - {name: Value, type: float32}",
+			errMsg: "field [2]: must have a version (since) no earlier than that of field [1]",
+		},
+		"Since Out of Order": {
+			old: "  - {name: Value, type: float32}",
+			new: "  - {name: Value, type: float32}\n  - {name: A, type: string, since: \"4.10\"}\n" +
+				"  - {name: B, type: string, since: \"2.3\"}",
+			errMsg: "field [3]: must have a version (since) no earlier than that of field [2]",
+		},
+		"Unknown Since": {
+			old:    "  - {name: Value, type: float32}",
+			new:    "  - {name: Value, type: float32}\n  - {name: A, type: string, since: \"2.2\"}",
+			errMsg: `field [2]: since must be one of the NMEA 0183 versions [2.3 3.0 4.10 4.11] after 2.0`,
+		},
+		"Duplicate Name": {
+			old:    "  - {name: Value, type: float32}",
+			new:    "  - {name: Value, type: float32}\n  - {name: Value, literal: K}",
+			errMsg: "field [2]: name Value is used more than once",
+		},
+		"Unknown Example Field": {
+			old: "Value: \"1.5\"", new: "Valu: \"1.5\"",
+			errMsg: `good example "Good": unknown field Valu`,
+		},
+		"Missing Example JSON": {
+			old: `json: '{"sentenceType":"GPTST","talkerId":"GP","value":1.5}'`, new: "json: ''",
+			errMsg: "every good example must have a title, an input and a json encoding",
+		},
+		"Proprietary With Formatter": {
+			old: "formatter: TST", new: "formatter: TST\nproprietary: {manufacturer: GRM, subtype: E}",
+			errMsg: "a proprietary sentence cannot have a formatter or talkers, or be encapsulated",
+		},
+	} {
+		t.Run(title, func(t *testing.T) {
+			content := strings.Replace(minimalSpec, vec.old, vec.new, 1)
+			if content == minimalSpec {
+				t.Fatalf("test is broken: %q is not in the spec", vec.old)
+			}
+
+			_, err := LoadSpec(writeSpec(t, content))
+			if err == nil || !strings.Contains(err.Error(), vec.errMsg) {
+				t.Errorf("expected an error containing %q but was %v", vec.errMsg, err)
+			}
+		})
+	}
+
+	t.Run("Bad Example Value", func(t *testing.T) {
+		spec, err := LoadSpec(writeSpec(t, strings.Replace(minimalSpec, `"1.5"`, `"X"`, 1)))
+		if err != nil {
+			t.Fatalf("LoadSpec failed: %v", err)
+		}
+
+		expected := `good example "Good": "X" is not a valid float32 for field Value`
+		if _, err := Generate(spec, "spec.yaml"); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected an error containing %q but was %v", expected, err)
+		}
+	})
+}
+
+func TestTimeValue(t *testing.T) {
+	for s, expected := range map[string]string{
+		"161229.487": "sentence.NMEATime{Hour: 16, Minute: 12, Second: 29, Millisecond: 487}",
+		"092725.00":  "sentence.NMEATime{Hour: 9, Minute: 27, Second: 25, Millisecond: 0, FractionDigits: 2}",
+		"183730":     "sentence.NMEATime{Hour: 18, Minute: 37, Second: 30, Millisecond: 0, FractionDigits: sentence.NoFractionDigits}",
+	} {
+		if actual, err := timeValue(s); err != nil || actual != expected {
+			t.Errorf("expected timeValue(%q) to be %q but was %q (error %v)", s, expected, actual, err)
+		}
+	}
+
+	if _, err := timeValue("183730."); err == nil {
+		t.Errorf("expected an error for %q", "183730.")
+	}
+}
+
+func TestJSONName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"TalkerID":      "talkerId",
+		"HDOP":          "hdop",
+		"DGPSUpdateAge": "dgpsUpdateAge",
+		"SpeedKmh":      "speedKmh",
+		"AltitudeUOM":   "altitudeUom",
+		"PRNs":          "prns",
+		"X":             "x",
+	} {
+		if actual := jsonName(name); actual != expected {
+			t.Errorf("expected jsonName(%q) to be %q but was %q", name, expected, actual)
+		}
+	}
+}
+
+func TestComment(t *testing.T) {
+	text := "Mode indicates the operating mode of a positioning system, which can be autonomous, " +
+		"differential or estimated.\n\nIt was added in NMEA 0183 version 2.3."
+	expected := "\t// Mode indicates the operating mode of a positioning system, which can be autonomous,\n" +
+		"\t// differential or estimated.\n" +
+		"\t//\n" +
+		"\t// It was added in NMEA 0183 version 2.3."
+
+	if actual := comment("\t", text); actual != expected {
+		t.Errorf("expected\n%s\nbut was\n%s", expected, actual)
+	}
+}
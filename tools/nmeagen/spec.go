@@ -0,0 +1,374 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/mab-go/nmea/sentence"
+	"gopkg.in/yaml.v2"
+)
+
+// Spec is the declarative definition of a sentence type, read from a YAML (or JSON) file.
+type Spec struct {
+	// Package is the name of the generated Go package, e.g. "vtg".
+	Package string `yaml:"package"`
+
+	// Type is the name of the generated struct. It defaults to Package in upper case.
+	Type string `yaml:"type"`
+
+	// Formatter is the sentence formatter of a standard sentence, e.g. "VTG". It is required
+	// unless Proprietary is set.
+	Formatter string `yaml:"formatter"`
+
+	// Talkers, if not empty, restricts the talker identifiers that a standard sentence may have.
+	// By default, any talker is accepted.
+	Talkers []string `yaml:"talkers"`
+
+	// Proprietary describes a proprietary ("$P") sentence instead of a standard one.
+	Proprietary *Proprietary `yaml:"proprietary"`
+
+	// Encapsulated marks an encapsulation ("!") sentence.
+	Encapsulated bool `yaml:"encapsulated"`
+
+	// Doc is the package documentation, which follows the generated "Package x ..." sentence.
+	Doc string `yaml:"doc"`
+
+	// Description is a short description of the sentence type, e.g. "course over ground and
+	// ground speed", used in the package and struct documentation.
+	Description string `yaml:"description"`
+
+	// Fields are the sentence's segments after element [0], in order.
+	Fields []*Field `yaml:"fields"`
+
+	// Enums are the enum types used by Fields.
+	Enums []*Enum `yaml:"enums"`
+
+	// Examples are turned into the generated table tests.
+	Examples Examples `yaml:"examples"`
+}
+
+// Proprietary identifies a proprietary sentence whose sub-type is part of element [0], e.g. "GRM"
+// and "E" for "$PGRME".
+type Proprietary struct {
+	Manufacturer string `yaml:"manufacturer"`
+	SubType      string `yaml:"subtype"`
+}
+
+// Field is one segment of a sentence.
+type Field struct {
+	// Name is the name of the struct field (or, for a Literal, the name used in errors).
+	Name string `yaml:"name"`
+
+	// Type is one of float32, float64, int8, int16, int32, string, time, latitude, longitude or
+	// the name of one of the Spec's Enums. It is ignored for a Literal. A latitude or longitude
+	// occupies two segments, its value and its hemisphere, and is decoded as a sentence.Latitude or
+	// sentence.Longitude.
+	Type string `yaml:"type"`
+
+	// Optional decodes an empty float or integer segment as an invalid sentence.Float32,
+	// sentence.Float64 or sentence.Int rather than reporting an error.
+	Optional bool `yaml:"optional"`
+
+	// Pad is the number of digits to which an integer is zero-padded when it is encoded.
+	Pad int `yaml:"pad"`
+
+	// Literal, if not empty, is the fixed value of the segment (e.g. a unit such as "K"). It is
+	// verified when decoding and written when encoding, but has no struct field.
+	Literal string `yaml:"literal"`
+
+	// Since is the NMEA 0183 version that added the field (e.g. "2.3"; see sentence.Version).
+	// Such fields must come last, in release order. They are decoded only if the sentence has
+	// them, and encoded according to the struct's Version field.
+	Since string `yaml:"since"`
+
+	// Doc documents the struct field.
+	Doc string `yaml:"doc"`
+
+	index   int              // The segment index
+	enum    *Enum            // The enum type, if Type names one
+	version sentence.Version // The version named by Since, if any
+}
+
+// Enum is an enum type whose values are single wire strings.
+type Enum struct {
+	Name   string       `yaml:"name"`
+	Doc    string       `yaml:"doc"`
+	Values []*EnumValue `yaml:"values"`
+}
+
+// EnumValue is one of an Enum's values.
+type EnumValue struct {
+	Name string `yaml:"name"`
+	Wire string `yaml:"wire"`
+	Doc  string `yaml:"doc"`
+}
+
+// Examples are the sentences from which table tests are generated.
+type Examples struct {
+	Good []*GoodExample `yaml:"good"`
+	Bad  []*BadExample  `yaml:"bad"`
+}
+
+// GoodExample is a valid sentence, the values of the fields it decodes to and its exact JSON
+// encoding. Values are given in their wire form (e.g. "161229.487" for a time or "A" for an enum
+// value); fields that are not listed are expected to have their zero value.
+type GoodExample struct {
+	Title  string            `yaml:"title"`
+	Input  string            `yaml:"input"`
+	Fields map[string]string `yaml:"fields"`
+	JSON   string            `yaml:"json"`
+}
+
+// BadExample is an invalid sentence and the error that decoding it yields.
+type BadExample struct {
+	Title string `yaml:"title"`
+	Input string `yaml:"input"`
+	Error string `yaml:"error"`
+}
+
+// LoadSpec reads and validates the Spec in the file at path.
+func LoadSpec(path string) (*Spec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &Spec{}
+	if err := yaml.UnmarshalStrict(b, spec); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := spec.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return spec, nil
+}
+
+var (
+	identifier    = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	packageName   = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+	formatterCode = regexp.MustCompile(`^[A-Z]{3}$`)
+	talkerCode    = regexp.MustCompile(`^[A-Z]{2}$`)
+	subTypeCode   = regexp.MustCompile(`^[A-Z0-9]+$`)
+)
+
+// builtinTypes are the field types other than enums.
+var builtinTypes = map[string]bool{
+	"float32": true, "float64": true, "int8": true, "int16": true, "int32": true,
+	"string": true, "time": true, "latitude": true, "longitude": true,
+}
+
+// validate checks s for errors and fills in its defaults and derived values.
+func (s *Spec) validate() error {
+	if !packageName.MatchString(s.Package) {
+		return fmt.Errorf("package must be a lower case Go package name but was %q", s.Package)
+	}
+
+	if s.Type == "" {
+		s.Type = strings.ToUpper(s.Package)
+	}
+	if !identifier.MatchString(s.Type) {
+		return fmt.Errorf("type must be an exported Go identifier but was %q", s.Type)
+	}
+
+	if err := s.validateSentenceType(); err != nil {
+		return err
+	}
+
+	enums := map[string]*Enum{}
+	for _, e := range s.Enums {
+		if err := e.validate(); err != nil {
+			return err
+		}
+		if enums[e.Name] != nil {
+			return fmt.Errorf("enum %s is defined more than once", e.Name)
+		}
+		enums[e.Name] = e
+	}
+
+	if len(s.Fields) == 0 {
+		return fmt.Errorf("at least one field is required")
+	}
+
+	names := map[string]bool{"TagBlock": true, s.talkerField(): s.talkerField() != ""}
+	index := 1
+	for i, f := range s.Fields {
+		f.index = index
+		if err := f.validate(enums); err != nil {
+			return fmt.Errorf("field [%d]: %w", f.index, err)
+		}
+		if names[f.Name] {
+			return fmt.Errorf("field [%d]: name %s is used more than once", f.index, f.Name)
+		}
+		names[f.Name] = true
+		index += f.width()
+
+		if i > 0 && f.version < s.Fields[i-1].version {
+			return fmt.Errorf("field [%d]: must have a version (since) no earlier than that of "+
+				"field [%d]", f.index, s.Fields[i-1].index)
+		}
+	}
+
+	return s.Examples.validate(s)
+}
+
+func (s *Spec) validateSentenceType() error {
+	if s.Proprietary == nil {
+		if !formatterCode.MatchString(s.Formatter) {
+			return fmt.Errorf("formatter must be three upper case letters but was %q", s.Formatter)
+		}
+
+		for _, t := range s.Talkers {
+			if !talkerCode.MatchString(t) {
+				return fmt.Errorf("talkers must be two upper case letters but one was %q", t)
+			}
+		}
+
+		return nil
+	}
+
+	if s.Formatter != "" || len(s.Talkers) > 0 || s.Encapsulated {
+		return fmt.Errorf("a proprietary sentence cannot have a formatter or talkers, or be " +
+			"encapsulated")
+	}
+
+	if !formatterCode.MatchString(s.Proprietary.Manufacturer) {
+		return fmt.Errorf("manufacturer must be three upper case letters but was %q",
+			s.Proprietary.Manufacturer)
+	}
+
+	if !subTypeCode.MatchString(s.Proprietary.SubType) {
+		return fmt.Errorf("subtype must be upper case letters or digits but was %q",
+			s.Proprietary.SubType)
+	}
+
+	return nil
+}
+
+// talkerField returns the name of the struct field that holds the talker identifier of a standard
+// sentence, or "" for a proprietary sentence (whose element [0] is fixed).
+func (s *Spec) talkerField() string {
+	if s.Proprietary != nil {
+		return ""
+	}
+
+	return "TalkerID"
+}
+
+func (f *Field) validate(enums map[string]*Enum) error {
+	if !identifier.MatchString(f.Name) {
+		return fmt.Errorf("name must be an exported Go identifier but was %q", f.Name)
+	}
+
+	if f.Since != "" {
+		v, err := sentence.ParseVersion(f.Since)
+		if err != nil || v == sentence.Version20 {
+			return fmt.Errorf("since must be one of the NMEA 0183 versions %v after 2.0 but was %q",
+				v.Values()[1:], f.Since)
+		}
+
+		f.version = v
+	}
+
+	if f.Literal != "" {
+		if f.Type != "" || f.Optional || f.Pad != 0 || f.Since != "" {
+			return fmt.Errorf("a literal field cannot have a type, optional, pad or since")
+		}
+
+		return nil
+	}
+
+	f.enum = enums[f.Type]
+	if f.enum == nil && !builtinTypes[f.Type] {
+		return fmt.Errorf("type %q is neither a built-in type nor one of the enums", f.Type)
+	}
+
+	if f.Optional && !f.isNumber() {
+		return fmt.Errorf("only a float or integer field can be optional")
+	}
+
+	if f.Pad != 0 && (!f.isInt() || f.Pad < 0) {
+		return fmt.Errorf("only an integer field can be padded, to a positive width")
+	}
+
+	if f.Since != "" && !f.Optional && f.enum == nil && f.Type != "string" {
+		return fmt.Errorf("a field with a version (since) must be optional, an enum or a string")
+	}
+
+	return nil
+}
+
+func (f *Field) isInt() bool {
+	return f.Type == "int8" || f.Type == "int16" || f.Type == "int32"
+}
+
+func (f *Field) isNumber() bool {
+	return f.isInt() || f.Type == "float32" || f.Type == "float64"
+}
+
+func (e *Enum) validate() error {
+	if !identifier.MatchString(e.Name) || builtinTypes[e.Name] {
+		return fmt.Errorf("enum name must be an exported Go identifier but was %q", e.Name)
+	}
+
+	if len(e.Values) == 0 {
+		return fmt.Errorf("enum %s must have at least one value", e.Name)
+	}
+
+	wires := map[string]bool{}
+	for _, v := range e.Values {
+		if !identifier.MatchString(v.Name) {
+			return fmt.Errorf("enum %s: value name must be an exported Go identifier but was %q",
+				e.Name, v.Name)
+		}
+
+		if v.Wire == "" || strings.ContainsAny(v.Wire, ",*$!\\ ") {
+			return fmt.Errorf("enum %s: value %s must have a wire value without reserved "+
+				"characters",
+				e.Name, v.Name)
+		}
+
+		if wires[strings.ToUpper(v.Wire)] {
+			return fmt.Errorf("enum %s: wire value %q is used more than once", e.Name, v.Wire)
+		}
+		wires[strings.ToUpper(v.Wire)] = true
+	}
+
+	return nil
+}
+
+func (x *Examples) validate(s *Spec) error {
+	if len(x.Good) == 0 {
+		return fmt.Errorf("at least one good example is required")
+	}
+
+	known := map[string]bool{s.talkerField(): s.talkerField() != ""}
+	for _, f := range s.Fields {
+		if f.Literal == "" {
+			known[f.Name] = true
+		}
+	}
+
+	for _, g := range x.Good {
+		if g.Title == "" || g.Input == "" || g.JSON == "" {
+			return fmt.Errorf("every good example must have a title, an input and a json encoding")
+		}
+
+		for name := range g.Fields {
+			if !known[name] {
+				return fmt.Errorf("good example %q: unknown field %s", g.Title, name)
+			}
+		}
+	}
+
+	for _, b := range x.Bad {
+		if b.Title == "" || b.Input == "" || b.Error == "" {
+			return fmt.Errorf("every bad example must have a title, an input and an error")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,541 @@
+package main
+
+// sentenceTemplate generates the sentence struct, its methods, and its decoder. Its go:generate
+// directives are written as template strings so that go generate does not run them here.
+const sentenceTemplate = header + `
+
+{{.PackageDoc}}
+package {{.Package}} // import "github.com/mab-go/nmea/sentence/{{.Package}}"
+
+import (
+	"encoding/json"
+
+	"github.com/mab-go/nmea/sentence"
+)
+
+{{"//go:generate"}} go run github.com/mab-go/nmea/tools/nmeagen {{.SpecFile}}
+{{"//go:generate"}} go run github.com/mab-go/nmea/tools/nmeaschema {{.Package}}
+
+{{$r := .Receiver -}}
+// {{.Type}} represents an NMEA input of type "{{.Name}}"{{if .Description}} ({{.Description}}){{end}}.
+type {{.Type}} struct {
+{{- if not .Proprietary}}
+	// TalkerID identifies the talker that sent the input (e.g. "GP" or "GN"). It is the first two
+	// characters of element [0] of {{.A}} {{.Type}} input.
+	TalkerID string ` + "`" + `json:"talkerId"` + "`" + `
+{{end}}
+{{- range .Fields}}{{if not .Literal}}
+{{$.FieldDoc .}}
+	{{.Name}} {{.GoType}} {{.JSONTag}}
+{{end}}{{end}}
+{{- if .Since}}
+{{.VersionDoc}}
+	Version sentence.Version ` + "`" + `json:"version,omitzero"` + "`" + `
+{{end}}
+	// TagBlock is the tag block (see [sentence.TagBlock]) that preceded the {{.Type}} input, or nil if
+	// there was none. It is not part of the {{.Type}} input itself.
+	TagBlock *sentence.TagBlock ` + "`" + `json:"tagBlock,omitzero"` + "`" + `
+}
+
+{{if .Proprietary -}}
+// GetSentenceType returns the type of NMEA input represented by the struct {{.Type}}. It always
+// returns "{{.Name}}". It represents element [0] of {{.A}} {{.Type}} input.
+func ({{$r}} {{.Type}}) GetSentenceType() string {
+	return "{{.Name}}"
+}
+
+// Talker returns "P" followed by the manufacturer code of the {{.Type}} input, i.e. "P{{.Proprietary.Manufacturer}}".
+func ({{$r}} {{.Type}}) Talker() string {
+	return "P{{.Proprietary.Manufacturer}}"
+}
+{{- else -}}
+// GetSentenceType returns the type of NMEA input represented by the struct {{.Type}}: its talker
+// identifier followed by "{{.Formatter}}" (e.g. "GN{{.Formatter}}"). It represents element [0] of
+{{- " "}}{{.A}} {{.Type}} input.
+func ({{$r}} {{.Type}}) GetSentenceType() string {
+	return {{$r}}.TalkerID + "{{.Formatter}}"
+}
+
+// Talker returns the talker identifier of the {{.Type}} input (e.g. "GP" or "GN").
+func ({{$r}} {{.Type}}) Talker() string {
+	return {{$r}}.TalkerID
+}
+{{- end}}
+
+// MarshalNMEA encodes {{$r}} as {{.A}} {{.Type}} input (including its checksum)
+{{- if .Since}}, with the fields of {{$r}}.Version{{end}}.
+{{- if not .Proprietary}} It returns an error if {{$r}}.TalkerID is not a valid talker
+{{- " "}}identifier{{end}}
+{{- if .Enums}} or if an enum field does not hold one of its defined values{{end}}.
+func ({{$r}} {{.Type}}) MarshalNMEA() (string, error) {
+	w := &sentence.SegmentWriter{}
+{{- if .Encapsulated}}
+	w.WriteStartDelimiter(sentence.EncapsulationDelimiter)
+{{- end}}
+	w.WriteTagBlock({{$r}}.TagBlock)
+{{- if .Proprietary}}
+	w.WriteString("{{.Name}}")
+{{- else}}
+	w.WriteSentenceType({{$r}}.TalkerID, "{{.Formatter}}")
+{{- end}}
+{{- range .Fields}}{{if not .Since}}
+	{{.Encode $r}}
+{{- end}}{{end}}
+{{- range .Since}}
+
+	if {{$.SinceCondition .}} { // Added in NMEA 0183 version {{.Since}}
+		{{.Encode $r}}
+	}
+{{- end}}
+
+	return w.Sentence()
+}
+
+// MarshalJSON encodes {{$r}} as a JSON object whose members are "sentenceType" (e.g.
+{{- " "}}"{{if .Proprietary}}{{.Name}}{{else}}GN{{.Formatter}}{{end}}") and the
+// fields of {{$r}}, named by their json struct tags. See [sentence.MarshalSentenceJSON] for the
+{{- " "}}encoding
+// of each type of field, and {{.Package}}.schema.json for its JSON Schema.
+func ({{$r}} {{.Type}}) MarshalJSON() ([]byte, error) {
+	type fields {{.Type}} // Has the fields of {{.Type}}, but not its MarshalJSON method
+
+	return sentence.MarshalSentenceJSON({{$r}}.GetSentenceType(), fields({{$r}}))
+}
+
+// UnmarshalJSON decodes a JSON object encoded by MarshalJSON into {{$r}}, which is only modified if
+// data is valid. Its "sentenceType" member may be omitted.
+func ({{$r}} *{{.Type}}) UnmarshalJSON(data []byte) error {
+	type fields {{.Type}}
+
+	var decoded {{.Type}}
+	if err := sentence.UnmarshalSentenceJSON(data, (*fields)(&decoded), &decoded); err != nil {
+		return err
+	}
+
+	*{{$r}} = decoded
+
+	return nil
+}
+
+// Ensure that {{.Type}} properly implements the NMEASentence, Marshaler and JSON interfaces
+var (
+	_ sentence.NMEASentence = {{.Type}}{}
+	_ sentence.Marshaler    = {{.Type}}{}
+	_ json.Marshaler        = {{.Type}}{}
+	_ json.Unmarshaler      = (*{{.Type}})(nil)
+)
+
+func init() {
+	{{.Register}}func(p *sentence.SegmentParser) (sentence.NMEASentence, error) {
+		{{.Var}}, err := Decode(p)
+		if err != nil {
+			return nil, err
+		}
+
+		return {{.Var}}, nil
+	})
+}
+
+// Parse parses {{.A}} {{.Type}} input string{{if not .Proprietary}} from any talker{{end}} and
+{{- " "}}returns a pointer to {{.A}} {{.Type}} struct
+// (or an error if the input is invalid).
+func Parse(s string) (*{{.Type}}, error) {
+	return ParseWithOptions(s)
+}
+
+// ParseWithOptions is like Parse, but parses the input according to opts (see
+// [sentence.ParseOptions]), e.g. to accept an input that has no checksum. With no options, it
+// is identical to Parse.
+func ParseWithOptions(s string, opts ...sentence.ParseOption) (*{{.Type}}, error) {
+	p := &sentence.SegmentParser{}
+	if err := p.ParseWithOptions(s, opts...); err != nil {
+		return nil, err
+	}
+
+	return Decode(p)
+}
+
+// Decode decodes {{.A}} {{.Type}} input from p, which must already have parsed the input (see
+// [sentence.SegmentParser.Parse]). It returns a pointer to {{.A}} {{.Type}} struct (or an error if the input
+// is invalid).
+func Decode(p *sentence.SegmentParser) (*{{.Type}}, error) {
+	{{.Var}} := &{{.Type}}{}
+	if err := DecodeInto(p, {{.Var}}); err != nil {
+		return nil, err
+	}
+
+	return {{.Var}}, nil
+}
+
+// DecodeInto is like Decode, but decodes the sentence into dst, which is only modified if the
+// sentence is valid.
+func DecodeInto(p *sentence.SegmentParser, dst *{{.Type}}) error {
+	segments := &SegmentParser{SegmentParser: *p}
+	segments.NameFields(fieldNames...)
+{{- if .Encapsulated}}
+	segments.RequireStartDelimiter(sentence.EncapsulationDelimiter)
+{{- end}}
+{{- if .Proprietary}}
+	segments.RequireProprietary("{{.Proprietary.Manufacturer}}", "{{.Proprietary.SubType}}") // Verify sentence type
+	{{.Var}} := {{.Type}}{TagBlock: p.TagBlock()}
+{{- else}}
+	{{.Var}} := {{.Type}}{
+		TalkerID: segments.RequireFormatter("{{.Formatter}}"), // Verify sentence type
+		TagBlock: p.TagBlock(),
+	}
+{{- if .Talkers}}
+	segments.requireTalker({{.Var}}.TalkerID)
+{{- end}}
+{{- end}}
+{{- range .Fields}}{{if not .Since}}
+	{{if not .Literal}}{{$.Var}}.{{.Name}} = {{end}}{{.Decode}}
+{{- end}}{{end}}
+{{- if .Since}}
+	{{.Var}}.Version = versions.Detect(segments.Len())
+{{- end}}
+{{- range .Since}}
+
+	if {{$.Var}}.Version >= {{.VersionConst}} { // Added in NMEA 0183 version {{.Since}}
+		{{$.Var}}.{{.Name}} = {{.Decode}}
+	}
+{{- end}}
+
+	if err := segments.Err(); err != nil {
+		return err
+	}
+
+	*dst = {{.Var}}
+
+	return nil
+}
+
+{{if .Since -}}
+// versions declares the NMEA 0183 versions of the {{.Type}} fields.
+var versions = sentence.FieldVersions{
+{{.Versions}}
+}
+
+{{end -}}
+// fieldNames names the {{.Type}} field held by each sentence segment, for use in errors (see
+// [sentence.FieldError]).
+var fieldNames = []string{
+{{.FieldNames}}
+}
+`
+
+// parserTemplate generates the package's SegmentParser and its enum accessors.
+const parserTemplate = header + `
+
+package {{.Package}}
+
+import (
+{{- if or .Enums .Talkers}}
+	"fmt"
+{{- end}}
+{{- if .Talkers}}
+	"slices"
+{{- end}}
+
+	"github.com/mab-go/nmea/sentence"
+)
+
+// SegmentParser extends sentence.SegmentParser to provide {{.Type}}-specific segment parsing methods.
+type SegmentParser struct {
+	sentence.SegmentParser
+}
+{{range .Enums}}
+// As{{.Name}} parses the input segment at the specified index as {{.Article}} {{.Name}} value. If p.Err()
+// is not nil, this function returns {{.Name}}(0) and leaves the error unchanged.
+func (p *SegmentParser) As{{.Name}}(i int8) {{.Name}} {
+	s, ok := p.Segment(i)
+	if !ok {
+		return {{.Name}}(0)
+	}
+
+	v, err := {{.Name}}String(s)
+	if err != nil || !p.AcceptsCase(s, v.String()) {
+		p.Fail(i, "{{.Name}}", fmt.Sprintf("must be parsable as {{.Article}} {{.Name}} but was \"%s\"", s))
+
+		return {{.Name}}(0)
+	}
+
+	return v
+}
+{{end}}
+{{- if .Talkers}}
+// talkers are the talker identifiers that {{.A}} {{.Type}} input may have.
+var talkers = []string{ {{- range $i, $t := .Talkers}}{{if $i}}, {{end}}{{quote $t}}{{end -}} }
+
+// requireTalker ensures that talker, the talker identifier of the input, is one of talkers.
+func (p *SegmentParser) requireTalker(talker string) {
+	if talker == "" || slices.Contains(talkers, talker) {
+		return // The sentence type is invalid (and has already failed) or the talker is allowed
+	}
+
+	p.Fail(0, fmt.Sprintf("one of %v", talkers),
+		fmt.Sprintf("must have one of the talker identifiers %v but was \"%s\"", talkers, talker))
+}
+{{end}}`
+
+// enumTemplate generates the package's enum types and the methods that enumer generates for the
+// hand-written sentence packages (with the flags -json, -text, -values and -linecomment).
+const enumTemplate = header + `
+
+package {{.Package}}
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+{{range $e := .Enums}}
+{{.EnumDoc}}
+type {{.Name}} int
+
+const (
+{{- range $i, $v := .Values}}
+{{.ValueDoc}}
+	{{.Name}}{{if not $i}} {{$e.Name}} = iota + 1{{end}} // {{.Wire}}
+{{end -}}
+)
+
+var _{{.Name}}Values = []{{.Name}}{
+{{- range .Values}}
+	{{.Name}},
+{{- end}}
+}
+
+// String returns the wire value of i (e.g. {{quote (index .Values 0).Wire}}), or "{{.Name}}(n)" if i is not one of the
+// defined {{.Name}} values.
+func (i {{.Name}}) String() string {
+	switch i {
+{{- range .Values}}
+	case {{.Name}}:
+		return {{quote .Wire}}
+{{- end}}
+	}
+
+	return fmt.Sprintf("{{.Name}}(%d)", int(i))
+}
+
+// {{.Name}}String returns the {{.Name}} value whose wire value is s (case-insensitive). It returns an error if
+// there is none.
+func {{.Name}}String(s string) ({{.Name}}, error) {
+	for _, v := range _{{.Name}}Values {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%s does not belong to {{.Name}} values", s)
+}
+
+// {{.Name}}Values returns all values of the enum.
+func {{.Name}}Values() []{{.Name}} {
+	return append([]{{.Name}}(nil), _{{.Name}}Values...)
+}
+
+// {{.Name}}Strings returns the wire values of all values of the enum.
+func {{.Name}}Strings() []string {
+	strs := make([]string, len(_{{.Name}}Values))
+	for i, v := range _{{.Name}}Values {
+		strs[i] = v.String()
+	}
+
+	return strs
+}
+
+// IsA{{.Name}} returns true if the value is listed in the enum definition, and false otherwise.
+func (i {{.Name}}) IsA{{.Name}}() bool {
+	return i >= {{(index .Values 0).Name}} && int(i) <= len(_{{.Name}}Values)
+}
+
+// Values returns the wire values of all values of the enum.
+func ({{.Name}}) Values() []string {
+	return {{.Name}}Strings()
+}
+
+// MarshalJSON implements the json.Marshaler interface for {{.Name}}.
+func (i {{.Name}}) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for {{.Name}}.
+func (i *{{.Name}}) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("{{.Name}} should be a string, got %s", data)
+	}
+
+	var err error
+	*i, err = {{.Name}}String(s)
+
+	return err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for {{.Name}}.
+func (i {{.Name}}) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for {{.Name}}.
+func (i *{{.Name}}) UnmarshalText(text []byte) error {
+	var err error
+	*i, err = {{.Name}}String(string(text))
+
+	return err
+}
+{{end}}`
+
+// schemaTemplate generates the registration of the package's struct with tools/nmeaschema.
+const schemaTemplate = header + `
+
+package main
+
+import "github.com/mab-go/nmea/sentence/{{.Package}}"
+
+func init() {
+	schemas[{{quote .Package}}] = {{.Package}}.{{.Type}}{}
+}
+`
+
+// testTemplate generates the package's table tests from the spec's examples.
+const testTemplate = header + `
+
+package {{.Package}}
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mab-go/nmea/sentence"
+)
+
+type testVec struct {
+	input    string
+	expected {{.Type}}
+	json     string
+	errMsg   string
+}
+
+var goodTestData = map[string]testVec{
+{{- range .Examples.Good}}
+	{{quote .Title}}: {
+		input: {{quote .Input}},
+		json: {{backquote .JSON}},
+		expected: {{$.Type}}{
+{{- range $.Expected .}}
+			{{.}},
+{{- end}}
+		},
+	},
+{{- end}}
+}
+
+var badTestData = map[string]testVec{
+{{- range .Examples.Bad}}
+	{{quote .Title}}: {
+		input:  {{quote .Input}},
+		errMsg: {{quote .Error}},
+	},
+{{- end}}
+}
+
+func TestParse_goodData(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			actual, err := Parse(vec.input)
+			if err != nil {
+				t.Fatalf("error creating {{.Type}} from NMEA input \"%v\": %v", title, err)
+			}
+
+			if *actual != vec.expected {
+				t.Errorf("result should have been %+v but was %+v", vec.expected, *actual)
+			}
+		})
+	}
+}
+
+func TestParse_badSegments(t *testing.T) {
+	for title, vec := range badTestData {
+		t.Run(title, func(t *testing.T) {
+			actual, err := Parse(vec.input)
+			if err == nil {
+				t.Fatalf("parsing succeeded (but should not have) for test sentence %q", title)
+			}
+
+			if actual != nil {
+				t.Fatalf("result should have been <nil> but was %v for test sentence %q", actual, title)
+			}
+
+			if err.Error() != vec.errMsg {
+				t.Fatalf("error message should have been '%v' but was '%v'", vec.errMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestParse_registered(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			s, err := sentence.Parse(vec.input)
+			if err != nil {
+				t.Fatalf("sentence.Parse failed: %v", err)
+			}
+
+			actual, ok := s.(*{{.Type}})
+			if !ok {
+				t.Fatalf("sentence.Parse should have returned a *{{.Type}} but returned %T", s)
+			}
+
+			if *actual != vec.expected {
+				t.Errorf("sentence.Parse result should have been %+v but was %+v", vec.expected, *actual)
+			}
+		})
+	}
+}
+
+func Test{{.Type}}_MarshalNMEA_roundTrips(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			encoded, err := vec.expected.MarshalNMEA()
+			if err != nil {
+				t.Fatalf("MarshalNMEA failed: %v", err)
+			}
+
+			actual, err := Parse(encoded)
+			if err != nil {
+				t.Fatalf("error re-parsing %q: %v", encoded, err)
+			}
+
+			if *actual != vec.expected {
+				t.Errorf("round trip through %q should have produced %+v but produced %+v", encoded, vec.expected, *actual)
+			}
+		})
+	}
+}
+
+func Test{{.Type}}_MarshalJSON(t *testing.T) {
+	for title, vec := range goodTestData {
+		t.Run(title, func(t *testing.T) {
+			encoded, err := json.Marshal(vec.expected)
+			if err != nil {
+				t.Fatalf("json.Marshal failed: %v", err)
+			}
+
+			if string(encoded) != vec.json {
+				t.Errorf("expected %s but was %s", vec.json, encoded)
+			}
+
+			var actual {{.Type}}
+			if err := json.Unmarshal([]byte(vec.json), &actual); err != nil {
+				t.Fatalf("error decoding %s: %v", vec.json, err)
+			}
+
+			if actual != vec.expected {
+				t.Errorf("decoding %s should have produced %+v but produced %+v", vec.json, vec.expected, actual)
+			}
+		})
+	}
+}
+`
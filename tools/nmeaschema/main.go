@@ -0,0 +1,89 @@
+// Command nmeaschema writes the JSON Schema (see sentence.JSONSchema) of the JSON encoding of a
+// sentence package's struct to <package>.schema.json. It is meant to be run by go:generate from
+// the package's directory:
+//
+//	//go:generate go run github.com/mab-go/nmea/tools/nmeaschema gga
+//
+// Usage:
+//
+//	nmeaschema [-o dir] package...
+//
+// The output directory defaults to the current directory. A package must be one of the sentence
+// packages of this module, or "raw" or "query" for the sentence package's RawSentence and Query
+// (whose schemas are written to raw.schema.json and query.schema.json). The hand-written packages
+// are listed in schemas; each package generated by tools/nmeagen registers itself in a
+// <package>_gen.go file of this directory, which nmeagen writes.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mab-go/nmea/sentence"
+	"github.com/mab-go/nmea/sentence/gga"
+	"github.com/mab-go/nmea/sentence/gll"
+	"github.com/mab-go/nmea/sentence/gpgga"
+	"github.com/mab-go/nmea/sentence/gpgll"
+	"github.com/mab-go/nmea/sentence/gpgsa"
+	"github.com/mab-go/nmea/sentence/gsa"
+)
+
+// schemas holds the struct of each sentence package, by package name, and the RawSentence and
+// Query of the sentence package. The generated packages add themselves in their init functions.
+var schemas = map[string]sentence.NMEASentence{
+	"gga":   gga.GGA{},
+	"gll":   gll.GLL{},
+	"gpgga": gpgga.GPGGA{},
+	"gpgll": gpgll.GPGLL{},
+	"gpgsa": gpgsa.GPGSA{},
+	"gsa":   gsa.GSA{},
+	"query": sentence.Query{},
+	"raw":   sentence.RawSentence{},
+}
+
+func main() {
+	dir := flag.String("o", ".", "the output directory")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: nmeaschema [-o dir] package...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	for _, pkg := range flag.Args() {
+		if err := run(pkg, *dir); err != nil {
+			fmt.Fprintln(os.Stderr, "nmeaschema:", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// run writes the JSON Schema of the package pkg to dir.
+func run(pkg, dir string) error {
+	content, err := Schema(pkg)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, pkg+".schema.json"), content, 0o644)
+}
+
+// Schema returns the JSON Schema of the struct of the sentence package pkg.
+func Schema(pkg string) ([]byte, error) {
+	s, ok := schemas[pkg]
+	if !ok {
+		return nil, fmt.Errorf("package must be one of %s but was %q",
+			strings.Join(slices.Sorted(maps.Keys(schemas)), ", "), pkg)
+	}
+
+	return sentence.JSONSchema(s)
+}
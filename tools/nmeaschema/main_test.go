@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// schemaFile returns the path of the schema file of pkg: beside the source of the package, or of
+// the sentence package for its RawSentence and Query.
+func schemaFile(pkg string) string {
+	if pkg == "raw" || pkg == "query" {
+		return filepath.Join("..", "..", "sentence", pkg+".schema.json")
+	}
+
+	return filepath.Join("..", "..", "sentence", pkg, pkg+".schema.json")
+}
+
+func TestSchema_upToDate(t *testing.T) {
+	for pkg := range schemas {
+		expected, err := Schema(pkg)
+		if err != nil {
+			t.Fatalf("Schema(%q) failed: %v", pkg, err)
+		}
+
+		actual, err := os.ReadFile(schemaFile(pkg))
+		if err != nil {
+			t.Fatalf("reading the schema of %s: %v", pkg, err)
+		}
+
+		if string(actual) != string(expected) {
+			t.Errorf("%s.schema.json is out of date; run \"go generate ./sentence/%s\"", pkg, pkg)
+		}
+	}
+}
+
+func TestSchema_everyFileGenerated(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "sentence", "*", "*.schema.json"))
+	if err != nil {
+		t.Fatalf("listing the schema files: %v", err)
+	}
+
+	sentenceFiles, err := filepath.Glob(filepath.Join("..", "..", "sentence", "*.schema.json"))
+	if err != nil {
+		t.Fatalf("listing the schema files: %v", err)
+	}
+
+	files = append(files, sentenceFiles...)
+	for _, f := range files {
+		pkg := strings.TrimSuffix(filepath.Base(f), ".schema.json")
+		if _, ok := schemas[pkg]; !ok || f != schemaFile(pkg) {
+			t.Errorf("%s is not generated by nmeaschema", f)
+		}
+	}
+
+	if len(files) != len(schemas) {
+		t.Errorf("expected %d schema files but found %d", len(schemas), len(files))
+	}
+}
+
+func TestSchema_unknownPackage(t *testing.T) {
+	_, err := Schema("rmc")
+	if err == nil || !strings.Contains(err.Error(), `package must be one of gga, gll, `) {
+		t.Errorf("expected an unknown package error but was %v", err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	if err := run("vtg", dir); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "vtg.schema.json"))
+	if err != nil || !strings.Contains(string(content), `"title": "vtg.VTG"`) {
+		t.Errorf("expected vtg.schema.json to be written but was %q (%v)", content, err)
+	}
+}